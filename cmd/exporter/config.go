@@ -33,26 +33,48 @@ func LoadConfig() config.Config {
 
 	//collector
 	a.Flag("web.disable-exporter-metrics", "Exclude metrics about the exporter itself (promhttp_*, process_*, go_*).").BoolVar(&cfg.CollectorConfig.DisableExporterMetrics)
-	a.Flag("collector.intrinsec", "Enable intrinsec specific features").Default("false").BoolVar(&cfg.CollectorConfig.UseIsecSpecifics)
+	useIsecSpecifics := a.Flag("collector.intrinsec", "Deprecated: same as --collector.annotation.format=json --collector.annotation.label=crit --collector.annotation.label=resp=responsable --collector.annotation.label=svc=service --collector.annotation.default_value='not defined'").Default("false").Bool()
+	a.Flag("collector.tag_label_mode", "How multiple tags of the same category are exported. join: tags are joined in one label value, series: one series per tag, info: tags are exported in a separate govc_tag_info metric").Default(config.TagLabelModeJoin).EnumVar(&cfg.CollectorConfig.TagLabelMode, config.TagLabelModeJoin, config.TagLabelModeSeries, config.TagLabelModeInfo)
+	a.Flag("collector.tag_label_separator", "Separator used to join multiple tags of the same category when collector.tag_label_mode=join").Default(",").StringVar(&cfg.CollectorConfig.TagLabelSeparator)
+	a.Flag("collector.folder_path", "Add the inventory folder path as folder_path label to vm, host and datastore metrics").Default("false").BoolVar(&cfg.CollectorConfig.FolderPathLabel)
+
+	//collector.annotation
+	a.Flag("collector.annotation.format", "Format of the annotation (notes) of objects. One of: [none, kv, json, yaml, regex]").Default(config.AnnotationFormatNone).EnumVar(&cfg.CollectorConfig.AnnotationFormat, config.AnnotationFormatNone, config.AnnotationFormatKV, config.AnnotationFormatJSON, config.AnnotationFormatYAML, config.AnnotationFormatRegex)
+	a.Flag("collector.annotation.regex", "Regex with named groups used to parse the annotation when collector.annotation.format=regex").StringVar(&cfg.CollectorConfig.AnnotationRegex)
+	a.Flag("collector.annotation.label", "Key in the annotation which will be added as label in metrics. Use key=label to use a different label name").StringsVar(&cfg.CollectorConfig.AnnotationLabels)
+	a.Flag("collector.annotation.default_value", "Label value used when a key is not found in the annotation").Default("").StringVar(&cfg.CollectorConfig.AnnotationDefaultValue)
 
 	//collector.cluster
 	a.Flag("collector.cluster.tag_label", "List of vmware tag categories to collect which will be added as label in metrics").StringsVar(&cfg.CollectorConfig.ClusterTagLabels)
+	a.Flag("collector.cluster.attribute_label", "List of vmware custom attributes which will be added as label in metrics").StringsVar(&cfg.CollectorConfig.ClusterAttributeLabels)
 
 	//collector.datastore
 	a.Flag("collector.datastore.tag_label", "List of vmware tag categories to collect which will be added as label in metrics").StringsVar(&cfg.CollectorConfig.DatastoreTagLabels)
+	a.Flag("collector.datastore.attribute_label", "List of vmware custom attributes which will be added as label in metrics").StringsVar(&cfg.CollectorConfig.DatastoreAttributeLabels)
+	a.Flag("collector.datastore.vm_info", "Export which vm's and templates are stored on each datastore (one series per vm and datastore)").Default("false").BoolVar(&cfg.CollectorConfig.DatastoreVMInfo)
 
 	//collector.host
 	a.Flag("collector.host.storage", "Collect host storage metrics").Default("false").BoolVar(&cfg.CollectorConfig.HostStorageMetrics)
+	a.Flag("collector.host.hardware", "Collect host hardware inventory (cpu packages, pci devices, gpu's, nic firmware, tpm)").Default("false").BoolVar(&cfg.CollectorConfig.HostHardwareMetrics)
+	a.Flag("collector.host.network", "Collect host network config (standard vswitches, portgroups, vmkernel adapters, nic link state)").Default("false").BoolVar(&cfg.CollectorConfig.HostNetworkMetrics)
 	a.Flag("collector.host.tag_label", "List of vmware tag categories which will be added as label in metrics").StringsVar(&cfg.CollectorConfig.HostTagLabels)
+	a.Flag("collector.host.attribute_label", "List of vmware custom attributes which will be added as label in metrics").StringsVar(&cfg.CollectorConfig.HostAttributeLabels)
 
 	//collector.repool
 	a.Flag("collector.repool.tag_label", "List of tag categories which will be added as label in metrics").StringsVar(&cfg.CollectorConfig.ResourcePoolTagLabels)
+	a.Flag("collector.repool.attribute_label", "List of vmware custom attributes which will be added as label in metrics").StringsVar(&cfg.CollectorConfig.ResourcePoolAttributeLabels)
 
 	//collector.spod
 	a.Flag("collector.spod.tag_label", "List of vmware tag categories to collect which will be added as label in metrics").StringsVar(&cfg.CollectorConfig.StoragePodTagLabels)
+	a.Flag("collector.spod.attribute_label", "List of vmware custom attributes which will be added as label in metrics").StringsVar(&cfg.CollectorConfig.StoragePodAttributeLabels)
+
+	//collector.vapp
+	a.Flag("collector.vapp.tag_label", "List of vmware tag categories to collect which will be added as label in metrics").StringsVar(&cfg.CollectorConfig.VirtualAppTagLabels)
+	a.Flag("collector.vapp.attribute_label", "List of vmware custom attributes which will be added as label in metrics").StringsVar(&cfg.CollectorConfig.VirtualAppAttributeLabels)
 
 	//collector.vm
 	a.Flag("collector.vm.tag_label", "List of vmware tag categories to collect which will be added as label in metrics").StringsVar(&cfg.CollectorConfig.VMTagLabels)
+	a.Flag("collector.vm.attribute_label", "List of vmware custom attributes which will be added as label in metrics").StringsVar(&cfg.CollectorConfig.VMAttributeLabels)
 
 	//scraper
 	a.Flag("scraper.vc.url", "vc api username").Envar("VC_URL").Required().StringVar(&cfg.ScraperConfig.VCenter)
@@ -90,6 +112,17 @@ func LoadConfig() config.Config {
 	a.Flag("scraper.host.perf.extra_metric", "Collect additional host perf metrics").StringsVar(&cfg.ScraperConfig.HostPerf.ExtraMetrics)
 	a.Flag("scraper.host.perf.filter", "Filters to modify/cleanup perf metrics and reduce the amount of metrics exported.").StringsVar(&cfg.ScraperConfig.HostPerf.Filters)
 
+	//scraper.host.security
+	a.Flag("scraper.host.security", "Enable host security sensor (lockdown mode, services, ntp, certificates and firewall)").Default("False").BoolVar(&cfg.ScraperConfig.HostSecurity.Enabled)
+	a.Flag("scraper.host.security.max_age", "time in seconds host security settings are cached").Default("10m").DurationVar(&cfg.ScraperConfig.HostSecurity.MaxAge)
+	a.Flag("scraper.host.security.refresh_interval", "interval host security settings are refreshed").Default("290s").DurationVar(&cfg.ScraperConfig.HostSecurity.RefreshInterval)
+
+	//scraper.host.options
+	a.Flag("scraper.host.options", "Enable host advanced options sensor to detect drift of the desired options").Default("False").BoolVar(&cfg.ScraperConfig.HostOptions.Enabled)
+	a.Flag("scraper.host.options.max_age", "time in seconds host advanced options are cached").Default("10m").DurationVar(&cfg.ScraperConfig.HostOptions.MaxAge)
+	a.Flag("scraper.host.options.refresh_interval", "interval host advanced options are refreshed").Default("290s").DurationVar(&cfg.ScraperConfig.HostOptions.RefreshInterval)
+	a.Flag("scraper.host.options.desired", "Desired advanced option as [cluster:<name>;|tag:<category>=<tag>;]<option>=<value>, ex. cluster:prod;Disk.MaxLUN=1024. The last matching value wins").StringsVar(&cfg.ScraperConfig.HostOptions.DesiredOptions)
+
 	//scraper.repool
 	a.Flag("scraper.repool", "Enable resource pool sensor").Default("True").BoolVar(&cfg.ScraperConfig.ResourcePool.Enabled)
 	a.Flag("scraper.repool.max_age", "time in seconds resource pools are cached").Default("2m").DurationVar(&cfg.ScraperConfig.ResourcePool.MaxAge)
@@ -100,19 +133,58 @@ func LoadConfig() config.Config {
 	a.Flag("scraper.spod.max_age", "time in seconds spods are cached").Default("2m").DurationVar(&cfg.ScraperConfig.Spod.MaxAge)
 	a.Flag("scraper.spod.refresh_interval", "interval spods are refreshed").Default("55s").DurationVar(&cfg.ScraperConfig.Spod.RefreshInterval)
 
+	//scraper.storage_policy
+	a.Flag("scraper.storage_policy", "Enable storage policy (SPBM) sensor").Default("False").BoolVar(&cfg.ScraperConfig.StoragePolicy.Enabled)
+	a.Flag("scraper.storage_policy.max_age", "time in seconds storage policies are cached").Default("10m").DurationVar(&cfg.ScraperConfig.StoragePolicy.MaxAge)
+	a.Flag("scraper.storage_policy.refresh_interval", "interval storage policies are refreshed").Default("290s").DurationVar(&cfg.ScraperConfig.StoragePolicy.RefreshInterval)
+
 	//scraper.tags
 	a.Flag("scraper.tags", "Collect tags").Default("True").BoolVar(&cfg.ScraperConfig.Tags.Enabled)
 	a.Flag("scraper.tags.max_age", "time in seconds tags are cached").Default("10m").DurationVar(&cfg.ScraperConfig.Tags.MaxAge)
 	a.Flag("scraper.tags.refresh_interval", "interval tags are refreshed").Default("55s").DurationVar(&cfg.ScraperConfig.Tags.RefreshInterval)
 
+	//scraper.attributes
+	a.Flag("scraper.attributes", "Collect custom attributes").Default("True").BoolVar(&cfg.ScraperConfig.CustomAttributes.Enabled)
+	a.Flag("scraper.attributes.max_age", "time in seconds custom attributes are cached").Default("10m").DurationVar(&cfg.ScraperConfig.CustomAttributes.MaxAge)
+	a.Flag("scraper.attributes.refresh_interval", "interval custom attributes are refreshed").Default("55s").DurationVar(&cfg.ScraperConfig.CustomAttributes.RefreshInterval)
+
+	//scraper.vapp
+	a.Flag("scraper.vapp", "Enable vApp sensor").Default("True").BoolVar(&cfg.ScraperConfig.VirtualApp.Enabled)
+	a.Flag("scraper.vapp.max_age", "time in seconds vApps are cached").Default("2m").DurationVar(&cfg.ScraperConfig.VirtualApp.MaxAge)
+	a.Flag("scraper.vapp.refresh_interval", "interval vApps are refreshed").Default("55s").DurationVar(&cfg.ScraperConfig.VirtualApp.RefreshInterval)
+
+	//scraper.service_instance
+	a.Flag("scraper.service_instance", "Enable service instance sensor (vCenter about info, server time and sessions)").Default("True").BoolVar(&cfg.ScraperConfig.ServiceInstance.Enabled)
+	a.Flag("scraper.service_instance.max_age", "time in seconds vCenter info is cached").Default("2m").DurationVar(&cfg.ScraperConfig.ServiceInstance.MaxAge)
+	a.Flag("scraper.service_instance.refresh_interval", "interval vCenter info is refreshed").Default("55s").DurationVar(&cfg.ScraperConfig.ServiceInstance.RefreshInterval)
+
+	//scraper.license
+	a.Flag("scraper.license", "Enable license sensor").Default("True").BoolVar(&cfg.ScraperConfig.License.Enabled)
+	a.Flag("scraper.license.max_age", "time in seconds licenses are cached").Default("10m").DurationVar(&cfg.ScraperConfig.License.MaxAge)
+	a.Flag("scraper.license.refresh_interval", "interval licenses are refreshed").Default("290s").DurationVar(&cfg.ScraperConfig.License.RefreshInterval)
+
+	//scraper.orphaned_vmdk
+	a.Flag("scraper.orphaned_vmdk", "Enable orphaned vmdk sensor, searches all datastores for vmdk files not used by any vm").Default("False").BoolVar(&cfg.ScraperConfig.OrphanedVMDK.Enabled)
+	a.Flag("scraper.orphaned_vmdk.max_age", "time in seconds orphaned vmdk files are cached").Default("13h").DurationVar(&cfg.ScraperConfig.OrphanedVMDK.MaxAge)
+	a.Flag("scraper.orphaned_vmdk.refresh_interval", "interval datastores are searched for orphaned vmdk files").Default("6h").DurationVar(&cfg.ScraperConfig.OrphanedVMDK.RefreshInterval)
+
+	//scraper.content_library
+	a.Flag("scraper.content_library", "Enable content library sensor").Default("False").BoolVar(&cfg.ScraperConfig.ContentLibrary.Enabled)
+	a.Flag("scraper.content_library.max_age", "time in seconds content libraries are cached").Default("10m").DurationVar(&cfg.ScraperConfig.ContentLibrary.MaxAge)
+	a.Flag("scraper.content_library.refresh_interval", "interval content libraries are refreshed").Default("290s").DurationVar(&cfg.ScraperConfig.ContentLibrary.RefreshInterval)
+
 	//scraper.vm
 	a.Flag("scraper.vm", "Enable virtualmachine sensor").Default("True").BoolVar(&cfg.ScraperConfig.VirtualMachine.Enabled)
 	a.Flag("scraper.vm.max_age", "time in seconds vm's are cached").Default("2m").DurationVar(&cfg.ScraperConfig.VirtualMachine.MaxAge)
 	a.Flag("scraper.vm.refresh_interval", "interval vm's are refreshed").Default("55s").DurationVar(&cfg.ScraperConfig.VirtualMachine.RefreshInterval)
 	a.Flag("scraper.vm.refresh_timeout", "the maximum amount of time a sensor refresh can take. Default is 3 times the refresh_interval").DurationVar(&cfg.ScraperConfig.VirtualMachine.RefreshTimeout)
+	a.Flag("scraper.vm.config", "Collect vm configuration settings used for compliance checks").Default("false").BoolVar(&cfg.ScraperConfig.VirtualMachine.ConfigSettings)
 	a.Flag("collector.vm.legacy", "Collect legacy metrics. Should all be available via scraper.vm.perf").Default("false").BoolVar(&cfg.CollectorConfig.VMLegacyMetrics)
 	a.Flag("collector.vm.disk", "Collect extra vm disk metrics").Default("false").BoolVar(&cfg.CollectorConfig.VMAdvancedStorageMetrics)
 	a.Flag("collector.vm.network", "Collect extra vm network metrics").Default("false").BoolVar(&cfg.CollectorConfig.VMAdvancedNetworkMetrics)
+	a.Flag("collector.vm.guest_disk", "Collect vm guest filesystem metrics reported by VMware Tools").Default("false").BoolVar(&cfg.CollectorConfig.VMGuestDiskMetrics)
+	a.Flag("collector.vm.datastore", "Collect per datastore storage usage of every vm (one series per vm and datastore)").Default("false").BoolVar(&cfg.CollectorConfig.VMDatastoreMetrics)
+	a.Flag("collector.vm.config.rule", "Desired vm setting as <setting><operator><value>, ex. hardware_version>=19. Requires scraper.vm.config").StringsVar(&cfg.CollectorConfig.VMConfigRules)
 
 	// scraper.vm.perf
 	a.Flag("scraper.vm.perf", "Enable vm performance metrics").Default("False").BoolVar(&cfg.ScraperConfig.VirtualMachinePerf.Enabled)
@@ -137,13 +209,25 @@ func LoadConfig() config.Config {
 		os.Exit(2)
 	}
 
-	cfg.ScraperConfig.Tags.CategoryToCollect = helper.Union(
-		cfg.CollectorConfig.ClusterTagLabels,
-		cfg.CollectorConfig.DatastoreTagLabels,
-		cfg.CollectorConfig.HostTagLabels,
-		cfg.CollectorConfig.ResourcePoolTagLabels,
-		cfg.CollectorConfig.StoragePodTagLabels,
-		cfg.CollectorConfig.VMTagLabels,
+	if *useIsecSpecifics {
+		cfg.CollectorConfig.AnnotationFormat = config.AnnotationFormatJSON
+		cfg.CollectorConfig.AnnotationLabels = []string{"crit", "resp=responsable", "svc=service"}
+		cfg.CollectorConfig.AnnotationDefaultValue = "not defined"
+	}
+
+	cfg.ScraperConfig.Host.Hardware = cfg.CollectorConfig.HostHardwareMetrics
+	cfg.ScraperConfig.Host.Network = cfg.CollectorConfig.HostNetworkMetrics
+
+	cfg.ScraperConfig.Tags.CategoryToCollect = cfg.TagCategoriesToCollect()
+
+	cfg.ScraperConfig.CustomAttributes.AttributesToCollect = helper.Union(
+		cfg.CollectorConfig.ClusterAttributeLabels,
+		cfg.CollectorConfig.DatastoreAttributeLabels,
+		cfg.CollectorConfig.HostAttributeLabels,
+		cfg.CollectorConfig.ResourcePoolAttributeLabels,
+		cfg.CollectorConfig.StoragePodAttributeLabels,
+		cfg.CollectorConfig.VirtualAppAttributeLabels,
+		cfg.CollectorConfig.VMAttributeLabels,
 	)
 
 	return cfg
@@ -106,6 +106,10 @@ func run(ctx context.Context) {
 		http.Handle("/dump", scraper.GetDumpHandler(*scrap, logger))
 		http.Handle("/dump/{sensor}", scraper.GetDumpHandler(*scrap, logger))
 	}
+	if config.ScraperConfig.OrphanedVMDK.Enabled {
+		http.Handle("/inventory/orphaned_vmdks", scraper.GetOrphanedVMDKHandler(*scrap, logger))
+	}
+	http.Handle("/inventory/topology", scraper.GetTopologyHandler(*scrap, logger))
 	http.Handle("/", defaultHandler(config.MetricPath))
 
 	// make it a goroutine
@@ -14,6 +14,7 @@ require (
 require (
 	github.com/alecthomas/kingpin/v2 v2.4.0
 	golang.org/x/exp v0.0.0-20250811191247-51f88131bc50
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
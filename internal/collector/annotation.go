@@ -0,0 +1,111 @@
+package collector
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/sanderdescamps/govc_exporter/internal/config"
+	"gopkg.in/yaml.v3"
+)
+
+// annotationParser extracts key/value pairs out of the annotation (notes) of
+// an object and converts them into label values
+type annotationParser struct {
+	format       string
+	regex        *regexp.Regexp
+	keys         []string
+	labels       []string
+	defaultValue string
+}
+
+func newAnnotationParser(cConf config.CollectorConfig) annotationParser {
+	p := annotationParser{
+		format:       cConf.AnnotationFormat,
+		keys:         cConf.AnnotationKeys(),
+		labels:       cConf.AnnotationLabelNames(),
+		defaultValue: cConf.AnnotationDefaultValue,
+	}
+	if p.format == config.AnnotationFormatRegex {
+		p.regex = regexp.MustCompile(cConf.AnnotationRegex)
+	}
+	return p
+}
+
+func (p annotationParser) Enabled() bool {
+	return p.format != "" && p.format != config.AnnotationFormatNone && len(p.keys) != 0
+}
+
+// Labels returns the label names which need to be added to the metrics
+func (p annotationParser) Labels() []string {
+	if !p.Enabled() {
+		return []string{}
+	}
+	return p.labels
+}
+
+// LabelValues returns a value for every label returned by Labels
+func (p annotationParser) LabelValues(annotation string) []string {
+	if !p.Enabled() {
+		return []string{}
+	}
+
+	values := p.Parse(annotation)
+	result := []string{}
+	for _, key := range p.keys {
+		if v, ok := values[key]; ok {
+			result = append(result, v)
+		} else {
+			result = append(result, p.defaultValue)
+		}
+	}
+	return result
+}
+
+// Parse returns all key/value pairs found in the annotation. An annotation
+// which can not be parsed results in an empty map.
+func (p annotationParser) Parse(annotation string) map[string]string {
+	result := map[string]string{}
+	switch p.format {
+	case config.AnnotationFormatKV:
+		for _, line := range strings.FieldsFunc(annotation, func(r rune) bool { return r == '\n' || r == ';' }) {
+			if key, value, found := strings.Cut(line, "="); found {
+				result[strings.TrimSpace(key)] = strings.TrimSpace(value)
+			}
+		}
+	case config.AnnotationFormatJSON:
+		var raw map[string]any
+		if err := json.Unmarshal([]byte(annotation), &raw); err == nil {
+			scalarValues(raw, result)
+		}
+	case config.AnnotationFormatYAML:
+		var raw map[string]any
+		if err := yaml.Unmarshal([]byte(annotation), &raw); err == nil {
+			scalarValues(raw, result)
+		}
+	case config.AnnotationFormatRegex:
+		match := p.regex.FindStringSubmatch(annotation)
+		if match == nil {
+			return result
+		}
+		for i, name := range p.regex.SubexpNames() {
+			if name != "" && match[i] != "" {
+				result[name] = match[i]
+			}
+		}
+	}
+	return result
+}
+
+// scalarValues adds all values of raw which are not a map or list to result
+func scalarValues(raw map[string]any, result map[string]string) {
+	for key, value := range raw {
+		switch value.(type) {
+		case map[string]any, []any, nil:
+			continue
+		default:
+			result[key] = fmt.Sprint(value)
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package collector
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/sanderdescamps/govc_exporter/internal/config"
+)
+
+func TestAnnotationParser(t *testing.T) {
+	tests := []struct {
+		format     string
+		regex      string
+		annotation string
+		expected   []string
+	}{
+		{config.AnnotationFormatKV, "", "crit=high\nresp = team-a;svc=web", []string{"high", "team-a", "web"}},
+		{config.AnnotationFormatJSON, "", `{"crit":"high","resp":"team-a","svc":3}`, []string{"high", "team-a", "3"}},
+		{config.AnnotationFormatJSON, "", "not json", []string{"unknown", "unknown", "unknown"}},
+		{config.AnnotationFormatYAML, "", "crit: high\nresp: team-a\n", []string{"high", "team-a", "unknown"}},
+		{config.AnnotationFormatRegex, `owner:(?P<resp>\S+) crit:(?P<crit>\S+) svc:(?P<svc>\S+)`, "owner:team-a crit:high svc:web", []string{"high", "team-a", "web"}},
+	}
+
+	for _, test := range tests {
+		cConf := config.DefaultCollectorConf()
+		cConf.AnnotationFormat = test.format
+		cConf.AnnotationRegex = test.regex
+		cConf.AnnotationLabels = []string{"crit", "resp=responsable", "svc=service"}
+		cConf.AnnotationDefaultValue = "unknown"
+		if err := cConf.Validate(); err != nil {
+			t.Fatalf("Config validation failed: %v", err)
+		}
+
+		p := newAnnotationParser(cConf)
+		if labels := p.Labels(); !slices.Equal(labels, []string{"crit", "responsable", "service"}) {
+			t.Errorf("unexpected labels %v", labels)
+		}
+		if values := p.LabelValues(test.annotation); !slices.Equal(values, test.expected) {
+			t.Errorf("%s: expected %v, got %v", test.format, test.expected, values)
+		}
+	}
+}
@@ -40,6 +40,29 @@ func NewVCCollector(ctx context.Context, conf config.Config, scraper *scraper.VC
 	collectors[helper.NewMatcher("resourcepool", "rp", "rpool")] = NewResourcePoolCollector(scraper, conf.CollectorConfig)
 	collectors[helper.NewMatcher("cluster", "clu")] = NewClusterCollector(scraper, conf.CollectorConfig)
 	collectors[helper.NewMatcher("vm", "virtualmachine")] = NewVirtualMachineCollector(scraper, conf.CollectorConfig)
+	collectors[helper.NewMatcher("vapp", "virtualapp")] = NewVirtualAppCollector(scraper, conf.CollectorConfig)
+	collectors[helper.NewMatcher("license", "licenses")] = NewLicenseCollector(scraper, conf.CollectorConfig)
+	collectors[helper.NewMatcher("vcenter", "service_instance", "sessions")] = NewVCenterCollector(scraper, conf.CollectorConfig)
+
+	if conf.ScraperConfig.HostSecurity.Enabled {
+		collectors[helper.NewMatcher("esx_security", "host_security", "security")] = NewEsxSecurityCollector(scraper, conf.CollectorConfig)
+	}
+
+	if conf.ScraperConfig.HostOptions.Enabled {
+		collectors[helper.NewMatcher("esx_options", "host_options", "advanced_options")] = NewEsxOptionsCollector(scraper, conf.CollectorConfig)
+	}
+
+	if conf.ScraperConfig.ContentLibrary.Enabled {
+		collectors[helper.NewMatcher("content_library", "library")] = NewContentLibraryCollector(scraper, conf.CollectorConfig)
+	}
+
+	if conf.ScraperConfig.StoragePolicy.Enabled {
+		collectors[helper.NewMatcher("storage_policy", "spbm")] = NewStoragePolicyCollector(scraper, conf.CollectorConfig)
+	}
+
+	if conf.ScraperConfig.VirtualMachine.ConfigSettings {
+		collectors[helper.NewMatcher("vmconfig", "vm_config", "compliance")] = NewVirtualMachineConfigCollector(scraper, conf.CollectorConfig)
+	}
 
 	if conf.ScraperConfig.VirtualMachinePerf.Enabled {
 		collectors[helper.NewMatcher("perfvm", "perf-vm")] = NewVMPerfCollector(scraper, conf.CollectorConfig)
@@ -50,6 +73,11 @@ func NewVCCollector(ctx context.Context, conf config.Config, scraper *scraper.VC
 	}
 
 	collectors[helper.NewMatcher("spod", "storagepod")] = NewStoragePodCollector(scraper, conf.CollectorConfig)
+
+	if conf.CollectorConfig.TagLabelMode == config.TagLabelModeInfo {
+		collectors[helper.NewMatcher("tag", "tags")] = NewTagCollector(scraper, conf.CollectorConfig)
+	}
+
 	collectors[helper.NewMatcher("scraper")] = NewScraperCollector(scraper)
 
 	return &VCCollector{
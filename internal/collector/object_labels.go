@@ -0,0 +1,69 @@
+package collector
+
+import (
+	"slices"
+
+	"github.com/sanderdescamps/govc_exporter/internal/config"
+	"github.com/sanderdescamps/govc_exporter/internal/database/objects"
+)
+
+// attributeLabeler converts the custom attributes of an object into label
+// values
+type attributeLabeler struct {
+	attributes []string
+}
+
+func newAttributeLabeler(attributes []string) attributeLabeler {
+	return attributeLabeler{
+		attributes: attributes,
+	}
+}
+
+// Labels returns the label names which need to be added to the metrics
+func (l attributeLabeler) Labels() []string {
+	return l.attributes
+}
+
+// LabelValues returns the value of every configured attribute, empty when
+// the object does not have the attribute
+func (l attributeLabeler) LabelValues(attributeSet objects.AttributeSet) []string {
+	values := []string{}
+	for _, attr := range l.attributes {
+		values = append(values, attributeSet.GetAttribute(attr))
+	}
+	return values
+}
+
+// objectLabeler adds the tag and custom attribute labels of an object after
+// its base labels
+type objectLabeler struct {
+	tags       tagLabeler
+	attributes attributeLabeler
+}
+
+func newObjectLabeler(tagCategories []string, attributes []string, cConf config.CollectorConfig) objectLabeler {
+	return objectLabeler{
+		tags:       newTagLabeler(tagCategories, cConf),
+		attributes: newAttributeLabeler(attributes),
+	}
+}
+
+// Labels returns the base labels followed by the tag and attribute labels
+func (l objectLabeler) Labels(base []string) []string {
+	labels := slices.Clone(base)
+	labels = append(labels, l.tags.Labels()...)
+	return append(labels, l.attributes.Labels()...)
+}
+
+// LabelValues returns the sets of label values for an object, see
+// tagLabeler.LabelValues. Every set starts with the base label values.
+func (l objectLabeler) LabelValues(base []string, tagSet objects.TagSet, attributeSet objects.AttributeSet) [][]string {
+	attributeValues := l.attributes.LabelValues(attributeSet)
+	result := [][]string{}
+	for _, tagValues := range l.tags.LabelValues(tagSet) {
+		values := slices.Clone(base)
+		values = append(values, tagValues...)
+		result = append(result, append(values, attributeValues...))
+	}
+	return result
+}
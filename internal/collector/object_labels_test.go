@@ -0,0 +1,29 @@
+package collector
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/sanderdescamps/govc_exporter/internal/config"
+	"github.com/sanderdescamps/govc_exporter/internal/database/objects"
+)
+
+func TestObjectLabeler(t *testing.T) {
+	cConf := config.DefaultCollectorConf()
+	cConf.TagLabelMode = config.TagLabelModeSeries
+	l := newObjectLabeler([]string{"env"}, []string{"owner", "backup"}, cConf)
+
+	if labels := l.Labels([]string{"id", "name"}); !reflect.DeepEqual(labels, []string{"id", "name", "env", "owner", "backup"}) {
+		t.Errorf("unexpected labels %v", labels)
+	}
+
+	tagSet := objects.TagSet{Tags: map[string][]string{"env": {"prd", "dr"}}}
+	attributeSet := objects.AttributeSet{Attributes: map[string]string{"owner": "team-a"}}
+	expected := [][]string{
+		{"vm-1", "vm1", "prd", "team-a", ""},
+		{"vm-1", "vm1", "dr", "team-a", ""},
+	}
+	if values := l.LabelValues([]string{"vm-1", "vm1"}, tagSet, attributeSet); !reflect.DeepEqual(values, expected) {
+		t.Errorf("expected label values %v, got %v", expected, values)
+	}
+}
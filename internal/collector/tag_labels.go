@@ -0,0 +1,64 @@
+package collector
+
+import (
+	"slices"
+
+	"github.com/sanderdescamps/govc_exporter/internal/config"
+	"github.com/sanderdescamps/govc_exporter/internal/database/objects"
+)
+
+// tagLabeler converts the tags of an object into label values according to
+// the configured tag label mode
+type tagLabeler struct {
+	categories []string
+	mode       string
+	separator  string
+}
+
+func newTagLabeler(categories []string, cConf config.CollectorConfig) tagLabeler {
+	return tagLabeler{
+		categories: categories,
+		mode:       cConf.TagLabelMode,
+		separator:  cConf.TagLabelSeparator,
+	}
+}
+
+// Labels returns the label names which need to be added to the metrics
+func (l tagLabeler) Labels() []string {
+	if l.mode == config.TagLabelModeInfo {
+		return []string{}
+	}
+	return l.categories
+}
+
+// LabelValues returns the sets of label values for an object. Every set
+// results in a separate series. Only in series mode more than one set can be
+// returned.
+func (l tagLabeler) LabelValues(tagSet objects.TagSet) [][]string {
+	switch l.mode {
+	case config.TagLabelModeInfo:
+		return [][]string{{}}
+	case config.TagLabelModeSeries:
+		result := [][]string{{}}
+		for _, cat := range l.categories {
+			tags := tagSet.GetTags(cat)
+			if len(tags) == 0 {
+				tags = []string{""}
+			}
+			next := make([][]string, 0, len(result)*len(tags))
+			for _, values := range result {
+				for _, tag := range tags {
+					next = append(next, append(slices.Clone(values), tag))
+				}
+			}
+			result = next
+		}
+		return result
+	default:
+		values := []string{}
+		for _, cat := range l.categories {
+			values = append(values, tagSet.JoinTags(cat, l.separator))
+		}
+		return [][]string{values}
+	}
+}
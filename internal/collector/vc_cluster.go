@@ -2,9 +2,11 @@ package collector
 
 import (
 	"context"
+	"slices"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/sanderdescamps/govc_exporter/internal/config"
+	"github.com/sanderdescamps/govc_exporter/internal/database/objects"
 	"github.com/sanderdescamps/govc_exporter/internal/scraper"
 )
 
@@ -13,8 +15,8 @@ const (
 )
 
 type clusterCollector struct {
-	scraper     *scraper.VCenterScraper
-	extraLabels []string
+	scraper      *scraper.VCenterScraper
+	objectLabels objectLabeler
 
 	totalCPU          *prometheus.Desc
 	effectiveCPU      *prometheus.Desc
@@ -25,19 +27,50 @@ type clusterCollector struct {
 	numEffectiveHosts *prometheus.Desc
 	numHosts          *prometheus.Desc
 	overallStatus     *prometheus.Desc
+	numVmotions       *prometheus.Desc
+
+	drsEnabled                *prometheus.Desc
+	drsAutomationLevel        *prometheus.Desc
+	drsScore                  *prometheus.Desc
+	drsVMsPerScoreBucket      *prometheus.Desc
+	drsPendingRecommendations *prometheus.Desc
+
+	haEnabled                   *prometheus.Desc
+	haHostMonitoring            *prometheus.Desc
+	haAdmissionControlEnabled   *prometheus.Desc
+	haAdmissionControlInfo      *prometheus.Desc
+	haFailoverLevel             *prometheus.Desc
+	haCurrentFailoverLevel      *prometheus.Desc
+	haConfiguredFailoverPercent *prometheus.Desc
+	haCurrentFailoverPercent    *prometheus.Desc
+
+	evcModeInfo *prometheus.Desc
+
+	ruleInfo       *prometheus.Desc
+	ruleEnabled    *prometheus.Desc
+	ruleMandatory  *prometheus.Desc
+	ruleViolations *prometheus.Desc
+	groupMembers   *prometheus.Desc
 }
 
 func NewClusterCollector(scraper *scraper.VCenterScraper, cConf config.CollectorConfig) *clusterCollector {
 	labels := []string{"id", "name", "datacenter"}
 
-	extraLabels := cConf.ClusterTagLabels
-	if len(extraLabels) != 0 {
-		labels = append(labels, extraLabels...)
-	}
+	objectLabels := newObjectLabeler(cConf.ClusterTagLabels, cConf.ClusterAttributeLabels, cConf)
+	labels = objectLabels.Labels(labels)
+
+	drsAutomationLabels := append(slices.Clone(labels), "automation_level")
+	drsBucketLabels := append(slices.Clone(labels), "bucket")
+	haAdmissionControlLabels := append(slices.Clone(labels), "policy", "vm_monitoring")
+	haResourceLabels := append(slices.Clone(labels), "resource")
+	evcLabels := append(slices.Clone(labels), "evc_mode")
+	ruleLabels := append(slices.Clone(labels), "rule", "rule_type")
+	ruleInfoLabels := append(slices.Clone(ruleLabels), "vm_group", "target_group")
+	groupLabels := append(slices.Clone(labels), "group", "group_type")
 
 	return &clusterCollector{
-		scraper:     scraper,
-		extraLabels: extraLabels,
+		scraper:      scraper,
+		objectLabels: objectLabels,
 		totalCPU: prometheus.NewDesc(
 			prometheus.BuildFQName(namespace, clusterCollectorSubsystem, "total_cpu_mhz"),
 			"Aggregated CPU resources of all hosts, in MHz", labels, nil),
@@ -65,6 +98,66 @@ func NewClusterCollector(scraper *scraper.VCenterScraper, cConf config.Collector
 		overallStatus: prometheus.NewDesc(
 			prometheus.BuildFQName(namespace, clusterCollectorSubsystem, "overall_status"),
 			"overall health status", labels, nil),
+		numVmotions: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, clusterCollectorSubsystem, "num_vmotions_total"),
+			"Total number of migrations with VMotion that have been done internal to this cluster", labels, nil),
+		drsEnabled: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, clusterCollectorSubsystem, "drs_enabled"),
+			"DRS is enabled", labels, nil),
+		drsAutomationLevel: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, clusterCollectorSubsystem, "drs_automation_level"),
+			"DRS automation level (0=manual, 1=partiallyAutomated, 2=fullyAutomated)", drsAutomationLabels, nil),
+		drsScore: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, clusterCollectorSubsystem, "drs_score"),
+			"DRS score of the cluster in percent", labels, nil),
+		drsVMsPerScoreBucket: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, clusterCollectorSubsystem, "drs_vms_per_score_bucket"),
+			"Number of vm's per DRS score bucket (vm happiness)", drsBucketLabels, nil),
+		drsPendingRecommendations: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, clusterCollectorSubsystem, "drs_pending_recommendations"),
+			"Number of pending DRS recommendations", labels, nil),
+		haEnabled: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, clusterCollectorSubsystem, "ha_enabled"),
+			"HA is enabled", labels, nil),
+		haHostMonitoring: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, clusterCollectorSubsystem, "ha_host_monitoring_enabled"),
+			"HA host monitoring is enabled", labels, nil),
+		haAdmissionControlEnabled: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, clusterCollectorSubsystem, "ha_admission_control_enabled"),
+			"HA admission control is enabled", labels, nil),
+		haAdmissionControlInfo: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, clusterCollectorSubsystem, "ha_admission_control_info"),
+			"HA admission control policy", haAdmissionControlLabels, nil),
+		haFailoverLevel: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, clusterCollectorSubsystem, "ha_failover_level"),
+			"Number of host failures the cluster should tolerate", labels, nil),
+		haCurrentFailoverLevel: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, clusterCollectorSubsystem, "ha_current_failover_level"),
+			"Number of host failures the cluster can currently tolerate", labels, nil),
+		haConfiguredFailoverPercent: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, clusterCollectorSubsystem, "ha_configured_failover_percent"),
+			"Resources reserved for failover in percent, only for the failover_resources policy", haResourceLabels, nil),
+		haCurrentFailoverPercent: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, clusterCollectorSubsystem, "ha_current_failover_percent"),
+			"Resources currently available for failover in percent, only for the failover_resources policy", haResourceLabels, nil),
+		evcModeInfo: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, clusterCollectorSubsystem, "evc_mode_info"),
+			"Current EVC mode, empty when EVC is disabled", evcLabels, nil),
+		ruleInfo: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, clusterCollectorSubsystem, "rule_info"),
+			"DRS rule configured on the cluster", ruleInfoLabels, nil),
+		ruleEnabled: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, clusterCollectorSubsystem, "rule_enabled"),
+			"DRS rule is enabled", ruleLabels, nil),
+		ruleMandatory: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, clusterCollectorSubsystem, "rule_mandatory"),
+			"DRS rule is mandatory (must) instead of preferential (should)", ruleLabels, nil),
+		ruleViolations: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, clusterCollectorSubsystem, "rule_violations"),
+			"Number of running vm's violating an enabled DRS placement rule, requires the vm sensor", ruleLabels, nil),
+		groupMembers: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, clusterCollectorSubsystem, "group_members"),
+			"Number of members of a DRS vm or host group", groupLabels, nil),
 	}
 }
 
@@ -78,6 +171,26 @@ func (c *clusterCollector) Describe(ch chan<- *prometheus.Desc) {
 	ch <- c.numEffectiveHosts
 	ch <- c.numHosts
 	ch <- c.overallStatus
+	ch <- c.numVmotions
+	ch <- c.drsEnabled
+	ch <- c.drsAutomationLevel
+	ch <- c.drsScore
+	ch <- c.drsVMsPerScoreBucket
+	ch <- c.drsPendingRecommendations
+	ch <- c.haEnabled
+	ch <- c.haHostMonitoring
+	ch <- c.haAdmissionControlEnabled
+	ch <- c.haAdmissionControlInfo
+	ch <- c.haFailoverLevel
+	ch <- c.haCurrentFailoverLevel
+	ch <- c.haConfiguredFailoverPercent
+	ch <- c.haCurrentFailoverPercent
+	ch <- c.evcModeInfo
+	ch <- c.ruleInfo
+	ch <- c.ruleEnabled
+	ch <- c.ruleMandatory
+	ch <- c.ruleViolations
+	ch <- c.groupMembers
 }
 
 func (c *clusterCollector) Collect(ch chan<- prometheus.Metric) {
@@ -92,43 +205,158 @@ func (c *clusterCollector) Collect(ch chan<- prometheus.Metric) {
 	if err != nil && Logger != nil {
 		Logger.Error("failed to get clusters", "err", err)
 	}
+
+	var vmHosts map[string]string
+	vmHostsKnown := false
+	if slices.ContainsFunc(clusters, func(c objects.Cluster) bool { return len(c.Rules) > 0 }) {
+		vmHosts, vmHostsKnown = c.runningVMHosts(ctx)
+	}
+
 	for _, cluster := range clusters {
 
-		extraLabelValues := []string{}
 		objectTags := c.scraper.DB.GetTags(ctx, cluster.Self)
-		for _, tagCat := range c.extraLabels {
-			extraLabelValues = append(extraLabelValues, objectTags.GetTag(tagCat))
+		objectAttributes := c.scraper.DB.GetAttributes(ctx, cluster.Self)
+		baseLabelValues := []string{cluster.Self.ID(), cluster.Name, cluster.Datacenter}
+		for _, labelValues := range c.objectLabels.LabelValues(baseLabelValues, objectTags, objectAttributes) {
+			ch <- prometheus.NewMetricWithTimestamp(cluster.Timestamp, prometheus.MustNewConstMetric(
+				c.totalCPU, prometheus.GaugeValue, float64(cluster.TotalCPU), labelValues...,
+			))
+			ch <- prometheus.NewMetricWithTimestamp(cluster.Timestamp, prometheus.MustNewConstMetric(
+				c.effectiveCPU, prometheus.GaugeValue, float64(cluster.EffectiveCPU), labelValues...,
+			))
+			ch <- prometheus.NewMetricWithTimestamp(cluster.Timestamp, prometheus.MustNewConstMetric(
+				c.totalMemory, prometheus.GaugeValue, float64(cluster.TotalMemory), labelValues...,
+			))
+			ch <- prometheus.NewMetricWithTimestamp(cluster.Timestamp, prometheus.MustNewConstMetric(
+				c.effectiveMemory, prometheus.GaugeValue, float64(cluster.EffectiveMemory), labelValues...,
+			))
+			ch <- prometheus.NewMetricWithTimestamp(cluster.Timestamp, prometheus.MustNewConstMetric(
+				c.numCPUCores, prometheus.GaugeValue, float64(cluster.NumCPUThreads), labelValues...,
+			))
+			ch <- prometheus.NewMetricWithTimestamp(cluster.Timestamp, prometheus.MustNewConstMetric(
+				c.numCPUThreads, prometheus.GaugeValue, float64(cluster.NumCPUThreads), labelValues...,
+			))
+			ch <- prometheus.NewMetricWithTimestamp(cluster.Timestamp, prometheus.MustNewConstMetric(
+				c.numEffectiveHosts, prometheus.GaugeValue, float64(cluster.NumEffectiveHosts), labelValues...,
+			))
+			ch <- prometheus.NewMetricWithTimestamp(cluster.Timestamp, prometheus.MustNewConstMetric(
+				c.numHosts, prometheus.GaugeValue, cluster.NumHosts, labelValues...,
+			))
+			ch <- prometheus.NewMetricWithTimestamp(cluster.Timestamp, prometheus.MustNewConstMetric(
+				c.overallStatus, prometheus.GaugeValue, cluster.OverallStatusFloat64(), labelValues...,
+			))
+			ch <- prometheus.NewMetricWithTimestamp(cluster.Timestamp, prometheus.MustNewConstMetric(
+				c.numVmotions, prometheus.CounterValue, cluster.NumVmotions, labelValues...,
+			))
+
+			ch <- prometheus.NewMetricWithTimestamp(cluster.Timestamp, prometheus.MustNewConstMetric(
+				c.drsEnabled, prometheus.GaugeValue, b2f(cluster.DRSEnabled), labelValues...,
+			))
+			if cluster.DRSEnabled {
+				ch <- prometheus.NewMetricWithTimestamp(cluster.Timestamp, prometheus.MustNewConstMetric(
+					c.drsAutomationLevel, prometheus.GaugeValue, cluster.DRSBehaviorFloat64(), append(slices.Clone(labelValues), cluster.DRSBehavior)...,
+				))
+				ch <- prometheus.NewMetricWithTimestamp(cluster.Timestamp, prometheus.MustNewConstMetric(
+					c.drsScore, prometheus.GaugeValue, cluster.DRSScore, labelValues...,
+				))
+				for i, n := range cluster.DRSVMsPerScoreBucket {
+					if i >= len(objects.DRSScoreBuckets) {
+						break
+					}
+					ch <- prometheus.NewMetricWithTimestamp(cluster.Timestamp, prometheus.MustNewConstMetric(
+						c.drsVMsPerScoreBucket, prometheus.GaugeValue, n, append(slices.Clone(labelValues), objects.DRSScoreBuckets[i])...,
+					))
+				}
+				ch <- prometheus.NewMetricWithTimestamp(cluster.Timestamp, prometheus.MustNewConstMetric(
+					c.drsPendingRecommendations, prometheus.GaugeValue, cluster.DRSPendingRecommendations, labelValues...,
+				))
+			}
+
+			ch <- prometheus.NewMetricWithTimestamp(cluster.Timestamp, prometheus.MustNewConstMetric(
+				c.haEnabled, prometheus.GaugeValue, b2f(cluster.HAEnabled), labelValues...,
+			))
+			if cluster.HAEnabled {
+				ch <- prometheus.NewMetricWithTimestamp(cluster.Timestamp, prometheus.MustNewConstMetric(
+					c.haHostMonitoring, prometheus.GaugeValue, b2f(cluster.HAHostMonitoring), labelValues...,
+				))
+				ch <- prometheus.NewMetricWithTimestamp(cluster.Timestamp, prometheus.MustNewConstMetric(
+					c.haAdmissionControlEnabled, prometheus.GaugeValue, b2f(cluster.HAAdmissionControlEnabled), labelValues...,
+				))
+				ch <- prometheus.NewMetricWithTimestamp(cluster.Timestamp, prometheus.MustNewConstMetric(
+					c.haAdmissionControlInfo, prometheus.GaugeValue, 1, append(slices.Clone(labelValues), cluster.HAAdmissionControlPolicy, cluster.HAVMMonitoring)...,
+				))
+				ch <- prometheus.NewMetricWithTimestamp(cluster.Timestamp, prometheus.MustNewConstMetric(
+					c.haFailoverLevel, prometheus.GaugeValue, cluster.HAFailoverLevel, labelValues...,
+				))
+				ch <- prometheus.NewMetricWithTimestamp(cluster.Timestamp, prometheus.MustNewConstMetric(
+					c.haCurrentFailoverLevel, prometheus.GaugeValue, cluster.HACurrentFailoverLevel, labelValues...,
+				))
+				if cluster.HAAdmissionControlPolicy == "failover_resources" {
+					ch <- prometheus.NewMetricWithTimestamp(cluster.Timestamp, prometheus.MustNewConstMetric(
+						c.haConfiguredFailoverPercent, prometheus.GaugeValue, cluster.HAConfiguredCPUFailoverPercent, append(slices.Clone(labelValues), "cpu")...,
+					))
+					ch <- prometheus.NewMetricWithTimestamp(cluster.Timestamp, prometheus.MustNewConstMetric(
+						c.haConfiguredFailoverPercent, prometheus.GaugeValue, cluster.HAConfiguredMemoryFailoverPercent, append(slices.Clone(labelValues), "memory")...,
+					))
+					ch <- prometheus.NewMetricWithTimestamp(cluster.Timestamp, prometheus.MustNewConstMetric(
+						c.haCurrentFailoverPercent, prometheus.GaugeValue, cluster.HACurrentCPUFailoverPercent, append(slices.Clone(labelValues), "cpu")...,
+					))
+					ch <- prometheus.NewMetricWithTimestamp(cluster.Timestamp, prometheus.MustNewConstMetric(
+						c.haCurrentFailoverPercent, prometheus.GaugeValue, cluster.HACurrentMemoryFailoverPercent, append(slices.Clone(labelValues), "memory")...,
+					))
+				}
+			}
+
+			ch <- prometheus.NewMetricWithTimestamp(cluster.Timestamp, prometheus.MustNewConstMetric(
+				c.evcModeInfo, prometheus.GaugeValue, 1, append(slices.Clone(labelValues), cluster.EVCMode)...,
+			))
+
+			for _, rule := range cluster.Rules {
+				ruleLabelValues := append(slices.Clone(labelValues), rule.Name, rule.Type)
+				ch <- prometheus.NewMetricWithTimestamp(cluster.Timestamp, prometheus.MustNewConstMetric(
+					c.ruleInfo, prometheus.GaugeValue, 1, append(slices.Clone(ruleLabelValues), rule.VMGroup, rule.TargetGroup)...,
+				))
+				ch <- prometheus.NewMetricWithTimestamp(cluster.Timestamp, prometheus.MustNewConstMetric(
+					c.ruleEnabled, prometheus.GaugeValue, b2f(rule.Enabled), ruleLabelValues...,
+				))
+				ch <- prometheus.NewMetricWithTimestamp(cluster.Timestamp, prometheus.MustNewConstMetric(
+					c.ruleMandatory, prometheus.GaugeValue, b2f(rule.Mandatory), ruleLabelValues...,
+				))
+				// Without vm placements every rule would look compliant
+				if vmHostsKnown && rule.Enabled && rule.Type != objects.ClusterRuleTypeVMDependency {
+					ch <- prometheus.NewMetricWithTimestamp(cluster.Timestamp, prometheus.MustNewConstMetric(
+						c.ruleViolations, prometheus.GaugeValue, cluster.RuleViolations(rule, vmHosts), ruleLabelValues...,
+					))
+				}
+			}
+			for _, group := range cluster.Groups {
+				ch <- prometheus.NewMetricWithTimestamp(cluster.Timestamp, prometheus.MustNewConstMetric(
+					c.groupMembers, prometheus.GaugeValue, float64(len(group.Members)), append(slices.Clone(labelValues), group.Name, group.Type)...,
+				))
+			}
 		}
+	}
+}
 
-		labelValues := []string{cluster.Self.ID(), cluster.Name, cluster.Datacenter}
-		labelValues = append(labelValues, extraLabelValues...)
-
-		ch <- prometheus.NewMetricWithTimestamp(cluster.Timestamp, prometheus.MustNewConstMetric(
-			c.totalCPU, prometheus.GaugeValue, float64(cluster.TotalCPU), labelValues...,
-		))
-		ch <- prometheus.NewMetricWithTimestamp(cluster.Timestamp, prometheus.MustNewConstMetric(
-			c.effectiveCPU, prometheus.GaugeValue, float64(cluster.EffectiveCPU), labelValues...,
-		))
-		ch <- prometheus.NewMetricWithTimestamp(cluster.Timestamp, prometheus.MustNewConstMetric(
-			c.totalMemory, prometheus.GaugeValue, float64(cluster.TotalMemory), labelValues...,
-		))
-		ch <- prometheus.NewMetricWithTimestamp(cluster.Timestamp, prometheus.MustNewConstMetric(
-			c.effectiveMemory, prometheus.GaugeValue, float64(cluster.EffectiveMemory), labelValues...,
-		))
-		ch <- prometheus.NewMetricWithTimestamp(cluster.Timestamp, prometheus.MustNewConstMetric(
-			c.numCPUCores, prometheus.GaugeValue, float64(cluster.NumCPUThreads), labelValues...,
-		))
-		ch <- prometheus.NewMetricWithTimestamp(cluster.Timestamp, prometheus.MustNewConstMetric(
-			c.numCPUThreads, prometheus.GaugeValue, float64(cluster.NumCPUThreads), labelValues...,
-		))
-		ch <- prometheus.NewMetricWithTimestamp(cluster.Timestamp, prometheus.MustNewConstMetric(
-			c.numEffectiveHosts, prometheus.GaugeValue, float64(cluster.NumEffectiveHosts), labelValues...,
-		))
-		ch <- prometheus.NewMetricWithTimestamp(cluster.Timestamp, prometheus.MustNewConstMetric(
-			c.numHosts, prometheus.GaugeValue, cluster.NumHosts, labelValues...,
-		))
-		ch <- prometheus.NewMetricWithTimestamp(cluster.Timestamp, prometheus.MustNewConstMetric(
-			c.overallStatus, prometheus.GaugeValue, cluster.OverallStatusFloat64(), labelValues...,
-		))
+// Map the id of every powered on vm to the id of the host it is running on.
+// Returns false when the vm placements are unknown, because the vm sensor is
+// disabled or the vm's could not be loaded.
+func (c *clusterCollector) runningVMHosts(ctx context.Context) (map[string]string, bool) {
+	vmHosts := map[string]string{}
+	if !c.scraper.VM.Enabled() {
+		return vmHosts, false
+	}
+	vms, err := c.scraper.DB.GetAllVM(ctx)
+	if err != nil {
+		if Logger != nil {
+			Logger.Error("failed to get vm's", "err", err)
+		}
+		return vmHosts, false
+	}
+	for _, vm := range vms {
+		if vm.PowerState == "poweredOn" && vm.HostInfo.HostID != "" {
+			vmHosts[vm.Self.ID()] = vm.HostInfo.HostID
+		}
 	}
+	return vmHosts, true
 }
@@ -0,0 +1,168 @@
+package collector
+
+import (
+	"context"
+	"slices"
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sanderdescamps/govc_exporter/internal/config"
+	"github.com/sanderdescamps/govc_exporter/internal/database/objects"
+	"github.com/sanderdescamps/govc_exporter/internal/scraper"
+)
+
+const (
+	contentLibraryCollectorSubsystem = "content_library"
+)
+
+type contentLibraryCollector struct {
+	scraper *scraper.VCenterScraper
+
+	info                 *prometheus.Desc
+	sizeBytes            *prometheus.Desc
+	items                *prometheus.Desc
+	itemSizeBytes        *prometheus.Desc
+	itemLastModified     *prometheus.Desc
+	lastSync             *prometheus.Desc
+	subscriptionAutoSync *prometheus.Desc
+	subscriptionOnDemand *prometheus.Desc
+	datastoreBytes       *prometheus.Desc
+}
+
+func NewContentLibraryCollector(scraper *scraper.VCenterScraper, cConf config.CollectorConfig) *contentLibraryCollector {
+	labels := []string{"library_id", "library"}
+	infoLabels := append(slices.Clone(labels), "type", "state", "published", "subscription_url")
+	itemsLabels := append(slices.Clone(labels), "item_type", "cached")
+	itemLabels := append(slices.Clone(labels), "item_id", "item", "item_type")
+	datastoreLabels := []string{"datastore_id", "datastore"}
+
+	return &contentLibraryCollector{
+		scraper: scraper,
+		info: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, contentLibraryCollectorSubsystem, "info"),
+			"content library info", infoLabels, nil),
+		sizeBytes: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, contentLibraryCollectorSubsystem, "size_bytes"),
+			"Total size of all items in the content library", labels, nil),
+		items: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, contentLibraryCollectorSubsystem, "items"),
+			"Number of items in the content library per type. Items of subscribed libraries are not cached when only the metadata is synced", itemsLabels, nil),
+		itemSizeBytes: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, contentLibraryCollectorSubsystem, "item_size_bytes"),
+			"Size of the content library item", itemLabels, nil),
+		itemLastModified: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, contentLibraryCollectorSubsystem, "item_last_modified_timestamp_seconds"),
+			"Last time the content library item was modified", itemLabels, nil),
+		lastSync: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, contentLibraryCollectorSubsystem, "last_sync_timestamp_seconds"),
+			"Last time the subscribed content library was synchronized", labels, nil),
+		subscriptionAutoSync: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, contentLibraryCollectorSubsystem, "subscription_automatic_sync"),
+			"Automatic synchronization is enabled for the subscribed content library", labels, nil),
+		subscriptionOnDemand: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, contentLibraryCollectorSubsystem, "subscription_on_demand"),
+			"Subscribed content library only downloads the content of an item when it is used", labels, nil),
+		datastoreBytes: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, contentLibraryCollectorSubsystem, "datastore_bytes"),
+			"Storage consumed by content libraries per datastore", datastoreLabels, nil),
+	}
+}
+
+func (c *contentLibraryCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.info
+	ch <- c.sizeBytes
+	ch <- c.items
+	ch <- c.itemSizeBytes
+	ch <- c.itemLastModified
+	ch <- c.lastSync
+	ch <- c.subscriptionAutoSync
+	ch <- c.subscriptionOnDemand
+	ch <- c.datastoreBytes
+}
+
+func (c *contentLibraryCollector) Collect(ch chan<- prometheus.Metric) {
+	if !c.scraper.ContentLibrary.Enabled() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), COLLECT_TIMEOUT)
+	defer cancel()
+
+	libraries, err := c.scraper.DB.GetAllContentLibrary(ctx)
+	if err != nil && Logger != nil {
+		Logger.Error("failed to get content libraries", "err", err)
+	}
+
+	// datastore id => consumed bytes
+	datastoreBytes := map[string]float64{}
+	for _, lib := range libraries {
+		labelValues := []string{lib.ID, lib.Name}
+
+		subscriptionURL := ""
+		if lib.Subscription != nil {
+			subscriptionURL = lib.Subscription.URL
+		}
+		ch <- prometheus.NewMetricWithTimestamp(lib.Timestamp, prometheus.MustNewConstMetric(
+			c.info, prometheus.GaugeValue, 1, append(slices.Clone(labelValues), lib.Type, lib.State, strconv.FormatBool(lib.Published), subscriptionURL)...,
+		))
+		ch <- prometheus.NewMetricWithTimestamp(lib.Timestamp, prometheus.MustNewConstMetric(
+			c.sizeBytes, prometheus.GaugeValue, lib.Size(), labelValues...,
+		))
+
+		// item type => cached => count
+		itemCount := map[string]map[bool]float64{}
+		for _, item := range lib.Items {
+			if itemCount[item.Type] == nil {
+				itemCount[item.Type] = map[bool]float64{}
+			}
+			itemCount[item.Type][item.Cached]++
+
+			itemLabelValues := append(slices.Clone(labelValues), item.ID, item.Name, item.Type)
+			ch <- prometheus.NewMetricWithTimestamp(lib.Timestamp, prometheus.MustNewConstMetric(
+				c.itemSizeBytes, prometheus.GaugeValue, item.Size, itemLabelValues...,
+			))
+			if !item.LastModifiedTime.IsZero() {
+				ch <- prometheus.NewMetricWithTimestamp(lib.Timestamp, prometheus.MustNewConstMetric(
+					c.itemLastModified, prometheus.GaugeValue, float64(item.LastModifiedTime.Unix()), itemLabelValues...,
+				))
+			}
+		}
+		for itemType, counts := range itemCount {
+			for cached, n := range counts {
+				ch <- prometheus.NewMetricWithTimestamp(lib.Timestamp, prometheus.MustNewConstMetric(
+					c.items, prometheus.GaugeValue, n, append(slices.Clone(labelValues), itemType, strconv.FormatBool(cached))...,
+				))
+			}
+		}
+
+		if lib.Subscription != nil {
+			ch <- prometheus.NewMetricWithTimestamp(lib.Timestamp, prometheus.MustNewConstMetric(
+				c.subscriptionAutoSync, prometheus.GaugeValue, b2f(lib.Subscription.AutomaticSync), labelValues...,
+			))
+			ch <- prometheus.NewMetricWithTimestamp(lib.Timestamp, prometheus.MustNewConstMetric(
+				c.subscriptionOnDemand, prometheus.GaugeValue, b2f(lib.Subscription.OnDemand), labelValues...,
+			))
+		}
+		if !lib.LastSyncTime.IsZero() {
+			ch <- prometheus.NewMetricWithTimestamp(lib.Timestamp, prometheus.MustNewConstMetric(
+				c.lastSync, prometheus.GaugeValue, float64(lib.LastSyncTime.Unix()), labelValues...,
+			))
+		}
+
+		// A library is stored on a single datastore in practice, when
+		// multiple backings are configured the first one is used.
+		if len(lib.Datastores) > 0 {
+			datastoreBytes[lib.Datastores[0].Value] += lib.Size()
+		}
+	}
+
+	for id, size := range datastoreBytes {
+		name := ""
+		if ds := c.scraper.DB.GetDatastore(ctx, objects.ManagedObjectReference{Type: objects.ManagedObjectTypesDatastore, Value: id}); ds != nil {
+			name = ds.Name
+		}
+		ch <- prometheus.MustNewConstMetric(
+			c.datastoreBytes, prometheus.GaugeValue, size, id, name,
+		)
+	}
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/sanderdescamps/govc_exporter/internal/config"
+	"github.com/sanderdescamps/govc_exporter/internal/database/objects"
 	"github.com/sanderdescamps/govc_exporter/internal/scraper"
 )
 
@@ -16,8 +17,10 @@ const (
 
 type datastoreCollector struct {
 	// vcCollector
-	scraper     *scraper.VCenterScraper
-	extraLabels []string
+	scraper         *scraper.VCenterScraper
+	objectLabels    objectLabeler
+	folderPathLabel bool
+	enableVMInfo    bool
 
 	capacity         *prometheus.Desc
 	freeSpace        *prometheus.Desc
@@ -28,21 +31,40 @@ type datastoreCollector struct {
 	hostMounted      *prometheus.Desc
 	hostVmknicActive *prometheus.Desc
 	vmfsInfo         *prometheus.Desc
+
+	uncommitted           *prometheus.Desc
+	provisioned           *prometheus.Desc
+	overprovisioningRatio *prometheus.Desc
+	vms                   *prometheus.Desc
+	templates             *prometheus.Desc
+	vmInfo                *prometheus.Desc
+	vmfsBlockSize         *prometheus.Desc
+	vmfsUnmapEnabled      *prometheus.Desc
+	vmfsUnmapGranularity  *prometheus.Desc
+	nfsInfo               *prometheus.Desc
+	orphanedVMDKFiles     *prometheus.Desc
+	orphanedVMDKBytes     *prometheus.Desc
 }
 
 func NewDatastoreCollector(scraper *scraper.VCenterScraper, cConf config.CollectorConfig) *datastoreCollector {
 	labels := []string{"id", "name", "cluster", "kind"}
-
-	extraLabels := cConf.DatastoreTagLabels
-	if len(extraLabels) != 0 {
-		labels = append(labels, extraLabels...)
+	if cConf.FolderPathLabel {
+		labels = append(labels, "folder_path")
 	}
 
+	objectLabels := newObjectLabeler(cConf.DatastoreTagLabels, cConf.DatastoreAttributeLabels, cConf)
+	labels = objectLabels.Labels(labels)
+
 	hostLables := append(slices.Clone(labels), "esx", "esx_id")
-	vmfsLabels := append(slices.Clone(labels), "uuid", "naa", "ssd", "local")
+	vmfsLabels := append(slices.Clone(labels), "uuid", "naa", "ssd", "local", "version")
+	vmfsUnmapLabels := append(slices.Clone(labels), "unmap_priority", "unmap_bandwidth")
+	vmLabels := append(slices.Clone(labels), "vm", "vm_id", "template")
+	nfsLabels := append(slices.Clone(labels), "nfs_type", "server", "path")
 	return &datastoreCollector{
-		scraper:     scraper,
-		extraLabels: extraLabels,
+		scraper:         scraper,
+		objectLabels:    objectLabels,
+		folderPathLabel: cConf.FolderPathLabel,
+		enableVMInfo:    cConf.DatastoreVMInfo,
 		accessible: prometheus.NewDesc(
 			prometheus.BuildFQName(namespace, datastoreCollectorSubsystem, "accessible"),
 			"datastore is accessible", labels, nil),
@@ -70,6 +92,42 @@ func NewDatastoreCollector(scraper *scraper.VCenterScraper, cConf config.Collect
 		vmfsInfo: prometheus.NewDesc(
 			prometheus.BuildFQName(namespace, datastoreCollectorSubsystem, "vmfs_info"),
 			"Info in case datastore is of type vmsf", vmfsLabels, nil),
+		uncommitted: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, datastoreCollectorSubsystem, "uncommitted_bytes"),
+			"Additional storage space potentially used by thin provisioned disks in bytes", labels, nil),
+		provisioned: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, datastoreCollectorSubsystem, "provisioned_bytes"),
+			"Provisioned space (used + uncommitted) in bytes", labels, nil),
+		overprovisioningRatio: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, datastoreCollectorSubsystem, "overprovisioning_ratio"),
+			"Provisioned space divided by capacity, above 1 means the datastore is overprovisioned", labels, nil),
+		vms: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, datastoreCollectorSubsystem, "vms"),
+			"Number of vm's with files on the datastore, includes the templates when the vm sensor is disabled", labels, nil),
+		templates: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, datastoreCollectorSubsystem, "templates"),
+			"Number of templates with files on the datastore, requires the vm sensor", labels, nil),
+		vmInfo: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, datastoreCollectorSubsystem, "vm_info"),
+			"vm or template with files on the datastore", vmLabels, nil),
+		vmfsBlockSize: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, datastoreCollectorSubsystem, "vmfs_block_size_bytes"),
+			"VMFS block size in bytes", labels, nil),
+		vmfsUnmapEnabled: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, datastoreCollectorSubsystem, "vmfs_unmap_enabled"),
+			"Automatic space reclamation (UNMAP) is enabled on the VMFS volume", vmfsUnmapLabels, nil),
+		vmfsUnmapGranularity: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, datastoreCollectorSubsystem, "vmfs_unmap_granularity_bytes"),
+			"VMFS space reclamation granularity in bytes", labels, nil),
+		nfsInfo: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, datastoreCollectorSubsystem, "nfs_info"),
+			"Info in case datastore is of type nfs", nfsLabels, nil),
+		orphanedVMDKFiles: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, datastoreCollectorSubsystem, "orphaned_vmdk_files"),
+			"Number of vmdk files not used by any registered vm or template", labels, nil),
+		orphanedVMDKBytes: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, datastoreCollectorSubsystem, "orphaned_vmdk_bytes"),
+			"Size of the vmdk files not used by any registered vm or template in bytes", labels, nil),
 	}
 }
 
@@ -83,6 +141,18 @@ func (c *datastoreCollector) Describe(ch chan<- *prometheus.Desc) {
 	ch <- c.hostMounted
 	ch <- c.hostVmknicActive
 	ch <- c.vmfsInfo
+	ch <- c.uncommitted
+	ch <- c.provisioned
+	ch <- c.overprovisioningRatio
+	ch <- c.vms
+	ch <- c.templates
+	ch <- c.vmInfo
+	ch <- c.vmfsBlockSize
+	ch <- c.vmfsUnmapEnabled
+	ch <- c.vmfsUnmapGranularity
+	ch <- c.nfsInfo
+	ch <- c.orphanedVMDKFiles
+	ch <- c.orphanedVMDKBytes
 }
 
 func (c *datastoreCollector) Collect(ch chan<- prometheus.Metric) {
@@ -97,57 +167,139 @@ func (c *datastoreCollector) Collect(ch chan<- prometheus.Metric) {
 	if err != nil && Logger != nil {
 		Logger.Error("failed to get datastores", "err", err)
 	}
+
+	// Templates can only be told apart from vm's with the data of the vm sensor
+	vmsKnown := c.scraper.VM.Enabled()
+	vms := map[string]objects.VirtualMachine{}
+	if vmsKnown && slices.ContainsFunc(datastores, func(d objects.Datastore) bool { return len(d.VMs) > 0 }) {
+		allVMs, err := c.scraper.DB.GetAllVM(ctx)
+		if err != nil && Logger != nil {
+			Logger.Error("failed to get vm's", "err", err)
+		}
+		for _, vm := range allVMs {
+			vms[vm.Self.ID()] = vm
+		}
+	}
+
 	for _, datastore := range datastores {
 
-		extraLabelValues := []string{}
-		objectTags := c.scraper.DB.GetTags(ctx, datastore.Self)
-		for _, tagCat := range c.extraLabels {
-			extraLabelValues = append(extraLabelValues, objectTags.GetTag(tagCat))
+		var orphans *objects.OrphanedVMDKs
+		if c.scraper.OrphanedVMDK.Enabled() {
+			if o := c.scraper.DB.GetOrphanedVMDKs(ctx, datastore.Self); o != nil && !o.Timestamp.IsZero() {
+				orphans = o
+			}
 		}
 
-		labelValues := []string{datastore.Self.ID(), datastore.Name, datastore.DatastoreCluster, datastore.Kind}
-		labelValues = append(labelValues, extraLabelValues...)
-
-		ch <- prometheus.NewMetricWithTimestamp(datastore.Timestamp, prometheus.MustNewConstMetric(
-			c.accessible, prometheus.GaugeValue, b2f(datastore.Accessible), labelValues...,
-		))
-		ch <- prometheus.NewMetricWithTimestamp(datastore.Timestamp, prometheus.MustNewConstMetric(
-			c.capacity, prometheus.GaugeValue, float64(datastore.Capacity), labelValues...,
-		))
-		ch <- prometheus.NewMetricWithTimestamp(datastore.Timestamp, prometheus.MustNewConstMetric(
-			c.freeSpace, prometheus.GaugeValue, float64(datastore.FreeSpace), labelValues...,
-		))
-		ch <- prometheus.NewMetricWithTimestamp(datastore.Timestamp, prometheus.MustNewConstMetric(
-			c.maintenance, prometheus.GaugeValue, datastore.MaintenanceStatusFloat64(), labelValues...,
-		))
-		ch <- prometheus.NewMetricWithTimestamp(datastore.Timestamp, prometheus.MustNewConstMetric(
-			c.overallStatus, prometheus.GaugeValue, datastore.OverallStatusFloat64(), labelValues...,
-		))
-
-		for _, mountInfo := range datastore.HostMountInfo {
-			hostLabelValues := append(slices.Clone(labelValues), mountInfo.Host, mountInfo.HostID)
+		objectTags := c.scraper.DB.GetTags(ctx, datastore.Self)
+		objectAttributes := c.scraper.DB.GetAttributes(ctx, datastore.Self)
+		baseLabelValues := []string{datastore.Self.ID(), datastore.Name, datastore.DatastoreCluster, datastore.Kind}
+		if c.folderPathLabel {
+			baseLabelValues = append(baseLabelValues, datastore.FolderPath)
+		}
+		for _, labelValues := range c.objectLabels.LabelValues(baseLabelValues, objectTags, objectAttributes) {
 			ch <- prometheus.NewMetricWithTimestamp(datastore.Timestamp, prometheus.MustNewConstMetric(
-				c.hostAccessible, prometheus.GaugeValue, b2f(mountInfo.Accessible), hostLabelValues...,
+				c.accessible, prometheus.GaugeValue, b2f(datastore.Accessible), labelValues...,
 			))
 			ch <- prometheus.NewMetricWithTimestamp(datastore.Timestamp, prometheus.MustNewConstMetric(
-				c.hostMounted, prometheus.GaugeValue, b2f(mountInfo.Mounted), hostLabelValues...,
+				c.capacity, prometheus.GaugeValue, float64(datastore.Capacity), labelValues...,
 			))
 			ch <- prometheus.NewMetricWithTimestamp(datastore.Timestamp, prometheus.MustNewConstMetric(
-				c.hostVmknicActive, prometheus.GaugeValue, b2f(mountInfo.VmknicActiveNic), hostLabelValues...,
+				c.freeSpace, prometheus.GaugeValue, float64(datastore.FreeSpace), labelValues...,
 			))
-		}
-
-		if vmfsInfo := datastore.VmfsInfo; vmfsInfo != nil {
-			vmfsLabelValues := append(
-				slices.Clone(labelValues),
-				vmfsInfo.UUID,
-				vmfsInfo.NAA,
-				strconv.FormatBool(vmfsInfo.SSD),
-				strconv.FormatBool(vmfsInfo.Local),
-			)
 			ch <- prometheus.NewMetricWithTimestamp(datastore.Timestamp, prometheus.MustNewConstMetric(
-				c.vmfsInfo, prometheus.GaugeValue, 1, vmfsLabelValues...,
+				c.maintenance, prometheus.GaugeValue, datastore.MaintenanceStatusFloat64(), labelValues...,
 			))
+			ch <- prometheus.NewMetricWithTimestamp(datastore.Timestamp, prometheus.MustNewConstMetric(
+				c.overallStatus, prometheus.GaugeValue, datastore.OverallStatusFloat64(), labelValues...,
+			))
+			ch <- prometheus.NewMetricWithTimestamp(datastore.Timestamp, prometheus.MustNewConstMetric(
+				c.uncommitted, prometheus.GaugeValue, datastore.Uncommitted, labelValues...,
+			))
+			ch <- prometheus.NewMetricWithTimestamp(datastore.Timestamp, prometheus.MustNewConstMetric(
+				c.provisioned, prometheus.GaugeValue, datastore.ProvisionedFloat64(), labelValues...,
+			))
+			ch <- prometheus.NewMetricWithTimestamp(datastore.Timestamp, prometheus.MustNewConstMetric(
+				c.overprovisioningRatio, prometheus.GaugeValue, datastore.OverprovisioningRatio(), labelValues...,
+			))
+
+			if vmsKnown {
+				// vm's which are not (yet) known to the vm sensor are counted as vm
+				numVMs, numTemplates := 0.0, 0.0
+				for _, vmID := range datastore.VMs {
+					vm, ok := vms[vmID]
+					if vm.Template {
+						numTemplates++
+					} else {
+						numVMs++
+					}
+					if ok && c.enableVMInfo {
+						ch <- prometheus.NewMetricWithTimestamp(datastore.Timestamp, prometheus.MustNewConstMetric(
+							c.vmInfo, prometheus.GaugeValue, 1, append(slices.Clone(labelValues), vm.Name, vmID, strconv.FormatBool(vm.Template))...,
+						))
+					}
+				}
+				ch <- prometheus.NewMetricWithTimestamp(datastore.Timestamp, prometheus.MustNewConstMetric(
+					c.vms, prometheus.GaugeValue, numVMs, labelValues...,
+				))
+				ch <- prometheus.NewMetricWithTimestamp(datastore.Timestamp, prometheus.MustNewConstMetric(
+					c.templates, prometheus.GaugeValue, numTemplates, labelValues...,
+				))
+			} else {
+				ch <- prometheus.NewMetricWithTimestamp(datastore.Timestamp, prometheus.MustNewConstMetric(
+					c.vms, prometheus.GaugeValue, float64(len(datastore.VMs)), labelValues...,
+				))
+			}
+
+			for _, mountInfo := range datastore.HostMountInfo {
+				hostLabelValues := append(slices.Clone(labelValues), mountInfo.Host, mountInfo.HostID)
+				ch <- prometheus.NewMetricWithTimestamp(datastore.Timestamp, prometheus.MustNewConstMetric(
+					c.hostAccessible, prometheus.GaugeValue, b2f(mountInfo.Accessible), hostLabelValues...,
+				))
+				ch <- prometheus.NewMetricWithTimestamp(datastore.Timestamp, prometheus.MustNewConstMetric(
+					c.hostMounted, prometheus.GaugeValue, b2f(mountInfo.Mounted), hostLabelValues...,
+				))
+				ch <- prometheus.NewMetricWithTimestamp(datastore.Timestamp, prometheus.MustNewConstMetric(
+					c.hostVmknicActive, prometheus.GaugeValue, b2f(mountInfo.VmknicActiveNic), hostLabelValues...,
+				))
+			}
+
+			if vmfsInfo := datastore.VmfsInfo; vmfsInfo != nil {
+				vmfsLabelValues := append(
+					slices.Clone(labelValues),
+					vmfsInfo.UUID,
+					vmfsInfo.NAA,
+					strconv.FormatBool(vmfsInfo.SSD),
+					strconv.FormatBool(vmfsInfo.Local),
+					vmfsInfo.Version,
+				)
+				ch <- prometheus.NewMetricWithTimestamp(datastore.Timestamp, prometheus.MustNewConstMetric(
+					c.vmfsInfo, prometheus.GaugeValue, 1, vmfsLabelValues...,
+				))
+				ch <- prometheus.NewMetricWithTimestamp(datastore.Timestamp, prometheus.MustNewConstMetric(
+					c.vmfsBlockSize, prometheus.GaugeValue, vmfsInfo.BlockSize, labelValues...,
+				))
+				ch <- prometheus.NewMetricWithTimestamp(datastore.Timestamp, prometheus.MustNewConstMetric(
+					c.vmfsUnmapEnabled, prometheus.GaugeValue, b2f(vmfsInfo.UnmapEnabled()), append(slices.Clone(labelValues), vmfsInfo.UnmapPriority, vmfsInfo.UnmapBandwidthSpec)...,
+				))
+				ch <- prometheus.NewMetricWithTimestamp(datastore.Timestamp, prometheus.MustNewConstMetric(
+					c.vmfsUnmapGranularity, prometheus.GaugeValue, vmfsInfo.UnmapGranularity, labelValues...,
+				))
+			}
+
+			if nasInfo := datastore.NasInfo; nasInfo != nil {
+				ch <- prometheus.NewMetricWithTimestamp(datastore.Timestamp, prometheus.MustNewConstMetric(
+					c.nfsInfo, prometheus.GaugeValue, 1, append(slices.Clone(labelValues), nasInfo.Type, nasInfo.RemoteHost, nasInfo.RemotePath)...,
+				))
+			}
+
+			if orphans != nil {
+				ch <- prometheus.NewMetricWithTimestamp(orphans.Timestamp, prometheus.MustNewConstMetric(
+					c.orphanedVMDKFiles, prometheus.GaugeValue, float64(len(orphans.Files)), labelValues...,
+				))
+				ch <- prometheus.NewMetricWithTimestamp(orphans.Timestamp, prometheus.MustNewConstMetric(
+					c.orphanedVMDKBytes, prometheus.GaugeValue, orphans.TotalSize(), labelValues...,
+				))
+			}
 		}
 	}
 
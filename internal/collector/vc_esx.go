@@ -5,9 +5,11 @@ import (
 	"fmt"
 	"slices"
 	"strconv"
+	"strings"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/sanderdescamps/govc_exporter/internal/config"
+	"github.com/sanderdescamps/govc_exporter/internal/database/objects"
 	"github.com/sanderdescamps/govc_exporter/internal/scraper"
 )
 
@@ -17,8 +19,11 @@ const (
 
 type esxCollector struct {
 	// vcCollector
-	enableStorageMetrics bool
-	extraLabels          []string
+	enableStorageMetrics  bool
+	enableHardwareMetrics bool
+	enableNetworkMetrics  bool
+	objectLabels          objectLabeler
+	folderPathLabel       bool
 
 	scraper                        *scraper.VCenterScraper
 	powerState                     *prometheus.Desc
@@ -42,21 +47,59 @@ type esxCollector struct {
 	hbaStatus                *prometheus.Desc
 	hbaIscsiSendTargetInfo   *prometheus.Desc
 	hbaIscsiStaticTargetInfo *prometheus.Desc
+	hbaIscsiSessionState     *prometheus.Desc
+	hbaSpeedMbps             *prometheus.Desc
+	hbaFcInfo                *prometheus.Desc
+	hbaNvmeControllerInfo    *prometheus.Desc
+	hbaNvmeControllerQueues  *prometheus.Desc
+	hbaNvmeNamespaceBytes    *prometheus.Desc
 	multipathPathState       *prometheus.Desc
 	volumeMounted            *prometheus.Desc
 	volumeAccessible         *prometheus.Desc
 	scsiLunActivePath        *prometheus.Desc
 	scsiLunTotalPath         *prometheus.Desc
 
+	// only used when enableHardwareMetrics == true
+	cpuPackageInfo       *prometheus.Desc
+	cpuPackageHz         *prometheus.Desc
+	hardwareMemoryBytes  *prometheus.Desc
+	pciDeviceInfo        *prometheus.Desc
+	pciPassthruEnabled   *prometheus.Desc
+	pciPassthruActive    *prometheus.Desc
+	gpuInfo              *prometheus.Desc
+	gpuMemoryBytes       *prometheus.Desc
+	gpuVMs               *prometheus.Desc
+	nicInfo              *prometheus.Desc
+	nicLinkSpeedMbps     *prometheus.Desc
+	hbaInfo              *prometheus.Desc
+	tpmAttestationStatus *prometheus.Desc
+
+	// only used when enableNetworkMetrics == true
+	nicLinkUp               *prometheus.Desc
+	vswitchInfo             *prometheus.Desc
+	vswitchMTU              *prometheus.Desc
+	vswitchMTUMismatch      *prometheus.Desc
+	vswitchPorts            *prometheus.Desc
+	vswitchUplinks          *prometheus.Desc
+	vswitchSecurityPolicy   *prometheus.Desc
+	portgroupVlanID         *prometheus.Desc
+	portgroupPorts          *prometheus.Desc
+	portgroupSecurityPolicy *prometheus.Desc
+	vmknicInfo              *prometheus.Desc
+	vmknicMTU               *prometheus.Desc
+	vmknicMTUMismatch       *prometheus.Desc
+	vmknicService           *prometheus.Desc
+
 	vmNumTotal *prometheus.Desc
 }
 
 func NewEsxCollector(scraper *scraper.VCenterScraper, cConf config.CollectorConfig) *esxCollector {
 	labels := []string{"id", "name", "datacenter", "cluster"}
-	extraLabels := cConf.HostTagLabels
-	if len(extraLabels) != 0 {
-		labels = append(labels, extraLabels...)
+	if cConf.FolderPathLabel {
+		labels = append(labels, "folder_path")
 	}
+	objectLabels := newObjectLabeler(cConf.HostTagLabels, cConf.HostAttributeLabels, cConf)
+	labels = objectLabels.Labels(labels)
 
 	infoLabels := append(slices.Clone(labels), "os_version", "vendor", "model", "asset_tag", "service_tag", "bios_version")
 	sysNumLabels := append(slices.Clone(labels), "sensor_id", "sensor_name", "sensor_type", "sensor_unit")
@@ -65,14 +108,41 @@ func NewEsxCollector(scraper *scraper.VCenterScraper, cConf config.CollectorConf
 	hbaLabels := append(slices.Clone(labels), "adapter_name", "driver", "model")
 	iscsiHbaSendTargetLabels := append(slices.Clone(labels), "adapter_name", "driver", "model", "target_address")
 	iscsiHbaStaticTargetLabels := append(slices.Clone(labels), "adapter_name", "driver", "model", "target_address", "target_name", "discovery_method", "initiator_name")
-	multipathStatusLabels := append(slices.Clone(labels), "path_name", "adapter_name", "target_address", "target_name", "lun", "canonical_name")
+	iscsiHbaSessionLabels := append(slices.Clone(labels), "adapter_name", "driver", "model", "target_address", "target_name")
+	fcHbaInfoLabels := append(slices.Clone(labels), "adapter_name", "driver", "model", "protocol", "node_wwn", "port_wwn", "port_type")
+	nvmeControllerInfoLabels := append(slices.Clone(labels), "adapter_name", "controller", "controller_number", "subnqn", "transport_type")
+	nvmeControllerLabels := append(slices.Clone(labels), "adapter_name", "controller")
+	nvmeNamespaceLabels := append(slices.Clone(labels), "adapter_name", "controller", "namespace_id", "canonical_name")
+	multipathStatusLabels := append(slices.Clone(labels), "path_name", "adapter_name", "transport", "target_address", "target_name", "target_wwnn", "target_wwpn", "lun", "canonical_name")
 	volumeLabels := append(slices.Clone(labels), "uuid", "canonical_name", "datastore", "local", "ssd")
 	scsiLunLabels := append(slices.Clone(labels), "vendor", "model", "canonical_name", "local", "ssd")
 
+	cpuPackageInfoLabels := append(slices.Clone(labels), "package", "vendor", "description")
+	cpuPackageLabels := append(slices.Clone(labels), "package")
+	pciDeviceInfoLabels := append(slices.Clone(labels), "pci_id", "class_id", "vendor_name", "device_name")
+	pciDeviceLabels := append(slices.Clone(labels), "pci_id", "device_name")
+	gpuInfoLabels := append(slices.Clone(labels), "pci_id", "vendor_name", "device_name", "graphics_type", "vgpu_mode")
+	nicInfoLabels := append(slices.Clone(labels), "nic", "pci_id", "mac", "driver", "driver_version", "firmware_version")
+	nicLabels := append(slices.Clone(labels), "nic")
+	hbaInfoLabels := append(slices.Clone(labels), "adapter_name", "pci_id", "driver", "model", "vendor_name", "device_name")
+	tpmLabels := append(slices.Clone(labels), "status")
+
+	vswitchLabels := append(slices.Clone(labels), "vswitch")
+	vswitchInfoLabels := append(slices.Clone(labels), "vswitch", "uplinks")
+	vswitchPolicyLabels := append(slices.Clone(labels), "vswitch", "policy")
+	portgroupLabels := append(slices.Clone(labels), "portgroup", "vswitch")
+	portgroupPolicyLabels := append(slices.Clone(labels), "portgroup", "vswitch", "policy")
+	vmknicInfoLabels := append(slices.Clone(labels), "vmknic", "portgroup", "distributed_switch", "mac", "ip", "subnet_mask", "dhcp", "netstack", "services", "mtu")
+	vmknicMTULabels := append(slices.Clone(labels), "vmknic", "portgroup", "netstack", "services")
+	vmknicServiceLabels := append(slices.Clone(labels), "vmknic", "service")
+
 	return &esxCollector{
-		scraper:              scraper,
-		enableStorageMetrics: cConf.HostStorageMetrics,
-		extraLabels:          extraLabels,
+		scraper:               scraper,
+		enableStorageMetrics:  cConf.HostStorageMetrics,
+		enableHardwareMetrics: cConf.HostHardwareMetrics,
+		enableNetworkMetrics:  cConf.HostNetworkMetrics,
+		objectLabels:          objectLabels,
+		folderPathLabel:       cConf.FolderPathLabel,
 		//GENERAL
 		powerState: prometheus.NewDesc(
 			prometheus.BuildFQName(namespace, esxCollectorSubsystem, "power_state"),
@@ -96,6 +166,89 @@ func NewEsxCollector(scraper *scraper.VCenterScraper, cConf config.CollectorConf
 			prometheus.BuildFQName(namespace, esxCollectorSubsystem, "info"),
 			"Additional information", infoLabels, nil),
 
+		//HARDWARE
+		cpuPackageInfo: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, esxCollectorSubsystem, "cpu_package_info"),
+			"cpu package (socket) of the host", cpuPackageInfoLabels, nil),
+		cpuPackageHz: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, esxCollectorSubsystem, "cpu_package_hz"),
+			"cpu package frequency in hz", cpuPackageLabels, nil),
+		hardwareMemoryBytes: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, esxCollectorSubsystem, "hardware_memory_bytes"),
+			"physical memory of the host in bytes", labels, nil),
+		pciDeviceInfo: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, esxCollectorSubsystem, "pci_device_info"),
+			"pci device of the host", pciDeviceInfoLabels, nil),
+		pciPassthruEnabled: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, esxCollectorSubsystem, "pci_passthru_enabled"),
+			"passthrough is enabled for the pci device, only for passthrough capable devices", pciDeviceLabels, nil),
+		pciPassthruActive: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, esxCollectorSubsystem, "pci_passthru_active"),
+			"passthrough is active for the pci device, only for passthrough capable devices", pciDeviceLabels, nil),
+		gpuInfo: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, esxCollectorSubsystem, "gpu_info"),
+			"graphics device of the host", gpuInfoLabels, nil),
+		gpuMemoryBytes: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, esxCollectorSubsystem, "gpu_memory_bytes"),
+			"memory of the graphics device in bytes", pciDeviceLabels, nil),
+		gpuVMs: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, esxCollectorSubsystem, "gpu_vms"),
+			"number of vm's using the graphics device", pciDeviceLabels, nil),
+		nicInfo: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, esxCollectorSubsystem, "nic_info"),
+			"physical nic of the host with driver and firmware version", nicInfoLabels, nil),
+		nicLinkSpeedMbps: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, esxCollectorSubsystem, "nic_link_speed_mbps"),
+			"link speed of the physical nic, 0 when the link is down", nicLabels, nil),
+		nicLinkUp: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, esxCollectorSubsystem, "nic_link_up"),
+			"link state of the physical nic", nicLabels, nil),
+		vswitchInfo: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, esxCollectorSubsystem, "vswitch_info"),
+			"standard vswitch with its uplinks", vswitchInfoLabels, nil),
+		vswitchMTU: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, esxCollectorSubsystem, "vswitch_mtu"),
+			"MTU of the standard vswitch", vswitchLabels, nil),
+		vswitchMTUMismatch: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, esxCollectorSubsystem, "vswitch_mtu_mismatch"),
+			"1 when a vswitch with the same name has a different MTU on another host of the cluster, only for hosts in a cluster", vswitchLabels, nil),
+		vswitchPorts: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, esxCollectorSubsystem, "vswitch_ports"),
+			"number of ports of the standard vswitch", vswitchLabels, nil),
+		vswitchUplinks: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, esxCollectorSubsystem, "vswitch_uplinks"),
+			"number of physical nics connected to the standard vswitch", vswitchLabels, nil),
+		vswitchSecurityPolicy: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, esxCollectorSubsystem, "vswitch_security_policy"),
+			"security policy of the standard vswitch (allow_promiscuous, mac_changes, forged_transmits)", vswitchPolicyLabels, nil),
+		portgroupVlanID: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, esxCollectorSubsystem, "portgroup_vlan_id"),
+			"VLAN id of the standard portgroup (0=none, 4095=trunk)", portgroupLabels, nil),
+		portgroupPorts: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, esxCollectorSubsystem, "portgroup_ports"),
+			"number of vm's and vmkernel adapters connected to the standard portgroup", portgroupLabels, nil),
+		portgroupSecurityPolicy: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, esxCollectorSubsystem, "portgroup_security_policy"),
+			"effective security policy of the standard portgroup (allow_promiscuous, mac_changes, forged_transmits)", portgroupPolicyLabels, nil),
+		vmknicInfo: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, esxCollectorSubsystem, "vmknic_info"),
+			"vmkernel network adapter", vmknicInfoLabels, nil),
+		vmknicMTU: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, esxCollectorSubsystem, "vmknic_mtu"),
+			"MTU of the vmkernel adapter", vmknicMTULabels, nil),
+		vmknicMTUMismatch: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, esxCollectorSubsystem, "vmknic_mtu_mismatch"),
+			"1 when a vmkernel adapter with the same services (or portgroup) has a different MTU on another host of the cluster, only for hosts in a cluster", vmknicMTULabels, nil),
+		vmknicService: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, esxCollectorSubsystem, "vmknic_service_enabled"),
+			"service enabled on the vmkernel adapter (management, vmotion, vsan, ...)", vmknicServiceLabels, nil),
+		hbaInfo: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, esxCollectorSubsystem, "hba_info"),
+			"host bus adapter with driver and pci device", hbaInfoLabels, nil),
+		tpmAttestationStatus: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, esxCollectorSubsystem, "tpm_attestation_status"),
+			"tpm attestation status (0=unknown, 1=notAccepted, 2=accepted)", tpmLabels, nil),
+
 		//CPU
 		cpuCoresTotal: prometheus.NewDesc(
 			prometheus.BuildFQName(namespace, esxCollectorSubsystem, "cpu_cores"),
@@ -141,6 +294,24 @@ func NewEsxCollector(scraper *scraper.VCenterScraper, cConf config.CollectorConf
 		hbaIscsiStaticTargetInfo: prometheus.NewDesc(
 			prometheus.BuildFQName(namespace, esxCollectorSubsystem, "hba_iscsi_static_target_info"),
 			"The configured iSCSI static target entries.", iscsiHbaStaticTargetLabels, nil),
+		hbaIscsiSessionState: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, esxCollectorSubsystem, "hba_iscsi_session_state"),
+			"iSCSI session state of a static target derived from its paths (0=unknown, 1=offline, 2=online)", iscsiHbaSessionLabels, nil),
+		hbaSpeedMbps: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, esxCollectorSubsystem, "hba_speed_mbps"),
+			"Current speed of FC and iSCSI HBA cards in Mbps", hbaLabels, nil),
+		hbaFcInfo: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, esxCollectorSubsystem, "hba_fc_info"),
+			"Fibre Channel HBA world wide names and port type", fcHbaInfoLabels, nil),
+		hbaNvmeControllerInfo: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, esxCollectorSubsystem, "hba_nvme_controller_info"),
+			"NVMe controller connected to the HBA", nvmeControllerInfoLabels, nil),
+		hbaNvmeControllerQueues: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, esxCollectorSubsystem, "hba_nvme_controller_queues"),
+			"Number of IO queues of the NVMe controller", nvmeControllerLabels, nil),
+		hbaNvmeNamespaceBytes: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, esxCollectorSubsystem, "hba_nvme_namespace_capacity_bytes"),
+			"Capacity of the NVMe namespace attached to the controller", nvmeNamespaceLabels, nil),
 		multipathPathState: prometheus.NewDesc(
 			prometheus.BuildFQName(namespace, esxCollectorSubsystem, "multipath_path_state"),
 			"Multipath path state", multipathStatusLabels, nil),
@@ -181,11 +352,44 @@ func (c *esxCollector) Describe(ch chan<- *prometheus.Desc) {
 	ch <- c.hbaIscsiSendTargetInfo
 	ch <- c.hbaIscsiStaticTargetInfo
 	ch <- c.hbaStatus
+	ch <- c.hbaIscsiSessionState
+	ch <- c.hbaSpeedMbps
+	ch <- c.hbaFcInfo
+	ch <- c.hbaNvmeControllerInfo
+	ch <- c.hbaNvmeControllerQueues
+	ch <- c.hbaNvmeNamespaceBytes
 	ch <- c.multipathPathState
 	ch <- c.scsiLunActivePath
 	ch <- c.scsiLunTotalPath
 	ch <- c.vmNumTotal
 	ch <- c.info
+	ch <- c.cpuPackageInfo
+	ch <- c.cpuPackageHz
+	ch <- c.hardwareMemoryBytes
+	ch <- c.pciDeviceInfo
+	ch <- c.pciPassthruEnabled
+	ch <- c.pciPassthruActive
+	ch <- c.gpuInfo
+	ch <- c.gpuMemoryBytes
+	ch <- c.gpuVMs
+	ch <- c.nicInfo
+	ch <- c.nicLinkSpeedMbps
+	ch <- c.nicLinkUp
+	ch <- c.vswitchInfo
+	ch <- c.vswitchMTU
+	ch <- c.vswitchMTUMismatch
+	ch <- c.vswitchPorts
+	ch <- c.vswitchUplinks
+	ch <- c.vswitchSecurityPolicy
+	ch <- c.portgroupVlanID
+	ch <- c.portgroupPorts
+	ch <- c.portgroupSecurityPolicy
+	ch <- c.vmknicInfo
+	ch <- c.vmknicMTU
+	ch <- c.vmknicMTUMismatch
+	ch <- c.vmknicService
+	ch <- c.hbaInfo
+	ch <- c.tpmAttestationStatus
 }
 
 func (c *esxCollector) Collect(ch chan<- prometheus.Metric) {
@@ -200,128 +404,358 @@ func (c *esxCollector) Collect(ch chan<- prometheus.Metric) {
 	if err != nil && Logger != nil {
 		Logger.Error("failed to get hosts", "err", err)
 	}
+	mtuMismatches := newClusterMTUs(hosts)
 	for _, host := range hosts {
-		extraLabelValues := []string{}
 		objectTags := c.scraper.DB.GetTags(ctx, host.Self)
-		for _, tagCat := range c.extraLabels {
-			extraLabelValues = append(extraLabelValues, objectTags.GetTag(tagCat))
+		objectAttributes := c.scraper.DB.GetAttributes(ctx, host.Self)
+		baseLabelValues := []string{host.Self.ID(), host.Name, host.Datacenter, host.Cluster}
+		if c.folderPathLabel {
+			baseLabelValues = append(baseLabelValues, host.FolderPath)
 		}
+		for _, labelValues := range c.objectLabels.LabelValues(baseLabelValues, objectTags, objectAttributes) {
+			infoLabelValues := append(slices.Clone(labelValues), host.OSVersion, host.Vendor, host.Model, host.AssetTag, host.ServiceTag, host.BiosVersion)
 
-		labelValues := []string{host.Self.ID(), host.Name, host.Datacenter, host.Cluster}
-		labelValues = append(labelValues, extraLabelValues...)
-		infoLabelValues := append(slices.Clone(labelValues), host.OSVersion, host.Vendor, host.Model, host.AssetTag, host.ServiceTag, host.BiosVersion)
+			for _, health := range host.SystemHealthNumericSensors {
+				sysLabelsValues := append(slices.Clone(labelValues), health.ID, health.Name, health.Type, health.Unit)
+				ch <- prometheus.NewMetricWithTimestamp(host.Timestamp,
+					prometheus.MustNewConstMetric(
+						c.systemHealthNumericSensorValue, prometheus.GaugeValue, health.Value, sysLabelsValues...,
+					))
+				ch <- prometheus.NewMetricWithTimestamp(host.Timestamp,
+					prometheus.MustNewConstMetric(
+						c.systemHealthNumericSensorState, prometheus.GaugeValue, health.HealthStatus(), sysLabelsValues...,
+					))
+			}
 
-		for _, health := range host.SystemHealthNumericSensors {
-			sysLabelsValues := append(slices.Clone(labelValues), health.ID, health.Name, health.Type, health.Unit)
-			ch <- prometheus.NewMetricWithTimestamp(host.Timestamp,
-				prometheus.MustNewConstMetric(
-					c.systemHealthNumericSensorValue, prometheus.GaugeValue, health.Value, sysLabelsValues...,
-				))
-			ch <- prometheus.NewMetricWithTimestamp(host.Timestamp,
-				prometheus.MustNewConstMetric(
-					c.systemHealthNumericSensorState, prometheus.GaugeValue, health.HealthStatus(), sysLabelsValues...,
+			for _, elementStatus := range host.HardwareStatus {
+				sysLabelsValues := append(slices.Clone(labelValues), "memory", elementStatus.Name)
+				ch <- prometheus.NewMetricWithTimestamp(host.Timestamp, prometheus.MustNewConstMetric(
+					c.systemHealthStatusSensor, prometheus.GaugeValue, elementStatus.HealthStatus(), sysLabelsValues...,
 				))
-		}
+			}
 
-		for _, elementStatus := range host.HardwareStatus {
-			sysLabelsValues := append(slices.Clone(labelValues), "memory", elementStatus.Name)
 			ch <- prometheus.NewMetricWithTimestamp(host.Timestamp, prometheus.MustNewConstMetric(
-				c.systemHealthStatusSensor, prometheus.GaugeValue, elementStatus.HealthStatus(), sysLabelsValues...,
+				c.info, prometheus.GaugeValue, 1, infoLabelValues...,
+			))
+			ch <- prometheus.NewMetricWithTimestamp(host.Timestamp, prometheus.MustNewConstMetric(
+				c.powerState, prometheus.GaugeValue, host.PowerStateFloat64(), labelValues...,
+			))
+			ch <- prometheus.NewMetricWithTimestamp(host.Timestamp, prometheus.MustNewConstMetric(
+				c.connectionState, prometheus.GaugeValue, host.ConnectionStateFloat64(), labelValues...,
+			))
+			ch <- prometheus.NewMetricWithTimestamp(host.Timestamp, prometheus.MustNewConstMetric(
+				c.maintenance, prometheus.GaugeValue, b2f(host.Maintenance), labelValues...,
+			))
+			ch <- prometheus.NewMetricWithTimestamp(host.Timestamp, prometheus.MustNewConstMetric(
+				c.uptimeSeconds, prometheus.GaugeValue, host.UptimeSeconds, labelValues...,
+			))
+			ch <- prometheus.NewMetricWithTimestamp(host.Timestamp, prometheus.MustNewConstMetric(
+				c.rebootRequired, prometheus.GaugeValue, b2f(host.RebootRequired), labelValues...,
+			))
+			ch <- prometheus.NewMetricWithTimestamp(host.Timestamp, prometheus.MustNewConstMetric(
+				c.cpuCoresTotal, prometheus.GaugeValue, host.CPUCoresTotal, labelValues...,
+			))
+			ch <- prometheus.NewMetricWithTimestamp(host.Timestamp, prometheus.MustNewConstMetric(
+				c.cpuThreadsTotal, prometheus.GaugeValue, host.CPUThreadsTotal, labelValues...,
+			))
+			ch <- prometheus.NewMetricWithTimestamp(host.Timestamp, prometheus.MustNewConstMetric(
+				c.availCPUMhz, prometheus.GaugeValue, host.AvailCPUMhz, labelValues...,
+			))
+			ch <- prometheus.NewMetricWithTimestamp(host.Timestamp, prometheus.MustNewConstMetric(
+				c.usedCPUMhz, prometheus.GaugeValue, host.UsedCPUMhz, labelValues...,
+			))
+			ch <- prometheus.NewMetricWithTimestamp(host.Timestamp, prometheus.MustNewConstMetric(
+				c.availMemBytes, prometheus.GaugeValue, host.AvailMemBytes, labelValues...,
+			))
+			ch <- prometheus.NewMetricWithTimestamp(host.Timestamp, prometheus.MustNewConstMetric(
+				c.usedMemBytes, prometheus.GaugeValue, host.UsedMemBytes, labelValues...,
+			))
+			ch <- prometheus.NewMetricWithTimestamp(host.Timestamp, prometheus.MustNewConstMetric(
+				c.overallStatus, prometheus.GaugeValue, host.OverallStatusFloat64(), labelValues...,
 			))
-		}
 
-		ch <- prometheus.NewMetricWithTimestamp(host.Timestamp, prometheus.MustNewConstMetric(
-			c.info, prometheus.GaugeValue, 1, infoLabelValues...,
-		))
-		ch <- prometheus.NewMetricWithTimestamp(host.Timestamp, prometheus.MustNewConstMetric(
-			c.powerState, prometheus.GaugeValue, host.PowerStateFloat64(), labelValues...,
-		))
-		ch <- prometheus.NewMetricWithTimestamp(host.Timestamp, prometheus.MustNewConstMetric(
-			c.connectionState, prometheus.GaugeValue, host.ConnectionStateFloat64(), labelValues...,
-		))
-		ch <- prometheus.NewMetricWithTimestamp(host.Timestamp, prometheus.MustNewConstMetric(
-			c.maintenance, prometheus.GaugeValue, b2f(host.Maintenance), labelValues...,
-		))
-		ch <- prometheus.NewMetricWithTimestamp(host.Timestamp, prometheus.MustNewConstMetric(
-			c.uptimeSeconds, prometheus.GaugeValue, host.UptimeSeconds, labelValues...,
-		))
-		ch <- prometheus.NewMetricWithTimestamp(host.Timestamp, prometheus.MustNewConstMetric(
-			c.rebootRequired, prometheus.GaugeValue, b2f(host.RebootRequired), labelValues...,
-		))
-		ch <- prometheus.NewMetricWithTimestamp(host.Timestamp, prometheus.MustNewConstMetric(
-			c.cpuCoresTotal, prometheus.GaugeValue, host.CPUCoresTotal, labelValues...,
-		))
-		ch <- prometheus.NewMetricWithTimestamp(host.Timestamp, prometheus.MustNewConstMetric(
-			c.cpuThreadsTotal, prometheus.GaugeValue, host.CPUThreadsTotal, labelValues...,
-		))
-		ch <- prometheus.NewMetricWithTimestamp(host.Timestamp, prometheus.MustNewConstMetric(
-			c.availCPUMhz, prometheus.GaugeValue, host.AvailCPUMhz, labelValues...,
-		))
-		ch <- prometheus.NewMetricWithTimestamp(host.Timestamp, prometheus.MustNewConstMetric(
-			c.usedCPUMhz, prometheus.GaugeValue, host.UsedCPUMhz, labelValues...,
-		))
-		ch <- prometheus.NewMetricWithTimestamp(host.Timestamp, prometheus.MustNewConstMetric(
-			c.availMemBytes, prometheus.GaugeValue, host.AvailMemBytes, labelValues...,
-		))
-		ch <- prometheus.NewMetricWithTimestamp(host.Timestamp, prometheus.MustNewConstMetric(
-			c.usedMemBytes, prometheus.GaugeValue, host.UsedMemBytes, labelValues...,
-		))
-		ch <- prometheus.NewMetricWithTimestamp(host.Timestamp, prometheus.MustNewConstMetric(
-			c.overallStatus, prometheus.GaugeValue, host.OverallStatusFloat64(), labelValues...,
-		))
-
-		ch <- prometheus.NewMetricWithTimestamp(host.Timestamp, prometheus.MustNewConstMetric(
-			c.vmNumTotal, prometheus.GaugeValue, host.NumberOfVMs, labelValues...,
-		))
-		if c.enableStorageMetrics {
-			for _, hba := range host.HBA {
-				hbaLabelValues := append(slices.Clone(labelValues), hba.Device, hba.Driver, hba.Model)
+			ch <- prometheus.NewMetricWithTimestamp(host.Timestamp, prometheus.MustNewConstMetric(
+				c.vmNumTotal, prometheus.GaugeValue, host.NumberOfVMs, labelValues...,
+			))
+			if c.enableHardwareMetrics {
+				for _, pkg := range host.CPUPackages {
+					pkgIndex := strconv.Itoa(pkg.Index)
+					ch <- prometheus.NewMetricWithTimestamp(host.Timestamp, prometheus.MustNewConstMetric(
+						c.cpuPackageInfo, prometheus.GaugeValue, 1, append(slices.Clone(labelValues), pkgIndex, pkg.Vendor, pkg.Description)...,
+					))
+					ch <- prometheus.NewMetricWithTimestamp(host.Timestamp, prometheus.MustNewConstMetric(
+						c.cpuPackageHz, prometheus.GaugeValue, pkg.Hz, append(slices.Clone(labelValues), pkgIndex)...,
+					))
+				}
 				ch <- prometheus.NewMetricWithTimestamp(host.Timestamp, prometheus.MustNewConstMetric(
-					c.hbaStatus, prometheus.GaugeValue, hba.StatusFloat64(), hbaLabelValues...,
+					c.hardwareMemoryBytes, prometheus.GaugeValue, host.HardwareMemoryBytes, labelValues...,
 				))
 
-				if hba.Type == "iscsi" {
-					for _, target := range hba.IscsiDiscoveryTarget {
-						iscsiLabelTargetValues := append(slices.Clone(hbaLabelValues), fmt.Sprintf("%s:%d", target.Address, target.Port))
+				for _, device := range host.PCIDevices {
+					ch <- prometheus.NewMetricWithTimestamp(host.Timestamp, prometheus.MustNewConstMetric(
+						c.pciDeviceInfo, prometheus.GaugeValue, 1, append(slices.Clone(labelValues), device.ID, device.ClassID, device.VendorName, device.DeviceName)...,
+					))
+					if device.PassthruCapable {
+						deviceLabelValues := append(slices.Clone(labelValues), device.ID, device.DeviceName)
 						ch <- prometheus.NewMetricWithTimestamp(host.Timestamp, prometheus.MustNewConstMetric(
-							c.hbaIscsiSendTargetInfo, prometheus.GaugeValue, 1, iscsiLabelTargetValues...,
+							c.pciPassthruEnabled, prometheus.GaugeValue, b2f(device.PassthruEnabled), deviceLabelValues...,
 						))
-					}
-					for _, target := range hba.IscsiStaticTarget {
-						iscsiLabelTargetValues := append(slices.Clone(hbaLabelValues), fmt.Sprintf("%s:%d", target.Address, target.Port), target.IQN, target.DiscoveryMethod, hba.IscsiInitiatorIQN)
 						ch <- prometheus.NewMetricWithTimestamp(host.Timestamp, prometheus.MustNewConstMetric(
-							c.hbaIscsiStaticTargetInfo, prometheus.GaugeValue, 1, iscsiLabelTargetValues...,
+							c.pciPassthruActive, prometheus.GaugeValue, b2f(device.PassthruActive), deviceLabelValues...,
 						))
 					}
 				}
-			}
 
-			for _, p := range host.MultipathPathInfo {
-				pathLabelValues := append(slices.Clone(labelValues), p.Name, p.Adapter, p.IscsiTargetAddress, p.IscsiTargetIQN, strconv.Itoa(p.LUN), p.CanonicalName)
+				for _, gpu := range host.GPUs {
+					ch <- prometheus.NewMetricWithTimestamp(host.Timestamp, prometheus.MustNewConstMetric(
+						c.gpuInfo, prometheus.GaugeValue, 1, append(slices.Clone(labelValues), gpu.PCIID, gpu.VendorName, gpu.DeviceName, gpu.GraphicsType, gpu.VGPUMode)...,
+					))
+					gpuLabelValues := append(slices.Clone(labelValues), gpu.PCIID, gpu.DeviceName)
+					ch <- prometheus.NewMetricWithTimestamp(host.Timestamp, prometheus.MustNewConstMetric(
+						c.gpuMemoryBytes, prometheus.GaugeValue, gpu.MemoryBytes, gpuLabelValues...,
+					))
+					ch <- prometheus.NewMetricWithTimestamp(host.Timestamp, prometheus.MustNewConstMetric(
+						c.gpuVMs, prometheus.GaugeValue, float64(len(gpu.VMs)), gpuLabelValues...,
+					))
+				}
+
+				for _, nic := range host.PhysicalNICs {
+					ch <- prometheus.NewMetricWithTimestamp(host.Timestamp, prometheus.MustNewConstMetric(
+						c.nicInfo, prometheus.GaugeValue, 1, append(slices.Clone(labelValues), nic.Device, nic.PCI, nic.Mac, nic.Driver, nic.DriverVersion, nic.FirmwareVersion)...,
+					))
+				}
+
+				for _, hba := range host.HBA {
+					var vendorName, deviceName string
+					if device := host.GetPCIDevice(hba.PCI); device != nil {
+						vendorName, deviceName = device.VendorName, device.DeviceName
+					}
+					ch <- prometheus.NewMetricWithTimestamp(host.Timestamp, prometheus.MustNewConstMetric(
+						c.hbaInfo, prometheus.GaugeValue, 1, append(slices.Clone(labelValues), hba.Device, hba.PCI, hba.Driver, hba.Model, vendorName, deviceName)...,
+					))
+				}
+
 				ch <- prometheus.NewMetricWithTimestamp(host.Timestamp, prometheus.MustNewConstMetric(
-					c.multipathPathState, prometheus.GaugeValue, p.StateFloat64(), pathLabelValues...,
+					c.tpmAttestationStatus, prometheus.GaugeValue, host.TPMAttestationStatusFloat64(), append(slices.Clone(labelValues), host.TPMAttestationStatus)...,
 				))
 			}
 
-			for _, lun := range host.Luns {
-				vmfsLabelValues := append(slices.Clone(labelValues), lun.Vendor, lun.Model, lun.CanonicalName, strconv.FormatBool(lun.Local), strconv.FormatBool(lun.SSD))
-				ch <- prometheus.NewMetricWithTimestamp(host.Timestamp, prometheus.MustNewConstMetric(
-					c.scsiLunActivePath, prometheus.GaugeValue, float64(lun.ActiveNumberPaths), vmfsLabelValues...,
-				))
-				ch <- prometheus.NewMetricWithTimestamp(host.Timestamp, prometheus.MustNewConstMetric(
-					c.scsiLunTotalPath, prometheus.GaugeValue, float64(lun.TotalNumberPaths), vmfsLabelValues...,
-				))
+			if c.enableHardwareMetrics || c.enableNetworkMetrics {
+				for _, nic := range host.PhysicalNICs {
+					ch <- prometheus.NewMetricWithTimestamp(host.Timestamp, prometheus.MustNewConstMetric(
+						c.nicLinkSpeedMbps, prometheus.GaugeValue, nic.LinkSpeedMbps, append(slices.Clone(labelValues), nic.Device)...,
+					))
+				}
 			}
 
-			for _, volume := range host.Volumes {
-				volumeLabelValues := append(slices.Clone(labelValues), volume.UUID, volume.DiskName, volume.Name, strconv.FormatBool(volume.Local), strconv.FormatBool(volume.SSD))
-				ch <- prometheus.NewMetricWithTimestamp(host.Timestamp, prometheus.MustNewConstMetric(
-					c.volumeAccessible, prometheus.GaugeValue, b2f(volume.Accessible), volumeLabelValues...,
-				))
-				ch <- prometheus.NewMetricWithTimestamp(host.Timestamp, prometheus.MustNewConstMetric(
-					c.volumeMounted, prometheus.GaugeValue, b2f(volume.Mounted), volumeLabelValues...,
-				))
+			if c.enableNetworkMetrics {
+				for _, nic := range host.PhysicalNICs {
+					ch <- prometheus.NewMetricWithTimestamp(host.Timestamp, prometheus.MustNewConstMetric(
+						c.nicLinkUp, prometheus.GaugeValue, b2f(nic.LinkUp), append(slices.Clone(labelValues), nic.Device)...,
+					))
+				}
+
+				for _, vswitch := range host.VirtualSwitches {
+					vswitchLabelValues := append(slices.Clone(labelValues), vswitch.Name)
+					ch <- prometheus.NewMetricWithTimestamp(host.Timestamp, prometheus.MustNewConstMetric(
+						c.vswitchInfo, prometheus.GaugeValue, 1, append(slices.Clone(vswitchLabelValues), strings.Join(vswitch.Uplinks, ","))...,
+					))
+					ch <- prometheus.NewMetricWithTimestamp(host.Timestamp, prometheus.MustNewConstMetric(
+						c.vswitchMTU, prometheus.GaugeValue, vswitch.MTU, vswitchLabelValues...,
+					))
+					if host.Cluster != "" {
+						ch <- prometheus.NewMetricWithTimestamp(host.Timestamp, prometheus.MustNewConstMetric(
+							c.vswitchMTUMismatch, prometheus.GaugeValue, b2f(mtuMismatches.vswitch(host, vswitch)), vswitchLabelValues...,
+						))
+					}
+					ch <- prometheus.NewMetricWithTimestamp(host.Timestamp, prometheus.MustNewConstMetric(
+						c.vswitchPorts, prometheus.GaugeValue, vswitch.NumPorts, vswitchLabelValues...,
+					))
+					ch <- prometheus.NewMetricWithTimestamp(host.Timestamp, prometheus.MustNewConstMetric(
+						c.vswitchUplinks, prometheus.GaugeValue, float64(len(vswitch.Uplinks)), vswitchLabelValues...,
+					))
+					for policy, value := range securityPolicyValues(vswitch.Security) {
+						ch <- prometheus.NewMetricWithTimestamp(host.Timestamp, prometheus.MustNewConstMetric(
+							c.vswitchSecurityPolicy, prometheus.GaugeValue, value, append(slices.Clone(vswitchLabelValues), policy)...,
+						))
+					}
+				}
+
+				for _, pg := range host.PortGroups {
+					pgLabelValues := append(slices.Clone(labelValues), pg.Name, pg.VSwitch)
+					ch <- prometheus.NewMetricWithTimestamp(host.Timestamp, prometheus.MustNewConstMetric(
+						c.portgroupVlanID, prometheus.GaugeValue, pg.VlanID, pgLabelValues...,
+					))
+					ch <- prometheus.NewMetricWithTimestamp(host.Timestamp, prometheus.MustNewConstMetric(
+						c.portgroupPorts, prometheus.GaugeValue, pg.NumPorts, pgLabelValues...,
+					))
+					for policy, value := range securityPolicyValues(pg.Security) {
+						ch <- prometheus.NewMetricWithTimestamp(host.Timestamp, prometheus.MustNewConstMetric(
+							c.portgroupSecurityPolicy, prometheus.GaugeValue, value, append(slices.Clone(pgLabelValues), policy)...,
+						))
+					}
+				}
+
+				for _, vmknic := range host.VMKernelNICs {
+					services := strings.Join(vmknic.Services, ",")
+					ch <- prometheus.NewMetricWithTimestamp(host.Timestamp, prometheus.MustNewConstMetric(
+						c.vmknicInfo, prometheus.GaugeValue, 1, append(slices.Clone(labelValues),
+							vmknic.Device, vmknic.PortGroup, vmknic.DistributedSwitch, vmknic.Mac, vmknic.IP, vmknic.SubnetMask,
+							strconv.FormatBool(vmknic.DHCP), vmknic.NetStack, services, strconv.FormatFloat(vmknic.MTU, 'f', -1, 64),
+						)...,
+					))
+					vmknicLabelValues := append(slices.Clone(labelValues), vmknic.Device, vmknic.PortGroup, vmknic.NetStack, services)
+					ch <- prometheus.NewMetricWithTimestamp(host.Timestamp, prometheus.MustNewConstMetric(
+						c.vmknicMTU, prometheus.GaugeValue, vmknic.MTU, vmknicLabelValues...,
+					))
+					if host.Cluster != "" {
+						ch <- prometheus.NewMetricWithTimestamp(host.Timestamp, prometheus.MustNewConstMetric(
+							c.vmknicMTUMismatch, prometheus.GaugeValue, b2f(mtuMismatches.vmknic(host, vmknic)), vmknicLabelValues...,
+						))
+					}
+					for _, service := range vmknic.Services {
+						ch <- prometheus.NewMetricWithTimestamp(host.Timestamp, prometheus.MustNewConstMetric(
+							c.vmknicService, prometheus.GaugeValue, 1, append(slices.Clone(labelValues), vmknic.Device, service)...,
+						))
+					}
+				}
+			}
+
+			if c.enableStorageMetrics {
+				for _, hba := range host.HBA {
+					hbaLabelValues := append(slices.Clone(labelValues), hba.Device, hba.Driver, hba.Model)
+					ch <- prometheus.NewMetricWithTimestamp(host.Timestamp, prometheus.MustNewConstMetric(
+						c.hbaStatus, prometheus.GaugeValue, hba.StatusFloat64(), hbaLabelValues...,
+					))
+
+					if hba.Type == "iscsi" || hba.Type == "fc" || hba.Type == "fcoe" {
+						ch <- prometheus.NewMetricWithTimestamp(host.Timestamp, prometheus.MustNewConstMetric(
+							c.hbaSpeedMbps, prometheus.GaugeValue, hba.SpeedMbps, hbaLabelValues...,
+						))
+					}
+
+					if hba.Type == "fc" || hba.Type == "fcoe" {
+						ch <- prometheus.NewMetricWithTimestamp(host.Timestamp, prometheus.MustNewConstMetric(
+							c.hbaFcInfo, prometheus.GaugeValue, 1, append(slices.Clone(hbaLabelValues), hba.Protocol, hba.FcNodeWWN, hba.FcPortWWN, hba.FcPortType)...,
+						))
+					}
+
+					for _, controller := range hba.NvmeControllers {
+						ch <- prometheus.NewMetricWithTimestamp(host.Timestamp, prometheus.MustNewConstMetric(
+							c.hbaNvmeControllerInfo, prometheus.GaugeValue, 1, append(slices.Clone(labelValues), hba.Device, controller.Name, strconv.Itoa(int(controller.ControllerNumber)), controller.Subnqn, controller.TransportType)...,
+						))
+						ch <- prometheus.NewMetricWithTimestamp(host.Timestamp, prometheus.MustNewConstMetric(
+							c.hbaNvmeControllerQueues, prometheus.GaugeValue, controller.NumberOfQueues, append(slices.Clone(labelValues), hba.Device, controller.Name)...,
+						))
+						for _, ns := range controller.Namespaces {
+							ch <- prometheus.NewMetricWithTimestamp(host.Timestamp, prometheus.MustNewConstMetric(
+								c.hbaNvmeNamespaceBytes, prometheus.GaugeValue, ns.CapacityBytes, append(slices.Clone(labelValues), hba.Device, controller.Name, strconv.Itoa(int(ns.ID)), ns.Name)...,
+							))
+						}
+					}
+
+					if hba.Type == "iscsi" {
+						for _, target := range hba.IscsiDiscoveryTarget {
+							iscsiLabelTargetValues := append(slices.Clone(hbaLabelValues), fmt.Sprintf("%s:%d", target.Address, target.Port))
+							ch <- prometheus.NewMetricWithTimestamp(host.Timestamp, prometheus.MustNewConstMetric(
+								c.hbaIscsiSendTargetInfo, prometheus.GaugeValue, 1, iscsiLabelTargetValues...,
+							))
+						}
+						for _, target := range hba.IscsiStaticTarget {
+							iscsiLabelTargetValues := append(slices.Clone(hbaLabelValues), fmt.Sprintf("%s:%d", target.Address, target.Port), target.IQN, target.DiscoveryMethod, hba.IscsiInitiatorIQN)
+							ch <- prometheus.NewMetricWithTimestamp(host.Timestamp, prometheus.MustNewConstMetric(
+								c.hbaIscsiStaticTargetInfo, prometheus.GaugeValue, 1, iscsiLabelTargetValues...,
+							))
+							ch <- prometheus.NewMetricWithTimestamp(host.Timestamp, prometheus.MustNewConstMetric(
+								c.hbaIscsiSessionState, prometheus.GaugeValue, target.SessionStateFloat64(), append(slices.Clone(hbaLabelValues), fmt.Sprintf("%s:%d", target.Address, target.Port), target.IQN)...,
+							))
+						}
+					}
+				}
+
+				for _, p := range host.MultipathPathInfo {
+					pathLabelValues := append(slices.Clone(labelValues), p.Name, p.Adapter, p.Type, p.IscsiTargetAddress, p.IscsiTargetIQN, p.FcTargetNodeWWN, p.FcTargetPortWWN, strconv.Itoa(p.LUN), p.CanonicalName)
+					ch <- prometheus.NewMetricWithTimestamp(host.Timestamp, prometheus.MustNewConstMetric(
+						c.multipathPathState, prometheus.GaugeValue, p.StateFloat64(), pathLabelValues...,
+					))
+				}
+
+				for _, lun := range host.Luns {
+					vmfsLabelValues := append(slices.Clone(labelValues), lun.Vendor, lun.Model, lun.CanonicalName, strconv.FormatBool(lun.Local), strconv.FormatBool(lun.SSD))
+					ch <- prometheus.NewMetricWithTimestamp(host.Timestamp, prometheus.MustNewConstMetric(
+						c.scsiLunActivePath, prometheus.GaugeValue, float64(lun.ActiveNumberPaths), vmfsLabelValues...,
+					))
+					ch <- prometheus.NewMetricWithTimestamp(host.Timestamp, prometheus.MustNewConstMetric(
+						c.scsiLunTotalPath, prometheus.GaugeValue, float64(lun.TotalNumberPaths), vmfsLabelValues...,
+					))
+				}
+
+				for _, volume := range host.Volumes {
+					volumeLabelValues := append(slices.Clone(labelValues), volume.UUID, volume.DiskName, volume.Name, strconv.FormatBool(volume.Local), strconv.FormatBool(volume.SSD))
+					ch <- prometheus.NewMetricWithTimestamp(host.Timestamp, prometheus.MustNewConstMetric(
+						c.volumeAccessible, prometheus.GaugeValue, b2f(volume.Accessible), volumeLabelValues...,
+					))
+					ch <- prometheus.NewMetricWithTimestamp(host.Timestamp, prometheus.MustNewConstMetric(
+						c.volumeMounted, prometheus.GaugeValue, b2f(volume.Mounted), volumeLabelValues...,
+					))
+				}
 			}
 		}
 	}
 }
+
+func securityPolicyValues(policy objects.HostNetworkSecurityPolicy) map[string]float64 {
+	return map[string]float64{
+		"allow_promiscuous": b2f(policy.AllowPromiscuous),
+		"mac_changes":       b2f(policy.MacChanges),
+		"forged_transmits":  b2f(policy.ForgedTransmits),
+	}
+}
+
+// MTU's of the vswitches and vmkernel adapters of all hosts in a cluster.
+// Vmkernel adapters are matched on their enabled services, or on their
+// portgroup when no services are enabled. Standalone hosts are never
+// compared.
+type clusterMTUs map[string]map[float64]bool
+
+func newClusterMTUs(hosts []objects.Host) clusterMTUs {
+	m := clusterMTUs{}
+	for _, host := range hosts {
+		if host.Cluster == "" {
+			continue
+		}
+		for _, vswitch := range host.VirtualSwitches {
+			m.add(vswitchMTUKey(host, vswitch), vswitch.MTU)
+		}
+		for _, vmknic := range host.VMKernelNICs {
+			m.add(vmknicMTUKey(host, vmknic), vmknic.MTU)
+		}
+	}
+	return m
+}
+
+func (m clusterMTUs) add(key string, mtu float64) {
+	if m[key] == nil {
+		m[key] = map[float64]bool{}
+	}
+	m[key][mtu] = true
+}
+
+func (m clusterMTUs) vswitch(host objects.Host, vswitch objects.HostVirtualSwitch) bool {
+	return host.Cluster != "" && len(m[vswitchMTUKey(host, vswitch)]) > 1
+}
+
+func (m clusterMTUs) vmknic(host objects.Host, vmknic objects.HostVMKernelNIC) bool {
+	return host.Cluster != "" && len(m[vmknicMTUKey(host, vmknic)]) > 1
+}
+
+func vswitchMTUKey(host objects.Host, vswitch objects.HostVirtualSwitch) string {
+	return strings.Join([]string{host.Datacenter, host.Cluster, "vswitch", vswitch.Name}, "/")
+}
+
+func vmknicMTUKey(host objects.Host, vmknic objects.HostVMKernelNIC) string {
+	if len(vmknic.Services) > 0 {
+		return strings.Join([]string{host.Datacenter, host.Cluster, "services", strings.Join(vmknic.Services, ",")}, "/")
+	}
+	return strings.Join([]string{host.Datacenter, host.Cluster, "portgroup", vmknic.PortGroup}, "/")
+}
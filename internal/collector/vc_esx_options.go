@@ -0,0 +1,78 @@
+package collector
+
+import (
+	"context"
+	"slices"
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sanderdescamps/govc_exporter/internal/config"
+	"github.com/sanderdescamps/govc_exporter/internal/scraper"
+)
+
+type esxOptionsCollector struct {
+	scraper         *scraper.VCenterScraper
+	objectLabels    objectLabeler
+	folderPathLabel bool
+
+	optionDrift *prometheus.Desc
+}
+
+func NewEsxOptionsCollector(scraper *scraper.VCenterScraper, cConf config.CollectorConfig) *esxOptionsCollector {
+	labels := []string{"id", "name", "datacenter", "cluster"}
+	if cConf.FolderPathLabel {
+		labels = append(labels, "folder_path")
+	}
+	objectLabels := newObjectLabeler(cConf.HostTagLabels, cConf.HostAttributeLabels, cConf)
+	labels = objectLabels.Labels(labels)
+
+	optionLabels := append(slices.Clone(labels), "option", "expected", "actual", "missing")
+
+	return &esxOptionsCollector{
+		scraper:         scraper,
+		objectLabels:    objectLabels,
+		folderPathLabel: cConf.FolderPathLabel,
+		optionDrift: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, esxCollectorSubsystem, "advanced_option_drift"),
+			"advanced option differs from the desired value", optionLabels, nil),
+	}
+}
+
+func (c *esxOptionsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.optionDrift
+}
+
+func (c *esxOptionsCollector) Collect(ch chan<- prometheus.Metric) {
+	if !c.scraper.HostOptions.Enabled() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), COLLECT_TIMEOUT)
+	defer cancel()
+
+	hosts, err := c.scraper.DB.GetAllHost(ctx)
+	if err != nil && Logger != nil {
+		Logger.Error("failed to get hosts", "err", err)
+	}
+	for _, host := range hosts {
+		opts := c.scraper.DB.GetHostOptions(ctx, host.Self)
+		if opts == nil || opts.Timestamp.IsZero() {
+			continue
+		}
+
+		objectTags := c.scraper.DB.GetTags(ctx, host.Self)
+		objectAttributes := c.scraper.DB.GetAttributes(ctx, host.Self)
+		baseLabelValues := []string{host.Self.ID(), host.Name, host.Datacenter, host.Cluster}
+		if c.folderPathLabel {
+			baseLabelValues = append(baseLabelValues, host.FolderPath)
+		}
+		for _, labelValues := range c.objectLabels.LabelValues(baseLabelValues, objectTags, objectAttributes) {
+			for _, option := range opts.Options {
+				optionLabelValues := append(slices.Clone(labelValues), option.Key, option.Expected, option.Actual, strconv.FormatBool(option.Missing))
+				ch <- prometheus.NewMetricWithTimestamp(opts.Timestamp, prometheus.MustNewConstMetric(
+					c.optionDrift, prometheus.GaugeValue, b2f(option.Drift), optionLabelValues...,
+				))
+			}
+		}
+	}
+}
@@ -10,7 +10,8 @@ import (
 )
 
 type esxPerfCollector struct {
-	extraLabels []string
+	objectLabels    objectLabeler
+	folderPathLabel bool
 
 	scraper *scraper.VCenterScraper
 
@@ -19,16 +20,18 @@ type esxPerfCollector struct {
 
 func NewEsxPerfCollector(scraper *scraper.VCenterScraper, cConf config.CollectorConfig) *esxPerfCollector {
 	labels := []string{"id", "name", "datacenter", "cluster"}
-	extraLabels := cConf.HostTagLabels
-	if len(extraLabels) != 0 {
-		labels = append(labels, extraLabels...)
+	if cConf.FolderPathLabel {
+		labels = append(labels, "folder_path")
 	}
+	objectLabels := newObjectLabeler(cConf.HostTagLabels, cConf.HostAttributeLabels, cConf)
+	labels = objectLabels.Labels(labels)
 
 	perfLabels := append(slices.Clone(labels), "kind", "instance", "unit")
 
 	return &esxPerfCollector{
-		scraper:     scraper,
-		extraLabels: extraLabels,
+		scraper:         scraper,
+		objectLabels:    objectLabels,
+		folderPathLabel: cConf.FolderPathLabel,
 		perfMetric: prometheus.NewDesc(
 			prometheus.BuildFQName(namespace, esxCollectorSubsystem, "perf_metric"),
 			"Performance metric", perfLabels, nil),
@@ -52,21 +55,23 @@ func (c *esxPerfCollector) Collect(ch chan<- prometheus.Metric) {
 		Logger.Error("failed to get hosts", "err", err)
 	}
 	for _, host := range hosts {
+		// metrics are popped from the db, so they need to be fetched before
+		// they are exported for every set of tag labels
+		metrics := slices.Collect(c.scraper.MetricsDB.PopAllHostMetricsIter(ctx, host.Self))
 
-		extraLabelValues := []string{}
 		objectTags := c.scraper.DB.GetTags(ctx, host.Self)
-		for _, tagCat := range c.extraLabels {
-			extraLabelValues = append(extraLabelValues, objectTags.GetTag(tagCat))
+		objectAttributes := c.scraper.DB.GetAttributes(ctx, host.Self)
+		baseLabelValues := []string{host.Self.ID(), host.Name, host.Datacenter, host.Cluster}
+		if c.folderPathLabel {
+			baseLabelValues = append(baseLabelValues, host.FolderPath)
 		}
-
-		labelValues := []string{host.Self.ID(), host.Name, host.Datacenter, host.Cluster}
-		labelValues = append(labelValues, extraLabelValues...)
-
-		for metric := range c.scraper.MetricsDB.PopAllHostMetricsIter(ctx, host.Self) {
-			perfMetricLabelValues := append(slices.Clone(labelValues), metric.Name, metric.Instance, metric.Unit)
-			ch <- prometheus.NewMetricWithTimestamp(metric.Timestamp, prometheus.MustNewConstMetric(
-				c.perfMetric, prometheus.GaugeValue, metric.Value, perfMetricLabelValues...,
-			))
+		for _, labelValues := range c.objectLabels.LabelValues(baseLabelValues, objectTags, objectAttributes) {
+			for _, metric := range metrics {
+				perfMetricLabelValues := append(slices.Clone(labelValues), metric.Name, metric.Instance, metric.Unit)
+				ch <- prometheus.NewMetricWithTimestamp(metric.Timestamp, prometheus.MustNewConstMetric(
+					c.perfMetric, prometheus.GaugeValue, metric.Value, perfMetricLabelValues...,
+				))
+			}
 		}
 	}
 }
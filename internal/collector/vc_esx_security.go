@@ -0,0 +1,169 @@
+package collector
+
+import (
+	"context"
+	"slices"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sanderdescamps/govc_exporter/internal/config"
+	"github.com/sanderdescamps/govc_exporter/internal/scraper"
+)
+
+type esxSecurityCollector struct {
+	scraper         *scraper.VCenterScraper
+	objectLabels    objectLabeler
+	folderPathLabel bool
+
+	lockdownMode               *prometheus.Desc
+	serviceRunning             *prometheus.Desc
+	servicePolicyInfo          *prometheus.Desc
+	shellTimeout               *prometheus.Desc
+	shellInteractiveTimeout    *prometheus.Desc
+	ntpServerInfo              *prometheus.Desc
+	clockDrift                 *prometheus.Desc
+	certificateExpiry          *prometheus.Desc
+	firewallIncomingBlocked    *prometheus.Desc
+	firewallOutgoingBlocked    *prometheus.Desc
+	firewallDefaultRestrictive *prometheus.Desc
+}
+
+func NewEsxSecurityCollector(scraper *scraper.VCenterScraper, cConf config.CollectorConfig) *esxSecurityCollector {
+	labels := []string{"id", "name", "datacenter", "cluster"}
+	if cConf.FolderPathLabel {
+		labels = append(labels, "folder_path")
+	}
+	objectLabels := newObjectLabeler(cConf.HostTagLabels, cConf.HostAttributeLabels, cConf)
+	labels = objectLabels.Labels(labels)
+
+	serviceLabels := append(slices.Clone(labels), "service", "service_label")
+	servicePolicyLabels := append(slices.Clone(labels), "service", "service_label", "policy")
+	ntpLabels := append(slices.Clone(labels), "ntp_server")
+	certLabels := append(slices.Clone(labels), "subject", "issuer")
+
+	return &esxSecurityCollector{
+		scraper:         scraper,
+		objectLabels:    objectLabels,
+		folderPathLabel: cConf.FolderPathLabel,
+		lockdownMode: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, esxCollectorSubsystem, "lockdown_mode"),
+			"esx lockdown mode (0=disabled, 1=normal, 2=strict)", labels, nil),
+		serviceRunning: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, esxCollectorSubsystem, "service_running"),
+			"esx service is running", serviceLabels, nil),
+		servicePolicyInfo: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, esxCollectorSubsystem, "service_policy_info"),
+			"esx service startup policy", servicePolicyLabels, nil),
+		shellTimeout: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, esxCollectorSubsystem, "shell_timeout_seconds"),
+			"time the ESXi shell and SSH stay enabled, 0 means no timeout", labels, nil),
+		shellInteractiveTimeout: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, esxCollectorSubsystem, "shell_interactive_timeout_seconds"),
+			"idle time before an interactive shell session is logged out, 0 means no timeout", labels, nil),
+		ntpServerInfo: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, esxCollectorSubsystem, "ntp_server_info"),
+			"configured ntp server", ntpLabels, nil),
+		clockDrift: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, esxCollectorSubsystem, "clock_drift_seconds"),
+			"difference between the host clock and the clock of the exporter", labels, nil),
+		certificateExpiry: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, esxCollectorSubsystem, "certificate_expiry_timestamp_seconds"),
+			"expiry date of the host certificate", certLabels, nil),
+		firewallIncomingBlocked: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, esxCollectorSubsystem, "firewall_default_incoming_blocked"),
+			"incoming traffic is blocked by default", labels, nil),
+		firewallOutgoingBlocked: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, esxCollectorSubsystem, "firewall_default_outgoing_blocked"),
+			"outgoing traffic is blocked by default", labels, nil),
+		firewallDefaultRestrictive: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, esxCollectorSubsystem, "firewall_default_restrictive"),
+			"default firewall policy blocks incoming and outgoing traffic", labels, nil),
+	}
+}
+
+func (c *esxSecurityCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.lockdownMode
+	ch <- c.serviceRunning
+	ch <- c.servicePolicyInfo
+	ch <- c.shellTimeout
+	ch <- c.shellInteractiveTimeout
+	ch <- c.ntpServerInfo
+	ch <- c.clockDrift
+	ch <- c.certificateExpiry
+	ch <- c.firewallIncomingBlocked
+	ch <- c.firewallOutgoingBlocked
+	ch <- c.firewallDefaultRestrictive
+}
+
+func (c *esxSecurityCollector) Collect(ch chan<- prometheus.Metric) {
+	if !c.scraper.HostSecurity.Enabled() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), COLLECT_TIMEOUT)
+	defer cancel()
+
+	hosts, err := c.scraper.DB.GetAllHost(ctx)
+	if err != nil && Logger != nil {
+		Logger.Error("failed to get hosts", "err", err)
+	}
+	for _, host := range hosts {
+		sec := c.scraper.DB.GetHostSecurity(ctx, host.Self)
+		if sec == nil || sec.Timestamp.IsZero() {
+			continue
+		}
+
+		objectTags := c.scraper.DB.GetTags(ctx, host.Self)
+		objectAttributes := c.scraper.DB.GetAttributes(ctx, host.Self)
+		baseLabelValues := []string{host.Self.ID(), host.Name, host.Datacenter, host.Cluster}
+		if c.folderPathLabel {
+			baseLabelValues = append(baseLabelValues, host.FolderPath)
+		}
+		for _, labelValues := range c.objectLabels.LabelValues(baseLabelValues, objectTags, objectAttributes) {
+			ch <- prometheus.NewMetricWithTimestamp(sec.Timestamp, prometheus.MustNewConstMetric(
+				c.lockdownMode, prometheus.GaugeValue, sec.LockdownModeFloat64(), labelValues...,
+			))
+			for _, service := range sec.Services {
+				serviceLabelValues := append(slices.Clone(labelValues), service.Key, service.Label)
+				ch <- prometheus.NewMetricWithTimestamp(sec.Timestamp, prometheus.MustNewConstMetric(
+					c.serviceRunning, prometheus.GaugeValue, b2f(service.Running), serviceLabelValues...,
+				))
+				ch <- prometheus.NewMetricWithTimestamp(sec.Timestamp, prometheus.MustNewConstMetric(
+					c.servicePolicyInfo, prometheus.GaugeValue, 1, append(serviceLabelValues, service.Policy)...,
+				))
+			}
+			if sec.ShellTimeoutsQueried {
+				ch <- prometheus.NewMetricWithTimestamp(sec.Timestamp, prometheus.MustNewConstMetric(
+					c.shellTimeout, prometheus.GaugeValue, sec.ShellTimeout, labelValues...,
+				))
+				ch <- prometheus.NewMetricWithTimestamp(sec.Timestamp, prometheus.MustNewConstMetric(
+					c.shellInteractiveTimeout, prometheus.GaugeValue, sec.ShellInteractiveTimeout, labelValues...,
+				))
+			}
+			for _, server := range sec.NTPServers {
+				ch <- prometheus.NewMetricWithTimestamp(sec.Timestamp, prometheus.MustNewConstMetric(
+					c.ntpServerInfo, prometheus.GaugeValue, 1, append(slices.Clone(labelValues), server)...,
+				))
+			}
+			if sec.ClockDriftQueried {
+				ch <- prometheus.NewMetricWithTimestamp(sec.Timestamp, prometheus.MustNewConstMetric(
+					c.clockDrift, prometheus.GaugeValue, sec.ClockDrift, labelValues...,
+				))
+			}
+			if !sec.CertificateNotAfter.IsZero() {
+				ch <- prometheus.NewMetricWithTimestamp(sec.Timestamp, prometheus.MustNewConstMetric(
+					c.certificateExpiry, prometheus.GaugeValue, float64(sec.CertificateNotAfter.Unix()),
+					append(slices.Clone(labelValues), sec.CertificateSubject, sec.CertificateIssuer)...,
+				))
+			}
+			ch <- prometheus.NewMetricWithTimestamp(sec.Timestamp, prometheus.MustNewConstMetric(
+				c.firewallIncomingBlocked, prometheus.GaugeValue, b2f(sec.FirewallIncomingBlocked), labelValues...,
+			))
+			ch <- prometheus.NewMetricWithTimestamp(sec.Timestamp, prometheus.MustNewConstMetric(
+				c.firewallOutgoingBlocked, prometheus.GaugeValue, b2f(sec.FirewallOutgoingBlocked), labelValues...,
+			))
+			ch <- prometheus.NewMetricWithTimestamp(sec.Timestamp, prometheus.MustNewConstMetric(
+				c.firewallDefaultRestrictive, prometheus.GaugeValue, b2f(sec.FirewallRestrictive()), labelValues...,
+			))
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package collector
+
+import (
+	"testing"
+
+	"github.com/sanderdescamps/govc_exporter/internal/database/objects"
+)
+
+func TestClusterMTUs(t *testing.T) {
+	vmotion := func(mtu float64) objects.HostVMKernelNIC {
+		return objects.HostVMKernelNIC{Device: "vmk1", PortGroup: "vMotion", MTU: mtu, Services: []string{"vmotion"}}
+	}
+	hosts := []objects.Host{
+		{Name: "esx1", Datacenter: "dc", Cluster: "c1",
+			VirtualSwitches: []objects.HostVirtualSwitch{{Name: "vSwitch0", MTU: 9000}},
+			VMKernelNICs:    []objects.HostVMKernelNIC{vmotion(9000)}},
+		{Name: "esx2", Datacenter: "dc", Cluster: "c1",
+			VirtualSwitches: []objects.HostVirtualSwitch{{Name: "vSwitch0", MTU: 9000}},
+			VMKernelNICs:    []objects.HostVMKernelNIC{vmotion(1500)}},
+		{Name: "esx3", Datacenter: "dc", Cluster: "c2",
+			VirtualSwitches: []objects.HostVirtualSwitch{{Name: "vSwitch0", MTU: 1500}},
+			VMKernelNICs:    []objects.HostVMKernelNIC{vmotion(1500)}},
+		{Name: "esx4", Datacenter: "dc",
+			VirtualSwitches: []objects.HostVirtualSwitch{{Name: "vSwitch0", MTU: 9000}},
+			VMKernelNICs:    []objects.HostVMKernelNIC{vmotion(9000)}},
+		{Name: "esx5", Datacenter: "dc",
+			VirtualSwitches: []objects.HostVirtualSwitch{{Name: "vSwitch0", MTU: 1500}},
+			VMKernelNICs:    []objects.HostVMKernelNIC{vmotion(1500)}},
+	}
+
+	m := newClusterMTUs(hosts)
+	for _, host := range hosts {
+		if m.vswitch(host, host.VirtualSwitches[0]) {
+			t.Errorf("unexpected vswitch mtu mismatch on %s", host.Name)
+		}
+		if mismatch := m.vmknic(host, host.VMKernelNICs[0]); mismatch != (host.Cluster == "c1") {
+			t.Errorf("unexpected vmknic mtu mismatch %v on %s", mismatch, host.Name)
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package collector
+
+import (
+	"context"
+	"slices"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sanderdescamps/govc_exporter/internal/config"
+	"github.com/sanderdescamps/govc_exporter/internal/scraper"
+)
+
+const (
+	licenseCollectorSubsystem = "license"
+)
+
+type licenseCollector struct {
+	scraper         *scraper.VCenterScraper
+	objectLabels    objectLabeler
+	folderPathLabel bool
+
+	info           *prometheus.Desc
+	total          *prometheus.Desc
+	used           *prometheus.Desc
+	expiration     *prometheus.Desc
+	hostInfo       *prometheus.Desc
+	hostEvaluation *prometheus.Desc
+}
+
+func NewLicenseCollector(scraper *scraper.VCenterScraper, cConf config.CollectorConfig) *licenseCollector {
+	licenseLabels := []string{"license_key", "name", "edition", "cost_unit"}
+
+	hostLabels := []string{"id", "name", "datacenter", "cluster"}
+	if cConf.FolderPathLabel {
+		hostLabels = append(hostLabels, "folder_path")
+	}
+	objectLabels := newObjectLabeler(cConf.HostTagLabels, cConf.HostAttributeLabels, cConf)
+	hostLabels = objectLabels.Labels(hostLabels)
+
+	hostInfoLabels := append(slices.Clone(hostLabels), "license_key", "license_name", "edition")
+
+	return &licenseCollector{
+		scraper:         scraper,
+		objectLabels:    objectLabels,
+		folderPathLabel: cConf.FolderPathLabel,
+		info: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, licenseCollectorSubsystem, "info"),
+			"license info", licenseLabels, nil),
+		total: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, licenseCollectorSubsystem, "total"),
+			"total capacity of the license in cost units", licenseLabels, nil),
+		used: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, licenseCollectorSubsystem, "used"),
+			"used capacity of the license in cost units", licenseLabels, nil),
+		expiration: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, licenseCollectorSubsystem, "expiration_timestamp_seconds"),
+			"expiration date of the license, only for licenses that expire", licenseLabels, nil),
+		hostInfo: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, licenseCollectorSubsystem, "host_info"),
+			"license assigned to the host", hostInfoLabels, nil),
+		hostEvaluation: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, licenseCollectorSubsystem, "host_evaluation_mode"),
+			"host runs in evaluation mode", hostLabels, nil),
+	}
+}
+
+func (c *licenseCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.info
+	ch <- c.total
+	ch <- c.used
+	ch <- c.expiration
+	ch <- c.hostInfo
+	ch <- c.hostEvaluation
+}
+
+func (c *licenseCollector) Collect(ch chan<- prometheus.Metric) {
+	if !c.scraper.License.Enabled() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), COLLECT_TIMEOUT)
+	defer cancel()
+
+	licenses, err := c.scraper.DB.GetAllLicense(ctx)
+	if err != nil && Logger != nil {
+		Logger.Error("failed to get licenses", "err", err)
+	}
+	for _, l := range licenses {
+		labelValues := []string{l.LicenseKey, l.Name, l.EditionKey, l.CostUnit}
+		ch <- prometheus.NewMetricWithTimestamp(l.Timestamp, prometheus.MustNewConstMetric(
+			c.info, prometheus.GaugeValue, 1, labelValues...,
+		))
+		ch <- prometheus.NewMetricWithTimestamp(l.Timestamp, prometheus.MustNewConstMetric(
+			c.total, prometheus.GaugeValue, l.Total, labelValues...,
+		))
+		ch <- prometheus.NewMetricWithTimestamp(l.Timestamp, prometheus.MustNewConstMetric(
+			c.used, prometheus.GaugeValue, l.Used, labelValues...,
+		))
+		if !l.ExpirationDate.IsZero() {
+			ch <- prometheus.NewMetricWithTimestamp(l.Timestamp, prometheus.MustNewConstMetric(
+				c.expiration, prometheus.GaugeValue, float64(l.ExpirationDate.Unix()), labelValues...,
+			))
+		}
+	}
+
+	hosts, err := c.scraper.DB.GetAllHost(ctx)
+	if err != nil && Logger != nil {
+		Logger.Error("failed to get hosts", "err", err)
+	}
+	for _, host := range hosts {
+		hostLicense := c.scraper.DB.GetHostLicense(ctx, host.Self)
+		if hostLicense == nil || hostLicense.Timestamp.IsZero() {
+			continue
+		}
+
+		objectTags := c.scraper.DB.GetTags(ctx, host.Self)
+		objectAttributes := c.scraper.DB.GetAttributes(ctx, host.Self)
+		baseLabelValues := []string{host.Self.ID(), host.Name, host.Datacenter, host.Cluster}
+		if c.folderPathLabel {
+			baseLabelValues = append(baseLabelValues, host.FolderPath)
+		}
+		for _, labelValues := range c.objectLabels.LabelValues(baseLabelValues, objectTags, objectAttributes) {
+			infoLabelValues := append(slices.Clone(labelValues), hostLicense.LicenseKey, hostLicense.Name, hostLicense.EditionKey)
+			ch <- prometheus.NewMetricWithTimestamp(hostLicense.Timestamp, prometheus.MustNewConstMetric(
+				c.hostInfo, prometheus.GaugeValue, 1, infoLabelValues...,
+			))
+			ch <- prometheus.NewMetricWithTimestamp(hostLicense.Timestamp, prometheus.MustNewConstMetric(
+				c.hostEvaluation, prometheus.GaugeValue, b2f(hostLicense.Evaluation), labelValues...,
+			))
+		}
+	}
+}
@@ -13,8 +13,8 @@ const (
 )
 
 type resourcePoolCollector struct {
-	scraper     *scraper.VCenterScraper
-	extraLabels []string
+	scraper      *scraper.VCenterScraper
+	objectLabels objectLabeler
 
 	overallCPUUsage              *prometheus.Desc
 	overallCPUDemand             *prometheus.Desc
@@ -38,13 +38,11 @@ type resourcePoolCollector struct {
 func NewResourcePoolCollector(scraper *scraper.VCenterScraper, cConf config.CollectorConfig) *resourcePoolCollector {
 	labels := []string{"id", "name", "datacenter"}
 
-	extraLabels := cConf.ResourcePoolTagLabels
-	if len(extraLabels) != 0 {
-		labels = append(labels, extraLabels...)
-	}
+	objectLabels := newObjectLabeler(cConf.ResourcePoolTagLabels, cConf.ResourcePoolAttributeLabels, cConf)
+	labels = objectLabels.Labels(labels)
 	return &resourcePoolCollector{
-		scraper:     scraper,
-		extraLabels: extraLabels,
+		scraper:      scraper,
+		objectLabels: objectLabels,
 		overallCPUUsage: prometheus.NewDesc(
 			prometheus.BuildFQName(namespace, resourcePoolCollectorSubsystem, "used_cpu_mhz"),
 			"resource pool overall CPU usage MHz", labels, nil),
@@ -132,68 +130,63 @@ func (c *resourcePoolCollector) Collect(ch chan<- prometheus.Metric) {
 		Logger.Error("failed to get rpools", "err", err)
 	}
 	for _, rpool := range rpools {
-		extraLabelValues := []string{}
 		objectTags := c.scraper.DB.GetTags(ctx, rpool.Self)
-		for _, tagCat := range c.extraLabels {
-			extraLabelValues = append(extraLabelValues, objectTags.GetTag(tagCat))
+		objectAttributes := c.scraper.DB.GetAttributes(ctx, rpool.Self)
+		baseLabelValues := []string{rpool.Self.ID(), rpool.Name, rpool.Datacenter}
+		for _, labelValues := range c.objectLabels.LabelValues(baseLabelValues, objectTags, objectAttributes) {
+			ch <- prometheus.NewMetricWithTimestamp(rpool.Timestamp, prometheus.MustNewConstMetric(
+				c.overallCPUUsage, prometheus.GaugeValue, rpool.OverallCPUUsage, labelValues...,
+			))
+			ch <- prometheus.NewMetricWithTimestamp(rpool.Timestamp, prometheus.MustNewConstMetric(
+				c.overallCPUDemand, prometheus.GaugeValue, rpool.OverallCPUDemand, labelValues...,
+			))
+			ch <- prometheus.NewMetricWithTimestamp(rpool.Timestamp, prometheus.MustNewConstMetric(
+				c.guestMemoryUsage, prometheus.GaugeValue, rpool.GuestMemoryUsage, labelValues...,
+			))
+			ch <- prometheus.NewMetricWithTimestamp(rpool.Timestamp, prometheus.MustNewConstMetric(
+				c.hostMemoryUsage, prometheus.GaugeValue, rpool.HostMemoryUsage, labelValues...,
+			))
+			ch <- prometheus.NewMetricWithTimestamp(rpool.Timestamp, prometheus.MustNewConstMetric(
+				c.distributedCPUEntitlement, prometheus.GaugeValue, rpool.DistributedCPUEntitlement, labelValues...,
+			))
+			ch <- prometheus.NewMetricWithTimestamp(rpool.Timestamp, prometheus.MustNewConstMetric(
+				c.distributedMemoryEntitlement, prometheus.GaugeValue, rpool.DistributedMemoryEntitlement, labelValues...,
+			))
+			ch <- prometheus.NewMetricWithTimestamp(rpool.Timestamp, prometheus.MustNewConstMetric(
+				c.staticCPUEntitlement, prometheus.GaugeValue, rpool.StaticCPUEntitlement, labelValues...,
+			))
+			ch <- prometheus.NewMetricWithTimestamp(rpool.Timestamp, prometheus.MustNewConstMetric(
+				c.privateMemory, prometheus.GaugeValue, rpool.PrivateMemory, labelValues...,
+			))
+			ch <- prometheus.NewMetricWithTimestamp(rpool.Timestamp, prometheus.MustNewConstMetric(
+				c.sharedMemory, prometheus.GaugeValue, rpool.SharedMemory, labelValues...,
+			))
+			ch <- prometheus.NewMetricWithTimestamp(rpool.Timestamp, prometheus.MustNewConstMetric(
+				c.swappedMemory, prometheus.GaugeValue, rpool.SwappedMemory, labelValues...,
+			))
+			ch <- prometheus.NewMetricWithTimestamp(rpool.Timestamp, prometheus.MustNewConstMetric(
+				c.balloonedMemory, prometheus.GaugeValue, rpool.BalloonedMemory, labelValues...,
+			))
+			ch <- prometheus.NewMetricWithTimestamp(rpool.Timestamp, prometheus.MustNewConstMetric(
+				c.overheadMemory, prometheus.GaugeValue, rpool.OverheadMemory, labelValues...,
+			))
+			ch <- prometheus.NewMetricWithTimestamp(rpool.Timestamp, prometheus.MustNewConstMetric(
+				c.consumedOverheadMemory, prometheus.GaugeValue, rpool.ConsumedOverheadMemory, labelValues...,
+			))
+			ch <- prometheus.NewMetricWithTimestamp(rpool.Timestamp, prometheus.MustNewConstMetric(
+				c.compressedMemory, prometheus.GaugeValue, rpool.CompressedMemory, labelValues...,
+			))
+			ch <- prometheus.NewMetricWithTimestamp(rpool.Timestamp, prometheus.MustNewConstMetric(
+				c.memoryAllocationLimit, prometheus.GaugeValue, rpool.MemoryAllocationLimit, labelValues...,
+			))
+			ch <- prometheus.NewMetricWithTimestamp(rpool.Timestamp, prometheus.MustNewConstMetric(
+				c.cpuAllocationLimit, prometheus.GaugeValue, rpool.CPUAllocationLimit, labelValues...,
+			))
+
+			ch <- prometheus.NewMetricWithTimestamp(rpool.Timestamp, prometheus.MustNewConstMetric(
+				c.overallStatus, prometheus.GaugeValue, rpool.OverallStatusFloat64(), labelValues...,
+			))
 		}
-
-		labelValues := []string{rpool.Self.ID(), rpool.Name, rpool.Datacenter}
-		labelValues = append(labelValues, extraLabelValues...)
-
-		ch <- prometheus.NewMetricWithTimestamp(rpool.Timestamp, prometheus.MustNewConstMetric(
-			c.overallCPUUsage, prometheus.GaugeValue, rpool.OverallCPUUsage, labelValues...,
-		))
-		ch <- prometheus.NewMetricWithTimestamp(rpool.Timestamp, prometheus.MustNewConstMetric(
-			c.overallCPUDemand, prometheus.GaugeValue, rpool.OverallCPUDemand, labelValues...,
-		))
-		ch <- prometheus.NewMetricWithTimestamp(rpool.Timestamp, prometheus.MustNewConstMetric(
-			c.guestMemoryUsage, prometheus.GaugeValue, rpool.GuestMemoryUsage, labelValues...,
-		))
-		ch <- prometheus.NewMetricWithTimestamp(rpool.Timestamp, prometheus.MustNewConstMetric(
-			c.hostMemoryUsage, prometheus.GaugeValue, rpool.HostMemoryUsage, labelValues...,
-		))
-		ch <- prometheus.NewMetricWithTimestamp(rpool.Timestamp, prometheus.MustNewConstMetric(
-			c.distributedCPUEntitlement, prometheus.GaugeValue, rpool.DistributedCPUEntitlement, labelValues...,
-		))
-		ch <- prometheus.NewMetricWithTimestamp(rpool.Timestamp, prometheus.MustNewConstMetric(
-			c.distributedMemoryEntitlement, prometheus.GaugeValue, rpool.DistributedMemoryEntitlement, labelValues...,
-		))
-		ch <- prometheus.NewMetricWithTimestamp(rpool.Timestamp, prometheus.MustNewConstMetric(
-			c.staticCPUEntitlement, prometheus.GaugeValue, rpool.StaticCPUEntitlement, labelValues...,
-		))
-		ch <- prometheus.NewMetricWithTimestamp(rpool.Timestamp, prometheus.MustNewConstMetric(
-			c.privateMemory, prometheus.GaugeValue, rpool.PrivateMemory, labelValues...,
-		))
-		ch <- prometheus.NewMetricWithTimestamp(rpool.Timestamp, prometheus.MustNewConstMetric(
-			c.sharedMemory, prometheus.GaugeValue, rpool.SharedMemory, labelValues...,
-		))
-		ch <- prometheus.NewMetricWithTimestamp(rpool.Timestamp, prometheus.MustNewConstMetric(
-			c.swappedMemory, prometheus.GaugeValue, rpool.SwappedMemory, labelValues...,
-		))
-		ch <- prometheus.NewMetricWithTimestamp(rpool.Timestamp, prometheus.MustNewConstMetric(
-			c.balloonedMemory, prometheus.GaugeValue, rpool.BalloonedMemory, labelValues...,
-		))
-		ch <- prometheus.NewMetricWithTimestamp(rpool.Timestamp, prometheus.MustNewConstMetric(
-			c.overheadMemory, prometheus.GaugeValue, rpool.OverheadMemory, labelValues...,
-		))
-		ch <- prometheus.NewMetricWithTimestamp(rpool.Timestamp, prometheus.MustNewConstMetric(
-			c.consumedOverheadMemory, prometheus.GaugeValue, rpool.ConsumedOverheadMemory, labelValues...,
-		))
-		ch <- prometheus.NewMetricWithTimestamp(rpool.Timestamp, prometheus.MustNewConstMetric(
-			c.compressedMemory, prometheus.GaugeValue, rpool.CompressedMemory, labelValues...,
-		))
-		ch <- prometheus.NewMetricWithTimestamp(rpool.Timestamp, prometheus.MustNewConstMetric(
-			c.memoryAllocationLimit, prometheus.GaugeValue, rpool.MemoryAllocationLimit, labelValues...,
-		))
-		ch <- prometheus.NewMetricWithTimestamp(rpool.Timestamp, prometheus.MustNewConstMetric(
-			c.cpuAllocationLimit, prometheus.GaugeValue, rpool.CPUAllocationLimit, labelValues...,
-		))
-
-		ch <- prometheus.NewMetricWithTimestamp(rpool.Timestamp, prometheus.MustNewConstMetric(
-			c.overallStatus, prometheus.GaugeValue, rpool.OverallStatusFloat64(), labelValues...,
-		))
-
 	}
 
 }
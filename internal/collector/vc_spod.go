@@ -2,9 +2,11 @@ package collector
 
 import (
 	"context"
+	"slices"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/sanderdescamps/govc_exporter/internal/config"
+	"github.com/sanderdescamps/govc_exporter/internal/database/objects"
 	"github.com/sanderdescamps/govc_exporter/internal/scraper"
 )
 
@@ -13,36 +15,104 @@ const (
 )
 
 type storagePodCollector struct {
-	scraper     *scraper.VCenterScraper
-	extraLabels []string
+	scraper      *scraper.VCenterScraper
+	objectLabels objectLabeler
 
 	capacity  *prometheus.Desc
 	freeSpace *prometheus.Desc
+
+	sdrsEnabled                   *prometheus.Desc
+	sdrsAutomationLevel           *prometheus.Desc
+	sdrsIoLoadBalanceEnabled      *prometheus.Desc
+	sdrsLoadBalanceInterval       *prometheus.Desc
+	sdrsSpaceUtilizationThreshold *prometheus.Desc
+	sdrsFreeSpaceThreshold        *prometheus.Desc
+	sdrsIoLatencyThreshold        *prometheus.Desc
+	sdrsIoLoadImbalanceThreshold  *prometheus.Desc
+	sdrsPendingRecommendations    *prometheus.Desc
+	sdrsFaults                    *prometheus.Desc
+	datastores                    *prometheus.Desc
+	datastoresInMaintenance       *prometheus.Desc
+	datastoreMaintenance          *prometheus.Desc
 }
 
 func NewStoragePodCollector(scraper *scraper.VCenterScraper, cConf config.CollectorConfig) *storagePodCollector {
 	labels := []string{"id", "name", "datacenter"}
 
-	extraLabels := cConf.StoragePodTagLabels
-	if len(extraLabels) != 0 {
-		labels = append(labels, extraLabels...)
-	}
+	objectLabels := newObjectLabeler(cConf.StoragePodTagLabels, cConf.StoragePodAttributeLabels, cConf)
+	labels = objectLabels.Labels(labels)
+
+	automationLabels := append(slices.Clone(labels), "automation_level")
+	spaceThresholdLabels := append(slices.Clone(labels), "threshold_mode")
+	datastoreLabels := append(slices.Clone(labels), "datastore", "datastore_id")
 
 	return &storagePodCollector{
-		scraper:     scraper,
-		extraLabels: extraLabels,
+		scraper:      scraper,
+		objectLabels: objectLabels,
 		capacity: prometheus.NewDesc(
 			prometheus.BuildFQName(namespace, storagePodCollectorSubsystem, "capacity_bytes"),
 			"storagePod capacity in bytes", labels, nil),
 		freeSpace: prometheus.NewDesc(
 			prometheus.BuildFQName(namespace, storagePodCollectorSubsystem, "free_space_bytes"),
 			"storagePod freespace in bytes", labels, nil),
+		sdrsEnabled: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, storagePodCollectorSubsystem, "sdrs_enabled"),
+			"Storage DRS is enabled", labels, nil),
+		sdrsAutomationLevel: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, storagePodCollectorSubsystem, "sdrs_automation_level"),
+			"Storage DRS automation level (0=manual, 1=automated)", automationLabels, nil),
+		sdrsIoLoadBalanceEnabled: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, storagePodCollectorSubsystem, "sdrs_io_load_balance_enabled"),
+			"Storage DRS I/O load balancing is enabled", labels, nil),
+		sdrsLoadBalanceInterval: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, storagePodCollectorSubsystem, "sdrs_load_balance_interval_seconds"),
+			"Interval Storage DRS runs to load balance", labels, nil),
+		sdrsSpaceUtilizationThreshold: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, storagePodCollectorSubsystem, "sdrs_space_utilization_threshold_percent"),
+			"Storage DRS space utilization threshold in percent", spaceThresholdLabels, nil),
+		sdrsFreeSpaceThreshold: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, storagePodCollectorSubsystem, "sdrs_free_space_threshold_bytes"),
+			"Storage DRS minimum free space threshold in bytes", spaceThresholdLabels, nil),
+		sdrsIoLatencyThreshold: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, storagePodCollectorSubsystem, "sdrs_io_latency_threshold_seconds"),
+			"Storage DRS I/O latency threshold", labels, nil),
+		sdrsIoLoadImbalanceThreshold: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, storagePodCollectorSubsystem, "sdrs_io_load_imbalance_threshold"),
+			"Storage DRS I/O load imbalance threshold", labels, nil),
+		sdrsPendingRecommendations: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, storagePodCollectorSubsystem, "sdrs_pending_recommendations"),
+			"Number of pending Storage DRS recommendations", labels, nil),
+		sdrsFaults: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, storagePodCollectorSubsystem, "sdrs_faults"),
+			"Number of faults of the last Storage DRS run", labels, nil),
+		datastores: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, storagePodCollectorSubsystem, "datastores"),
+			"Number of datastores in the storagePod", labels, nil),
+		datastoresInMaintenance: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, storagePodCollectorSubsystem, "datastores_in_maintenance"),
+			"Number of datastores in the storagePod that are entering or in maintenance mode", labels, nil),
+		datastoreMaintenance: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, storagePodCollectorSubsystem, "datastore_maintenance"),
+			"Maintenance status of a member datastore (0=normal, 1=entering maintenance, 2=in maintenance)", datastoreLabels, nil),
 	}
 }
 
 func (c *storagePodCollector) Describe(ch chan<- *prometheus.Desc) {
 	ch <- c.capacity
 	ch <- c.freeSpace
+	ch <- c.sdrsEnabled
+	ch <- c.sdrsAutomationLevel
+	ch <- c.sdrsIoLoadBalanceEnabled
+	ch <- c.sdrsLoadBalanceInterval
+	ch <- c.sdrsSpaceUtilizationThreshold
+	ch <- c.sdrsFreeSpaceThreshold
+	ch <- c.sdrsIoLatencyThreshold
+	ch <- c.sdrsIoLoadImbalanceThreshold
+	ch <- c.sdrsPendingRecommendations
+	ch <- c.sdrsFaults
+	ch <- c.datastores
+	ch <- c.datastoresInMaintenance
+	ch <- c.datastoreMaintenance
 }
 
 func (c *storagePodCollector) Collect(ch chan<- prometheus.Metric) {
@@ -58,19 +128,77 @@ func (c *storagePodCollector) Collect(ch chan<- prometheus.Metric) {
 		Logger.Error("failed to get spods", "err", err)
 	}
 	for _, spod := range spods {
-		extraLabelValues := []string{}
-		objectTags := c.scraper.DB.GetTags(ctx, spod.Self)
-		for _, tagCat := range c.extraLabels {
-			extraLabelValues = append(extraLabelValues, objectTags.GetTag(tagCat))
+		members := []objects.Datastore{}
+		for _, ref := range spod.Datastores {
+			if ds := c.scraper.DB.GetDatastore(ctx, ref); ds != nil && !ds.Timestamp.IsZero() {
+				members = append(members, *ds)
+			}
 		}
 
-		labelValues := []string{spod.Self.ID(), spod.Name, spod.Datacenter}
-		labelValues = append(labelValues, extraLabelValues...)
-		ch <- prometheus.NewMetricWithTimestamp(spod.Timestamp, prometheus.MustNewConstMetric(
-			c.capacity, prometheus.GaugeValue, float64(spod.Capacity), labelValues...,
-		))
-		ch <- prometheus.NewMetricWithTimestamp(spod.Timestamp, prometheus.MustNewConstMetric(
-			c.freeSpace, prometheus.GaugeValue, float64(spod.FreeSpace), labelValues...,
-		))
+		objectTags := c.scraper.DB.GetTags(ctx, spod.Self)
+		objectAttributes := c.scraper.DB.GetAttributes(ctx, spod.Self)
+		baseLabelValues := []string{spod.Self.ID(), spod.Name, spod.Datacenter}
+		for _, labelValues := range c.objectLabels.LabelValues(baseLabelValues, objectTags, objectAttributes) {
+			ch <- prometheus.NewMetricWithTimestamp(spod.Timestamp, prometheus.MustNewConstMetric(
+				c.capacity, prometheus.GaugeValue, float64(spod.Capacity), labelValues...,
+			))
+			ch <- prometheus.NewMetricWithTimestamp(spod.Timestamp, prometheus.MustNewConstMetric(
+				c.freeSpace, prometheus.GaugeValue, float64(spod.FreeSpace), labelValues...,
+			))
+
+			ch <- prometheus.NewMetricWithTimestamp(spod.Timestamp, prometheus.MustNewConstMetric(
+				c.sdrsEnabled, prometheus.GaugeValue, b2f(spod.SDRSEnabled), labelValues...,
+			))
+			if spod.SDRSEnabled {
+				ch <- prometheus.NewMetricWithTimestamp(spod.Timestamp, prometheus.MustNewConstMetric(
+					c.sdrsAutomationLevel, prometheus.GaugeValue, spod.SDRSBehaviorFloat64(), append(slices.Clone(labelValues), spod.SDRSBehavior)...,
+				))
+				ch <- prometheus.NewMetricWithTimestamp(spod.Timestamp, prometheus.MustNewConstMetric(
+					c.sdrsIoLoadBalanceEnabled, prometheus.GaugeValue, b2f(spod.SDRSIoLoadBalanceEnabled), labelValues...,
+				))
+				ch <- prometheus.NewMetricWithTimestamp(spod.Timestamp, prometheus.MustNewConstMetric(
+					c.sdrsLoadBalanceInterval, prometheus.GaugeValue, spod.SDRSLoadBalanceInterval, labelValues...,
+				))
+				if spod.SDRSSpaceThresholdMode != "" {
+					spaceLabelValues := append(slices.Clone(labelValues), spod.SDRSSpaceThresholdMode)
+					ch <- prometheus.NewMetricWithTimestamp(spod.Timestamp, prometheus.MustNewConstMetric(
+						c.sdrsSpaceUtilizationThreshold, prometheus.GaugeValue, spod.SDRSSpaceUtilizationThreshold, spaceLabelValues...,
+					))
+					ch <- prometheus.NewMetricWithTimestamp(spod.Timestamp, prometheus.MustNewConstMetric(
+						c.sdrsFreeSpaceThreshold, prometheus.GaugeValue, spod.SDRSFreeSpaceThreshold, spaceLabelValues...,
+					))
+				}
+				if spod.SDRSIoLoadBalanceEnabled {
+					ch <- prometheus.NewMetricWithTimestamp(spod.Timestamp, prometheus.MustNewConstMetric(
+						c.sdrsIoLatencyThreshold, prometheus.GaugeValue, spod.SDRSIoLatencyThreshold, labelValues...,
+					))
+					ch <- prometheus.NewMetricWithTimestamp(spod.Timestamp, prometheus.MustNewConstMetric(
+						c.sdrsIoLoadImbalanceThreshold, prometheus.GaugeValue, spod.SDRSIoLoadImbalanceThreshold, labelValues...,
+					))
+				}
+				ch <- prometheus.NewMetricWithTimestamp(spod.Timestamp, prometheus.MustNewConstMetric(
+					c.sdrsPendingRecommendations, prometheus.GaugeValue, spod.SDRSPendingRecommendations, labelValues...,
+				))
+				ch <- prometheus.NewMetricWithTimestamp(spod.Timestamp, prometheus.MustNewConstMetric(
+					c.sdrsFaults, prometheus.GaugeValue, spod.SDRSFaults, labelValues...,
+				))
+			}
+
+			ch <- prometheus.NewMetricWithTimestamp(spod.Timestamp, prometheus.MustNewConstMetric(
+				c.datastores, prometheus.GaugeValue, float64(len(spod.Datastores)), labelValues...,
+			))
+			inMaintenance := 0.0
+			for _, ds := range members {
+				if ds.MaintenanceStatusFloat64() > 0 {
+					inMaintenance++
+				}
+				ch <- prometheus.NewMetricWithTimestamp(ds.Timestamp, prometheus.MustNewConstMetric(
+					c.datastoreMaintenance, prometheus.GaugeValue, ds.MaintenanceStatusFloat64(), append(slices.Clone(labelValues), ds.Name, ds.Self.ID())...,
+				))
+			}
+			ch <- prometheus.NewMetricWithTimestamp(spod.Timestamp, prometheus.MustNewConstMetric(
+				c.datastoresInMaintenance, prometheus.GaugeValue, inMaintenance, labelValues...,
+			))
+		}
 	}
 }
@@ -0,0 +1,144 @@
+package collector
+
+import (
+	"context"
+	"slices"
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sanderdescamps/govc_exporter/internal/config"
+	"github.com/sanderdescamps/govc_exporter/internal/scraper"
+)
+
+const (
+	storagePolicyCollectorSubsystem = "storage_policy"
+)
+
+type storagePolicyCollector struct {
+	scraper          *scraper.VCenterScraper
+	objectLabels     objectLabeler
+	folderPathLabel  bool
+	annotationParser annotationParser
+
+	info                   *prometheus.Desc
+	compatibleDatastores   *prometheus.Desc
+	incompatibleDatastores *prometheus.Desc
+	entities               *prometheus.Desc
+	vmCompliance           *prometheus.Desc
+}
+
+func NewStoragePolicyCollector(scraper *scraper.VCenterScraper, cConf config.CollectorConfig) *storagePolicyCollector {
+	policyLabels := []string{"policy_id", "policy"}
+	entitiesLabels := append(slices.Clone(policyLabels), "status")
+
+	vmLabels := []string{"uuid", "name", "template", "vm_id", "pool"}
+	if cConf.FolderPathLabel {
+		vmLabels = append(vmLabels, "folder_path")
+	}
+	objectLabels := newObjectLabeler(cConf.VMTagLabels, cConf.VMAttributeLabels, cConf)
+	vmLabels = objectLabels.Labels(vmLabels)
+	annotationParser := newAnnotationParser(cConf)
+	vmLabels = append(vmLabels, annotationParser.Labels()...)
+	vmComplianceLabels := append(slices.Clone(vmLabels), "entity_type", "disk", "policy_id", "policy", "status")
+
+	return &storagePolicyCollector{
+		scraper:          scraper,
+		objectLabels:     objectLabels,
+		folderPathLabel:  cConf.FolderPathLabel,
+		annotationParser: annotationParser,
+		info: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, storagePolicyCollectorSubsystem, "info"),
+			"storage policy info", policyLabels, nil),
+		compatibleDatastores: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, storagePolicyCollectorSubsystem, "compatible_datastores"),
+			"Number of datastores compatible with the storage policy", policyLabels, nil),
+		incompatibleDatastores: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, storagePolicyCollectorSubsystem, "incompatible_datastores"),
+			"Number of datastores not compatible with the storage policy", policyLabels, nil),
+		entities: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, storagePolicyCollectorSubsystem, "entities"),
+			"Number of vm homes and disks with the storage policy per compliance status", entitiesLabels, nil),
+		vmCompliance: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, storagePolicyCollectorSubsystem, "vm_compliance_status"),
+			"Storage policy compliance of a vm home or disk (0=unknown, 1=nonCompliant, 2=outOfDate, 3=compliant)", vmComplianceLabels, nil),
+	}
+}
+
+func (c *storagePolicyCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.info
+	ch <- c.compatibleDatastores
+	ch <- c.incompatibleDatastores
+	ch <- c.entities
+	ch <- c.vmCompliance
+}
+
+func (c *storagePolicyCollector) Collect(ch chan<- prometheus.Metric) {
+	if !c.scraper.StoragePolicy.Enabled() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), COLLECT_TIMEOUT)
+	defer cancel()
+
+	vmPolicies, err := c.scraper.DB.GetAllVMStoragePolicy(ctx)
+	if err != nil && Logger != nil {
+		Logger.Error("failed to get vm storage policies", "err", err)
+	}
+
+	// policy id => compliance status => number of entities
+	entityCount := map[string]map[string]float64{}
+	for _, vmPolicy := range vmPolicies {
+		for _, entity := range vmPolicy.Entities {
+			if entityCount[entity.PolicyID] == nil {
+				entityCount[entity.PolicyID] = map[string]float64{}
+			}
+			entityCount[entity.PolicyID][entity.ComplianceStatus]++
+		}
+
+		vm := c.scraper.DB.GetVM(ctx, vmPolicy.VM)
+		if vm == nil || vm.Timestamp.IsZero() {
+			continue
+		}
+
+		annotationLabelValues := c.annotationParser.LabelValues(vm.Annotation)
+
+		objectTags := c.scraper.DB.GetTags(ctx, vm.Self)
+		objectAttributes := c.scraper.DB.GetAttributes(ctx, vm.Self)
+		baseLabelValues := []string{vm.UUID, vm.Name, strconv.FormatBool(vm.Template), vm.Self.Value, vm.ResourcePool}
+		if c.folderPathLabel {
+			baseLabelValues = append(baseLabelValues, vm.FolderPath)
+		}
+		for _, labelValues := range c.objectLabels.LabelValues(baseLabelValues, objectTags, objectAttributes) {
+			labelValues = append(labelValues, annotationLabelValues...)
+
+			for _, entity := range vmPolicy.Entities {
+				ch <- prometheus.NewMetricWithTimestamp(vmPolicy.Timestamp, prometheus.MustNewConstMetric(
+					c.vmCompliance, prometheus.GaugeValue, entity.ComplianceStatusFloat64(),
+					append(slices.Clone(labelValues), entity.Type, entity.Disk, entity.PolicyID, entity.PolicyName, entity.ComplianceStatus)...,
+				))
+			}
+		}
+	}
+
+	policies, err := c.scraper.DB.GetAllStoragePolicy(ctx)
+	if err != nil && Logger != nil {
+		Logger.Error("failed to get storage policies", "err", err)
+	}
+	for _, policy := range policies {
+		labelValues := []string{policy.ID, policy.Name}
+		ch <- prometheus.NewMetricWithTimestamp(policy.Timestamp, prometheus.MustNewConstMetric(
+			c.info, prometheus.GaugeValue, 1, labelValues...,
+		))
+		ch <- prometheus.NewMetricWithTimestamp(policy.Timestamp, prometheus.MustNewConstMetric(
+			c.compatibleDatastores, prometheus.GaugeValue, float64(len(policy.CompatibleDatastores)), labelValues...,
+		))
+		ch <- prometheus.NewMetricWithTimestamp(policy.Timestamp, prometheus.MustNewConstMetric(
+			c.incompatibleDatastores, prometheus.GaugeValue, float64(len(policy.IncompatibleDatastores)), labelValues...,
+		))
+		for status, n := range entityCount[policy.ID] {
+			ch <- prometheus.NewMetricWithTimestamp(policy.Timestamp, prometheus.MustNewConstMetric(
+				c.entities, prometheus.GaugeValue, n, append(slices.Clone(labelValues), status)...,
+			))
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package collector
+
+import (
+	"context"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sanderdescamps/govc_exporter/internal/config"
+	"github.com/sanderdescamps/govc_exporter/internal/scraper"
+)
+
+const (
+	tagCollectorSubsystem = "tag"
+)
+
+type tagCollector struct {
+	scraper *scraper.VCenterScraper
+
+	info *prometheus.Desc
+}
+
+func NewTagCollector(scraper *scraper.VCenterScraper, cConf config.CollectorConfig) *tagCollector {
+	labels := []string{"object_type", "object_id", "category", "tag"}
+
+	return &tagCollector{
+		scraper: scraper,
+		info: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, tagCollectorSubsystem, "info"),
+			"tags attached to an object, one series per object, category and tag", labels, nil),
+	}
+}
+
+func (c *tagCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.info
+}
+
+func (c *tagCollector) Collect(ch chan<- prometheus.Metric) {
+	if !c.scraper.Tags.Enabled() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), COLLECT_TIMEOUT)
+	defer cancel()
+
+	tagSets, err := c.scraper.DB.GetAllTagSets(ctx)
+	if err != nil && Logger != nil {
+		Logger.Error("failed to get tags", "err", err)
+	}
+	for _, tagSet := range tagSets {
+		for cat, tags := range tagSet.Tags {
+			for _, tag := range tags {
+				ch <- prometheus.MustNewConstMetric(
+					c.info, prometheus.GaugeValue, 1.0, tagSet.ObjectRef.Type.String(), tagSet.ObjectRef.Value, cat, tag,
+				)
+			}
+		}
+	}
+}
@@ -0,0 +1,138 @@
+package collector
+
+import (
+	"context"
+	"slices"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sanderdescamps/govc_exporter/internal/config"
+	"github.com/sanderdescamps/govc_exporter/internal/scraper"
+)
+
+const (
+	virtualAppCollectorSubsystem = "vapp"
+)
+
+type virtualAppCollector struct {
+	scraper          *scraper.VCenterScraper
+	objectLabels     objectLabeler
+	folderPathLabel  bool
+	annotationParser annotationParser
+
+	info             *prometheus.Desc
+	state            *prometheus.Desc
+	numVM            *prometheus.Desc
+	overallCPUUsage  *prometheus.Desc
+	overallCPUDemand *prometheus.Desc
+	guestMemoryUsage *prometheus.Desc
+	hostMemoryUsage  *prometheus.Desc
+	overallStatus    *prometheus.Desc
+}
+
+func NewVirtualAppCollector(scraper *scraper.VCenterScraper, cConf config.CollectorConfig) *virtualAppCollector {
+	labels := []string{"id", "name", "datacenter", "cluster"}
+	if cConf.FolderPathLabel {
+		labels = append(labels, "folder_path")
+	}
+	objectLabels := newObjectLabeler(cConf.VirtualAppTagLabels, cConf.VirtualAppAttributeLabels, cConf)
+	labels = objectLabels.Labels(labels)
+	annotationParser := newAnnotationParser(cConf)
+	labels = append(labels, annotationParser.Labels()...)
+
+	infoLabels := append(slices.Clone(labels), "product", "version")
+
+	return &virtualAppCollector{
+		scraper:          scraper,
+		objectLabels:     objectLabels,
+		folderPathLabel:  cConf.FolderPathLabel,
+		annotationParser: annotationParser,
+		info: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, virtualAppCollectorSubsystem, "info"),
+			"Info about the vApp", infoLabels, nil),
+		state: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, virtualAppCollectorSubsystem, "state"),
+			"vApp state (0=stopped, 1=started, 2=starting, 3=stopping)", labels, nil),
+		numVM: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, virtualAppCollectorSubsystem, "vm_number_total"),
+			"number of vm's in the vApp", labels, nil),
+		overallCPUUsage: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, virtualAppCollectorSubsystem, "used_cpu_mhz"),
+			"vApp overall CPU usage MHz", labels, nil),
+		overallCPUDemand: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, virtualAppCollectorSubsystem, "demanded_cpu_mhz"),
+			"vApp overall CPU demand MHz", labels, nil),
+		guestMemoryUsage: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, virtualAppCollectorSubsystem, "guest_used_mem_bytes"),
+			"vApp guest memory usage in bytes", labels, nil),
+		hostMemoryUsage: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, virtualAppCollectorSubsystem, "host_used_mem_bytes"),
+			"vApp host memory usage in bytes", labels, nil),
+		overallStatus: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, virtualAppCollectorSubsystem, "overall_status"),
+			"overall health status", labels, nil),
+	}
+}
+
+func (c *virtualAppCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.info
+	ch <- c.state
+	ch <- c.numVM
+	ch <- c.overallCPUUsage
+	ch <- c.overallCPUDemand
+	ch <- c.guestMemoryUsage
+	ch <- c.hostMemoryUsage
+	ch <- c.overallStatus
+}
+
+func (c *virtualAppCollector) Collect(ch chan<- prometheus.Metric) {
+	if !c.scraper.VirtualApp.Enabled() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), COLLECT_TIMEOUT)
+	defer cancel()
+
+	vApps, err := c.scraper.DB.GetAllVirtualApp(ctx)
+	if err != nil && Logger != nil {
+		Logger.Error("failed to get vApps", "err", err)
+	}
+	for _, vApp := range vApps {
+		annotationLabelValues := c.annotationParser.LabelValues(vApp.Annotation)
+
+		objectTags := c.scraper.DB.GetTags(ctx, vApp.Self)
+		objectAttributes := c.scraper.DB.GetAttributes(ctx, vApp.Self)
+		baseLabelValues := []string{vApp.Self.ID(), vApp.Name, vApp.Datacenter, vApp.Cluster}
+		if c.folderPathLabel {
+			baseLabelValues = append(baseLabelValues, vApp.FolderPath)
+		}
+		for _, labelValues := range c.objectLabels.LabelValues(baseLabelValues, objectTags, objectAttributes) {
+			labelValues = append(labelValues, annotationLabelValues...)
+
+			infoLabelValues := append(slices.Clone(labelValues), vApp.Product, vApp.Version)
+			ch <- prometheus.NewMetricWithTimestamp(vApp.Timestamp, prometheus.MustNewConstMetric(
+				c.info, prometheus.GaugeValue, 1, infoLabelValues...,
+			))
+			ch <- prometheus.NewMetricWithTimestamp(vApp.Timestamp, prometheus.MustNewConstMetric(
+				c.state, prometheus.GaugeValue, vApp.StateFloat64(), labelValues...,
+			))
+			ch <- prometheus.NewMetricWithTimestamp(vApp.Timestamp, prometheus.MustNewConstMetric(
+				c.numVM, prometheus.GaugeValue, float64(len(vApp.VMs)), labelValues...,
+			))
+			ch <- prometheus.NewMetricWithTimestamp(vApp.Timestamp, prometheus.MustNewConstMetric(
+				c.overallCPUUsage, prometheus.GaugeValue, vApp.OverallCPUUsage, labelValues...,
+			))
+			ch <- prometheus.NewMetricWithTimestamp(vApp.Timestamp, prometheus.MustNewConstMetric(
+				c.overallCPUDemand, prometheus.GaugeValue, vApp.OverallCPUDemand, labelValues...,
+			))
+			ch <- prometheus.NewMetricWithTimestamp(vApp.Timestamp, prometheus.MustNewConstMetric(
+				c.guestMemoryUsage, prometheus.GaugeValue, vApp.GuestMemoryUsage, labelValues...,
+			))
+			ch <- prometheus.NewMetricWithTimestamp(vApp.Timestamp, prometheus.MustNewConstMetric(
+				c.hostMemoryUsage, prometheus.GaugeValue, vApp.HostMemoryUsage, labelValues...,
+			))
+			ch <- prometheus.NewMetricWithTimestamp(vApp.Timestamp, prometheus.MustNewConstMetric(
+				c.overallStatus, prometheus.GaugeValue, vApp.OverallStatusFloat64(), labelValues...,
+			))
+		}
+	}
+}
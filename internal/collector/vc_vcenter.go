@@ -0,0 +1,116 @@
+package collector
+
+import (
+	"context"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sanderdescamps/govc_exporter/internal/config"
+	"github.com/sanderdescamps/govc_exporter/internal/scraper"
+)
+
+const (
+	vcenterCollectorSubsystem = "vcenter"
+)
+
+type vcenterCollector struct {
+	scraper *scraper.VCenterScraper
+
+	info           *prometheus.Desc
+	serverTime     *prometheus.Desc
+	clockSkew      *prometheus.Desc
+	sessions       *prometheus.Desc
+	sessionMaxIdle *prometheus.Desc
+}
+
+func NewVCenterCollector(scraper *scraper.VCenterScraper, cConf config.CollectorConfig) *vcenterCollector {
+	infoLabels := []string{"name", "full_name", "version", "build", "api_type", "api_version", "instance_uuid", "os_type", "product_line_id"}
+	sessionLabels := []string{"user", "idle"}
+	userLabels := []string{"user"}
+
+	return &vcenterCollector{
+		scraper: scraper,
+		info: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, vcenterCollectorSubsystem, "info"),
+			"vCenter about info", infoLabels, nil),
+		serverTime: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, vcenterCollectorSubsystem, "server_time_seconds"),
+			"Current time of the vCenter server", nil, nil),
+		clockSkew: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, vcenterCollectorSubsystem, "clock_skew_seconds"),
+			"Time of the vCenter server minus the time of the exporter", nil, nil),
+		sessions: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, vcenterCollectorSubsystem, "sessions"),
+			"Number of active vCenter sessions per user and time since the last activity (<5m, 5m-1h, 1h-24h, >24h)", sessionLabels, nil),
+		sessionMaxIdle: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, vcenterCollectorSubsystem, "session_max_idle_seconds"),
+			"Highest time since the last activity of the sessions of the user", userLabels, nil),
+	}
+}
+
+func (c *vcenterCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.info
+	ch <- c.serverTime
+	ch <- c.clockSkew
+	ch <- c.sessions
+	ch <- c.sessionMaxIdle
+}
+
+func (c *vcenterCollector) Collect(ch chan<- prometheus.Metric) {
+	if !c.scraper.ServiceInstance.Enabled() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), COLLECT_TIMEOUT)
+	defer cancel()
+
+	vcenters, err := c.scraper.DB.GetAllVCenter(ctx)
+	if err != nil && Logger != nil {
+		Logger.Error("failed to get vcenter info", "err", err)
+	}
+	for _, vc := range vcenters {
+		ch <- prometheus.NewMetricWithTimestamp(vc.Timestamp, prometheus.MustNewConstMetric(
+			c.info, prometheus.GaugeValue, 1,
+			vc.Name, vc.FullName, vc.Version, vc.Build, vc.APIType, vc.APIVersion, vc.InstanceUUID, vc.OSType, vc.ProductLineID,
+		))
+		ch <- prometheus.NewMetricWithTimestamp(vc.Timestamp, prometheus.MustNewConstMetric(
+			c.serverTime, prometheus.GaugeValue, float64(vc.ServerTime.Unix()),
+		))
+		ch <- prometheus.NewMetricWithTimestamp(vc.Timestamp, prometheus.MustNewConstMetric(
+			c.clockSkew, prometheus.GaugeValue, vc.ClockSkewSeconds,
+		))
+
+		// user => idle bucket => number of sessions
+		sessions := map[string]map[string]float64{}
+		maxIdle := map[string]float64{}
+		for _, session := range vc.Sessions {
+			idle := session.IdleSeconds(vc.ServerTime)
+			if sessions[session.UserName] == nil {
+				sessions[session.UserName] = map[string]float64{}
+			}
+			sessions[session.UserName][sessionIdleBucket(idle)]++
+			maxIdle[session.UserName] = max(maxIdle[session.UserName], idle)
+		}
+		for user, buckets := range sessions {
+			for bucket, n := range buckets {
+				ch <- prometheus.NewMetricWithTimestamp(vc.Timestamp, prometheus.MustNewConstMetric(
+					c.sessions, prometheus.GaugeValue, n, user, bucket,
+				))
+			}
+			ch <- prometheus.NewMetricWithTimestamp(vc.Timestamp, prometheus.MustNewConstMetric(
+				c.sessionMaxIdle, prometheus.GaugeValue, maxIdle[user], user,
+			))
+		}
+	}
+}
+
+func sessionIdleBucket(idleSeconds float64) string {
+	switch {
+	case idleSeconds < 5*60:
+		return "<5m"
+	case idleSeconds < 60*60:
+		return "5m-1h"
+	case idleSeconds < 24*60*60:
+		return "1h-24h"
+	}
+	return ">24h"
+}
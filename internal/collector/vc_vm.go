@@ -19,8 +19,11 @@ type virtualMachineCollector struct {
 	legacyMetrics          bool
 	advancedStorageMetrics bool
 	advancedNetworkMetrics bool
-	useIsecSpecifics       bool
-	extraLabels            []string
+	guestDiskMetrics       bool
+	datastoreMetrics       bool
+	objectLabels           objectLabeler
+	folderPathLabel        bool
+	annotationParser       annotationParser
 
 	numCPU                      *prometheus.Desc
 	numCoresPerSocket           *prometheus.Desc
@@ -37,12 +40,15 @@ type virtualMachineCollector struct {
 
 	uptimeSeconds        *prometheus.Desc
 	numSnapshot          *prometheus.Desc
+	snapshotTotalSize    *prometheus.Desc
+	consolidationNeeded  *prometheus.Desc
 	powerState           *prometheus.Desc
 	overallStatus        *prometheus.Desc
 	guestHeartbeatStatus *prometheus.Desc
 	toolsStatus          *prometheus.Desc
 	vmInfo               *prometheus.Desc
 	hostInfo             *prometheus.Desc
+	guestInfo            *prometheus.Desc
 
 	// legacy metrics
 	distributedCPUEntitlement    *prometheus.Desc
@@ -59,35 +65,69 @@ type virtualMachineCollector struct {
 	compressedMemory             *prometheus.Desc
 	ssdSwappedMemory             *prometheus.Desc
 
+	// Snapshot metrics
+	snapshotCreateTime *prometheus.Desc
+	snapshotAge        *prometheus.Desc
+	snapshotSize       *prometheus.Desc
+	snapshotDepth      *prometheus.Desc
+	snapshotMemory     *prometheus.Desc
+
+	// Datastore metrics
+	datastoreInfo        *prometheus.Desc
+	datastoreCommitted   *prometheus.Desc
+	datastoreUncommitted *prometheus.Desc
+	datastoreUnshared    *prometheus.Desc
+
 	// Advanced network metrics
-	networkConnected *prometheus.Desc
+	networkConnected  *prometheus.Desc
+	nicInfo           *prometheus.Desc
+	nicConnected      *prometheus.Desc
+	nicStartConnected *prometheus.Desc
+	nicIpInfo         *prometheus.Desc
 	// ethernetDriverConnected *prometheus.Desc
 
 	// Advanced storage metrics
 	diskCapacityBytes *prometheus.Desc
+	diskInfo          *prometheus.Desc
+
+	// Guest filesystem metrics
+	guestDiskCapacity  *prometheus.Desc
+	guestDiskFreeSpace *prometheus.Desc
 }
 
 func NewVirtualMachineCollector(scraper *scraper.VCenterScraper, cConf config.CollectorConfig) *virtualMachineCollector {
 	labels := []string{"uuid", "name", "template", "vm_id", "pool"}
-	extraLabels := cConf.VMTagLabels
-	if len(extraLabels) != 0 {
-		labels = append(labels, extraLabels...)
+	if cConf.FolderPathLabel {
+		labels = append(labels, "folder_path")
 	}
+	objectLabels := newObjectLabeler(cConf.VMTagLabels, cConf.VMAttributeLabels, cConf)
+	labels = objectLabels.Labels(labels)
+	annotationParser := newAnnotationParser(cConf)
+	labels = append(labels, annotationParser.Labels()...)
 
-	if cConf.UseIsecSpecifics {
-		labels = append(labels, "crit", "responsable", "service")
-	}
-	infoLabels := append(slices.Clone(labels), "guest_id", "tools_version")
+	infoLabels := append(slices.Clone(labels), "guest_id", "tools_version", "vapp")
 	hostLabels := append(slices.Clone(labels), "datacenter", "cluster", "esx")
 	diskLabels := append(slices.Clone(labels), "disk_uuid", "thin_provisioned")
 	networkLabels := append(slices.Clone(labels), "mac", "ip")
+	nicLabels := append(slices.Clone(labels), "mac", "adapter_label")
+	nicInfoLabels := append(slices.Clone(nicLabels), "adapter_type", "backing_type", "network", "network_id", "dvs_uuid", "port_key")
+	nicIpLabels := append(slices.Clone(nicLabels), "ip", "prefix_length", "family")
+	snapshotLabels := append(slices.Clone(labels), "snapshot_id", "snapshot_name")
+	guestInfoLabels := append(slices.Clone(labels), "hostname", "guest_full_name")
+	guestDiskLabels := append(slices.Clone(labels), "disk_path", "filesystem_type")
+	datastoreLabels := append(slices.Clone(labels), "datastore_id", "datastore")
+	diskInfoLabels := append(slices.Clone(labels), "disk_uuid", "disk_label", "backing_type", "disk_mode", "thin_provisioned", "controller_type", "bus_number", "unit_number", "datastore", "vmdk_file")
 
 	return &virtualMachineCollector{
 		scraper:                scraper,
-		extraLabels:            extraLabels,
+		objectLabels:           objectLabels,
+		folderPathLabel:        cConf.FolderPathLabel,
+		annotationParser:       annotationParser,
 		legacyMetrics:          cConf.VMLegacyMetrics,
 		advancedNetworkMetrics: cConf.VMAdvancedNetworkMetrics,
 		advancedStorageMetrics: cConf.VMAdvancedStorageMetrics,
+		guestDiskMetrics:       cConf.VMGuestDiskMetrics,
+		datastoreMetrics:       cConf.VMDatastoreMetrics,
 
 		numCPU: prometheus.NewDesc(
 			prometheus.BuildFQName(namespace, virtualMachineCollectorSubsystem, "cpu_number"),
@@ -131,6 +171,12 @@ func NewVirtualMachineCollector(scraper *scraper.VCenterScraper, cConf config.Co
 		numSnapshot: prometheus.NewDesc(
 			prometheus.BuildFQName(namespace, virtualMachineCollectorSubsystem, "snapshot_number_total"),
 			"vm number of snapshot", labels, nil),
+		snapshotTotalSize: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, virtualMachineCollectorSubsystem, "snapshot_total_size_bytes"),
+			"total size of all snapshots of the vm in bytes", labels, nil),
+		consolidationNeeded: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, virtualMachineCollectorSubsystem, "consolidation_needed"),
+			"vm disks need to be consolidated", labels, nil),
 		powerState: prometheus.NewDesc(
 			prometheus.BuildFQName(namespace, virtualMachineCollectorSubsystem, "power_state"),
 			"vm power state", labels, nil),
@@ -149,16 +195,73 @@ func NewVirtualMachineCollector(scraper *scraper.VCenterScraper, cConf config.Co
 		hostInfo: prometheus.NewDesc(
 			prometheus.BuildFQName(namespace, virtualMachineCollectorSubsystem, "host_info"),
 			"Info about the host", hostLabels, nil),
+		guestInfo: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, virtualMachineCollectorSubsystem, "guest_info"),
+			"Info about the guest OS reported by VMware Tools", guestInfoLabels, nil),
+
+		// Snapshot metrics
+		snapshotCreateTime: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, virtualMachineCollectorSubsystem, "snapshot_create_timestamp_seconds"),
+			"creation time of the snapshot as unix timestamp", snapshotLabels, nil),
+		snapshotAge: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, virtualMachineCollectorSubsystem, "snapshot_age_seconds"),
+			"age of the snapshot in seconds", snapshotLabels, nil),
+		snapshotSize: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, virtualMachineCollectorSubsystem, "snapshot_size_bytes"),
+			"size of the delta disks and state files of the snapshot in bytes", snapshotLabels, nil),
+		snapshotDepth: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, virtualMachineCollectorSubsystem, "snapshot_depth"),
+			"depth of the snapshot in the snapshot chain, the root snapshot has depth 1", snapshotLabels, nil),
+		snapshotMemory: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, virtualMachineCollectorSubsystem, "snapshot_memory"),
+			"snapshot includes the memory of the vm", snapshotLabels, nil),
+
+		// Datastore metrics
+		datastoreInfo: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, virtualMachineCollectorSubsystem, "datastore_info"),
+			"datastores on which the vm stores files", datastoreLabels, nil),
+		datastoreCommitted: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, virtualMachineCollectorSubsystem, "datastore_committed_bytes"),
+			"storage used by the vm on the datastore in bytes", datastoreLabels, nil),
+		datastoreUncommitted: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, virtualMachineCollectorSubsystem, "datastore_uncommitted_bytes"),
+			"additional storage the vm can use on the datastore in bytes", datastoreLabels, nil),
+		datastoreUnshared: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, virtualMachineCollectorSubsystem, "datastore_unshared_bytes"),
+			"storage on the datastore which is exclusively used by the vm in bytes", datastoreLabels, nil),
 
 		// Advanced network metrics
 		networkConnected: prometheus.NewDesc(
 			prometheus.BuildFQName(namespace, virtualMachineCollectorSubsystem, "network_connected"),
 			"vm network connected", networkLabels, nil),
+		nicInfo: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, virtualMachineCollectorSubsystem, "network_adapter_info"),
+			"vm network adapter type and backing", nicInfoLabels, nil),
+		nicConnected: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, virtualMachineCollectorSubsystem, "network_adapter_connected"),
+			"vm network adapter is connected", nicLabels, nil),
+		nicStartConnected: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, virtualMachineCollectorSubsystem, "network_adapter_start_connected"),
+			"vm network adapter is connected when the vm starts", nicLabels, nil),
+		nicIpInfo: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, virtualMachineCollectorSubsystem, "network_adapter_ip_info"),
+			"ip addresses reported by the guest for the network adapter", nicIpLabels, nil),
 
 		// Advanced storage metrics
 		diskCapacityBytes: prometheus.NewDesc(
 			prometheus.BuildFQName(namespace, virtualMachineCollectorSubsystem, "disk_capacity_bytes"),
 			"vm disk capacity bytes", diskLabels, nil),
+		diskInfo: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, virtualMachineCollectorSubsystem, "disk_info"),
+			"vm disk backing and controller info", diskInfoLabels, nil),
+
+		// Guest filesystem metrics
+		guestDiskCapacity: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, virtualMachineCollectorSubsystem, "guest_disk_capacity_bytes"),
+			"capacity of the guest filesystem in bytes", guestDiskLabels, nil),
+		guestDiskFreeSpace: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, virtualMachineCollectorSubsystem, "guest_disk_free_bytes"),
+			"free space on the guest filesystem in bytes", guestDiskLabels, nil),
 
 		//Legacy metrics
 		distributedCPUEntitlement: prometheus.NewDesc(
@@ -218,12 +321,15 @@ func (c *virtualMachineCollector) Describe(ch chan<- *prometheus.Desc) {
 	ch <- c.memoryAllocationReservation
 	ch <- c.uptimeSeconds
 	ch <- c.numSnapshot
+	ch <- c.snapshotTotalSize
+	ch <- c.consolidationNeeded
 	ch <- c.powerState
 	ch <- c.overallStatus
 	ch <- c.guestHeartbeatStatus
 	ch <- c.toolsStatus
 	ch <- c.vmInfo
 	ch <- c.hostInfo
+	ch <- c.guestInfo
 
 	// Legacy metrics
 	ch <- c.distributedCPUEntitlement
@@ -240,10 +346,31 @@ func (c *virtualMachineCollector) Describe(ch chan<- *prometheus.Desc) {
 	ch <- c.compressedMemory
 	ch <- c.ssdSwappedMemory
 
+	// Snapshot metrics
+	ch <- c.snapshotCreateTime
+	ch <- c.snapshotAge
+	ch <- c.snapshotSize
+	ch <- c.snapshotDepth
+	ch <- c.snapshotMemory
+
+	// Datastore metrics
+	ch <- c.datastoreInfo
+	ch <- c.datastoreCommitted
+	ch <- c.datastoreUncommitted
+	ch <- c.datastoreUnshared
+
 	// Advanced network metrics
 	ch <- c.networkConnected
+	ch <- c.nicInfo
+	ch <- c.nicConnected
+	ch <- c.nicStartConnected
+	ch <- c.nicIpInfo
 	// Advanced storage metrics
 	ch <- c.diskCapacityBytes
+	ch <- c.diskInfo
+	// Guest filesystem metrics
+	ch <- c.guestDiskCapacity
+	ch <- c.guestDiskFreeSpace
 }
 
 func (c *virtualMachineCollector) Collect(ch chan<- prometheus.Metric) {
@@ -259,160 +386,238 @@ func (c *virtualMachineCollector) Collect(ch chan<- prometheus.Metric) {
 		Logger.Error("failed to get vm's", "err", err)
 	}
 	for _, vm := range vms {
-		extraLabelValues := []string{}
+		annotationLabelValues := c.annotationParser.LabelValues(vm.Annotation)
+
 		objectTags := c.scraper.DB.GetTags(ctx, vm.Self)
-		for _, tagCat := range c.extraLabels {
-			extraLabelValues = append(extraLabelValues, objectTags.GetTag(tagCat))
+		objectAttributes := c.scraper.DB.GetAttributes(ctx, vm.Self)
+		baseLabelValues := []string{vm.UUID, vm.Name, strconv.FormatBool(vm.Template), vm.Self.Value, vm.ResourcePool}
+		if c.folderPathLabel {
+			baseLabelValues = append(baseLabelValues, vm.FolderPath)
 		}
+		for _, labelValues := range c.objectLabels.LabelValues(baseLabelValues, objectTags, objectAttributes) {
+			labelValues = append(labelValues, annotationLabelValues...)
 
-		labelValues := []string{vm.UUID, vm.Name, strconv.FormatBool(vm.Template), vm.Self.Value, vm.ResourcePool}
-		labelValues = append(slices.Clone(labelValues), extraLabelValues...)
-
-		if c.useIsecSpecifics && vm.IsecAnnotation != nil {
-			annotation := vm.IsecAnnotation
-			labelValues = append(
-				slices.Clone(labelValues),
-				annotation.Criticality,
-				annotation.Responsable,
-				annotation.Service,
-			)
-		}
+			hostLabelValues := append(slices.Clone(labelValues), vm.Datacenter, vm.HostInfo.Cluster, vm.HostInfo.Host)
 
-		hostLabelValues := append(slices.Clone(labelValues), vm.Datacenter, vm.HostInfo.Cluster, vm.HostInfo.Host)
-
-		ch <- prometheus.NewMetricWithTimestamp(vm.Timestamp, prometheus.MustNewConstMetric(
-			c.numCPU, prometheus.GaugeValue, vm.NumCPU, labelValues...,
-		))
-		ch <- prometheus.NewMetricWithTimestamp(vm.Timestamp, prometheus.MustNewConstMetric(
-			c.numCoresPerSocket, prometheus.GaugeValue, vm.NumCoresPerSocket, labelValues...,
-		))
-		ch <- prometheus.NewMetricWithTimestamp(vm.Timestamp, prometheus.MustNewConstMetric(
-			c.maxCPUUsage, prometheus.GaugeValue, vm.MaxCPUUsage, labelValues...,
-		))
-		ch <- prometheus.NewMetricWithTimestamp(vm.Timestamp, prometheus.MustNewConstMetric(
-			c.overallCPUUsage, prometheus.GaugeValue, vm.OverallCPUUsage, labelValues...,
-		))
-		ch <- prometheus.NewMetricWithTimestamp(vm.Timestamp, prometheus.MustNewConstMetric(
-			c.overallCPUDemand, prometheus.GaugeValue, vm.OverallCPUDemand, labelValues...,
-		))
-		if vm.CPUAllocationShares != 0.0 {
 			ch <- prometheus.NewMetricWithTimestamp(vm.Timestamp, prometheus.MustNewConstMetric(
-				c.cpuAllocationShares, prometheus.GaugeValue, vm.CPUAllocationShares, labelValues...,
+				c.numCPU, prometheus.GaugeValue, vm.NumCPU, labelValues...,
 			))
-		}
-		if vm.CPUAllocationReservation != 0.0 {
 			ch <- prometheus.NewMetricWithTimestamp(vm.Timestamp, prometheus.MustNewConstMetric(
-				c.cpuAllocationReservation, prometheus.GaugeValue, vm.CPUAllocationReservation, labelValues...,
+				c.numCoresPerSocket, prometheus.GaugeValue, vm.NumCoresPerSocket, labelValues...,
 			))
-		}
-		ch <- prometheus.NewMetricWithTimestamp(vm.Timestamp, prometheus.MustNewConstMetric(
-			c.memoryBytes, prometheus.GaugeValue, vm.MemoryBytes, labelValues...,
-		))
-		ch <- prometheus.NewMetricWithTimestamp(vm.Timestamp, prometheus.MustNewConstMetric(
-			c.guestMemoryUsage, prometheus.GaugeValue, vm.GuestMemoryUsage, labelValues...,
-		))
-		ch <- prometheus.NewMetricWithTimestamp(vm.Timestamp, prometheus.MustNewConstMetric(
-			c.hostMemoryUsage, prometheus.GaugeValue, vm.HostMemoryUsage, labelValues...,
-		))
-		if vm.MemoryAllocationShares != 0.0 {
 			ch <- prometheus.NewMetricWithTimestamp(vm.Timestamp, prometheus.MustNewConstMetric(
-				c.memoryAllocationShares, prometheus.GaugeValue, vm.MemoryAllocationShares, labelValues...,
+				c.maxCPUUsage, prometheus.GaugeValue, vm.MaxCPUUsage, labelValues...,
 			))
-		}
-		if vm.MemoryAllocationReservation != 0.0 {
 			ch <- prometheus.NewMetricWithTimestamp(vm.Timestamp, prometheus.MustNewConstMetric(
-				c.memoryAllocationReservation, prometheus.GaugeValue, vm.MemoryAllocationReservation, labelValues...,
+				c.overallCPUUsage, prometheus.GaugeValue, vm.OverallCPUUsage, labelValues...,
 			))
-		}
-		ch <- prometheus.NewMetricWithTimestamp(vm.Timestamp, prometheus.MustNewConstMetric(
-			c.uptimeSeconds, prometheus.GaugeValue, vm.UptimeSeconds, labelValues...,
-		))
-		ch <- prometheus.NewMetricWithTimestamp(vm.Timestamp, prometheus.MustNewConstMetric(
-			c.numSnapshot, prometheus.GaugeValue, float64(len(vm.Snapshot)), labelValues...,
-		))
-
-		ch <- prometheus.NewMetricWithTimestamp(vm.Timestamp, prometheus.MustNewConstMetric(
-			c.powerState, prometheus.GaugeValue, vm.PowerStateFloat64(), labelValues...,
-		))
-		ch <- prometheus.NewMetricWithTimestamp(vm.Timestamp, prometheus.MustNewConstMetric(
-			c.overallStatus, prometheus.GaugeValue, vm.OverallStatusFloat64(), labelValues...,
-		))
-		ch <- prometheus.NewMetricWithTimestamp(vm.Timestamp, prometheus.MustNewConstMetric(
-			c.guestHeartbeatStatus, prometheus.GaugeValue, vm.GuestHeartbeatStateFloat64(), labelValues...,
-		))
-		ch <- prometheus.NewMetricWithTimestamp(vm.Timestamp, prometheus.MustNewConstMetric(
-			c.toolsStatus, prometheus.GaugeValue, vm.GuestToolsStatusFloat64(), labelValues...,
-		))
-
-		infoLabelValues := append(slices.Clone(labelValues), vm.GuestID, vm.GuestToolsVersion)
-		ch <- prometheus.NewMetricWithTimestamp(vm.Timestamp, prometheus.MustNewConstMetric(
-			c.vmInfo, prometheus.GaugeValue, 0, infoLabelValues...,
-		))
-
-		ch <- prometheus.NewMetricWithTimestamp(vm.Timestamp, prometheus.MustNewConstMetric(
-			c.hostInfo, prometheus.GaugeValue, 1, hostLabelValues...,
-		))
-
-		//Legacy metrics
-		if c.legacyMetrics {
 			ch <- prometheus.NewMetricWithTimestamp(vm.Timestamp, prometheus.MustNewConstMetric(
-				c.distributedCPUEntitlement, prometheus.GaugeValue, vm.DistributedCPUEntitlement, labelValues...,
+				c.overallCPUDemand, prometheus.GaugeValue, vm.OverallCPUDemand, labelValues...,
+			))
+			if vm.CPUAllocationShares != 0.0 {
+				ch <- prometheus.NewMetricWithTimestamp(vm.Timestamp, prometheus.MustNewConstMetric(
+					c.cpuAllocationShares, prometheus.GaugeValue, vm.CPUAllocationShares, labelValues...,
+				))
+			}
+			if vm.CPUAllocationReservation != 0.0 {
+				ch <- prometheus.NewMetricWithTimestamp(vm.Timestamp, prometheus.MustNewConstMetric(
+					c.cpuAllocationReservation, prometheus.GaugeValue, vm.CPUAllocationReservation, labelValues...,
+				))
+			}
+			ch <- prometheus.NewMetricWithTimestamp(vm.Timestamp, prometheus.MustNewConstMetric(
+				c.memoryBytes, prometheus.GaugeValue, vm.MemoryBytes, labelValues...,
 			))
 			ch <- prometheus.NewMetricWithTimestamp(vm.Timestamp, prometheus.MustNewConstMetric(
-				c.distributedMemoryEntitlement, prometheus.GaugeValue, vm.DistributedMemoryEntitlement, labelValues...,
+				c.guestMemoryUsage, prometheus.GaugeValue, vm.GuestMemoryUsage, labelValues...,
 			))
 			ch <- prometheus.NewMetricWithTimestamp(vm.Timestamp, prometheus.MustNewConstMetric(
-				c.staticCPUEntitlement, prometheus.GaugeValue, vm.StaticCPUEntitlement, labelValues...,
+				c.hostMemoryUsage, prometheus.GaugeValue, vm.HostMemoryUsage, labelValues...,
+			))
+			if vm.MemoryAllocationShares != 0.0 {
+				ch <- prometheus.NewMetricWithTimestamp(vm.Timestamp, prometheus.MustNewConstMetric(
+					c.memoryAllocationShares, prometheus.GaugeValue, vm.MemoryAllocationShares, labelValues...,
+				))
+			}
+			if vm.MemoryAllocationReservation != 0.0 {
+				ch <- prometheus.NewMetricWithTimestamp(vm.Timestamp, prometheus.MustNewConstMetric(
+					c.memoryAllocationReservation, prometheus.GaugeValue, vm.MemoryAllocationReservation, labelValues...,
+				))
+			}
+			ch <- prometheus.NewMetricWithTimestamp(vm.Timestamp, prometheus.MustNewConstMetric(
+				c.uptimeSeconds, prometheus.GaugeValue, vm.UptimeSeconds, labelValues...,
 			))
 			ch <- prometheus.NewMetricWithTimestamp(vm.Timestamp, prometheus.MustNewConstMetric(
-				c.staticMemoryEntitlement, prometheus.GaugeValue, vm.StaticMemoryEntitlement, labelValues...,
+				c.numSnapshot, prometheus.GaugeValue, float64(len(vm.Snapshot)), labelValues...,
 			))
 			ch <- prometheus.NewMetricWithTimestamp(vm.Timestamp, prometheus.MustNewConstMetric(
-				c.privateMemory, prometheus.GaugeValue, vm.PrivateMemory, labelValues...,
+				c.snapshotTotalSize, prometheus.GaugeValue, vm.SnapshotSize, labelValues...,
 			))
 			ch <- prometheus.NewMetricWithTimestamp(vm.Timestamp, prometheus.MustNewConstMetric(
-				c.sharedMemory, prometheus.GaugeValue, vm.SharedMemory, labelValues...,
+				c.consolidationNeeded, prometheus.GaugeValue, b2f(vm.ConsolidationNeeded), labelValues...,
 			))
+			for _, snap := range vm.Snapshot {
+				snapshotLabelValues := append(slices.Clone(labelValues), snap.ID, snap.Name)
+				ch <- prometheus.NewMetricWithTimestamp(vm.Timestamp, prometheus.MustNewConstMetric(
+					c.snapshotCreateTime, prometheus.GaugeValue, float64(snap.CreationTime.Unix()), snapshotLabelValues...,
+				))
+				ch <- prometheus.NewMetricWithTimestamp(vm.Timestamp, prometheus.MustNewConstMetric(
+					c.snapshotAge, prometheus.GaugeValue, vm.Timestamp.Sub(snap.CreationTime).Seconds(), snapshotLabelValues...,
+				))
+				ch <- prometheus.NewMetricWithTimestamp(vm.Timestamp, prometheus.MustNewConstMetric(
+					c.snapshotSize, prometheus.GaugeValue, snap.Size, snapshotLabelValues...,
+				))
+				ch <- prometheus.NewMetricWithTimestamp(vm.Timestamp, prometheus.MustNewConstMetric(
+					c.snapshotDepth, prometheus.GaugeValue, snap.Depth, snapshotLabelValues...,
+				))
+				ch <- prometheus.NewMetricWithTimestamp(vm.Timestamp, prometheus.MustNewConstMetric(
+					c.snapshotMemory, prometheus.GaugeValue, b2f(snap.Memory), snapshotLabelValues...,
+				))
+			}
+
+			if c.datastoreMetrics {
+				for _, usage := range vm.DatastoreUsage {
+					datastoreLabelValues := append(slices.Clone(labelValues), usage.Datastore.Value, usage.DatastoreName)
+					ch <- prometheus.NewMetricWithTimestamp(vm.Timestamp, prometheus.MustNewConstMetric(
+						c.datastoreInfo, prometheus.GaugeValue, 1, datastoreLabelValues...,
+					))
+					ch <- prometheus.NewMetricWithTimestamp(vm.Timestamp, prometheus.MustNewConstMetric(
+						c.datastoreCommitted, prometheus.GaugeValue, usage.Committed, datastoreLabelValues...,
+					))
+					ch <- prometheus.NewMetricWithTimestamp(vm.Timestamp, prometheus.MustNewConstMetric(
+						c.datastoreUncommitted, prometheus.GaugeValue, usage.Uncommitted, datastoreLabelValues...,
+					))
+					ch <- prometheus.NewMetricWithTimestamp(vm.Timestamp, prometheus.MustNewConstMetric(
+						c.datastoreUnshared, prometheus.GaugeValue, usage.Unshared, datastoreLabelValues...,
+					))
+				}
+			}
+
 			ch <- prometheus.NewMetricWithTimestamp(vm.Timestamp, prometheus.MustNewConstMetric(
-				c.swappedMemory, prometheus.GaugeValue, vm.SwappedMemory, labelValues...,
+				c.powerState, prometheus.GaugeValue, vm.PowerStateFloat64(), labelValues...,
 			))
 			ch <- prometheus.NewMetricWithTimestamp(vm.Timestamp, prometheus.MustNewConstMetric(
-				c.balloonedMemory, prometheus.GaugeValue, vm.BalloonedMemory, labelValues...,
+				c.overallStatus, prometheus.GaugeValue, vm.OverallStatusFloat64(), labelValues...,
 			))
 			ch <- prometheus.NewMetricWithTimestamp(vm.Timestamp, prometheus.MustNewConstMetric(
-				c.consumedOverheadMemory, prometheus.GaugeValue, vm.ConsumedOverheadMemory, labelValues...,
+				c.guestHeartbeatStatus, prometheus.GaugeValue, vm.GuestHeartbeatStateFloat64(), labelValues...,
 			))
 			ch <- prometheus.NewMetricWithTimestamp(vm.Timestamp, prometheus.MustNewConstMetric(
-				c.ftLogBandwidth, prometheus.GaugeValue, vm.FtLogBandwidth, labelValues...,
+				c.toolsStatus, prometheus.GaugeValue, vm.GuestToolsStatusFloat64(), labelValues...,
 			))
+
+			infoLabelValues := append(slices.Clone(labelValues), vm.GuestID, vm.GuestToolsVersion, vm.VApp)
 			ch <- prometheus.NewMetricWithTimestamp(vm.Timestamp, prometheus.MustNewConstMetric(
-				c.ftSecondaryLatency, prometheus.GaugeValue, vm.FtSecondaryLatency, labelValues...,
+				c.vmInfo, prometheus.GaugeValue, 0, infoLabelValues...,
 			))
+
 			ch <- prometheus.NewMetricWithTimestamp(vm.Timestamp, prometheus.MustNewConstMetric(
-				c.compressedMemory, prometheus.GaugeValue, vm.CompressedMemory, labelValues...,
+				c.hostInfo, prometheus.GaugeValue, 1, hostLabelValues...,
 			))
+
+			guestInfoLabelValues := append(slices.Clone(labelValues), vm.GuestHostname, vm.GuestFullName)
 			ch <- prometheus.NewMetricWithTimestamp(vm.Timestamp, prometheus.MustNewConstMetric(
-				c.ssdSwappedMemory, prometheus.GaugeValue, vm.SsdSwappedMemory, labelValues...,
+				c.guestInfo, prometheus.GaugeValue, 1, guestInfoLabelValues...,
 			))
-		}
 
-		// Advanced network metrics
-		if c.advancedNetworkMetrics {
-			for _, net := range vm.GuestNetwork {
-				networkLabelValues := append(slices.Clone(labelValues), net.MacAddress, net.IpAddress)
+			//Legacy metrics
+			if c.legacyMetrics {
 				ch <- prometheus.NewMetricWithTimestamp(vm.Timestamp, prometheus.MustNewConstMetric(
-					c.networkConnected, prometheus.GaugeValue, b2f(net.Connected), networkLabelValues...,
+					c.distributedCPUEntitlement, prometheus.GaugeValue, vm.DistributedCPUEntitlement, labelValues...,
+				))
+				ch <- prometheus.NewMetricWithTimestamp(vm.Timestamp, prometheus.MustNewConstMetric(
+					c.distributedMemoryEntitlement, prometheus.GaugeValue, vm.DistributedMemoryEntitlement, labelValues...,
+				))
+				ch <- prometheus.NewMetricWithTimestamp(vm.Timestamp, prometheus.MustNewConstMetric(
+					c.staticCPUEntitlement, prometheus.GaugeValue, vm.StaticCPUEntitlement, labelValues...,
+				))
+				ch <- prometheus.NewMetricWithTimestamp(vm.Timestamp, prometheus.MustNewConstMetric(
+					c.staticMemoryEntitlement, prometheus.GaugeValue, vm.StaticMemoryEntitlement, labelValues...,
+				))
+				ch <- prometheus.NewMetricWithTimestamp(vm.Timestamp, prometheus.MustNewConstMetric(
+					c.privateMemory, prometheus.GaugeValue, vm.PrivateMemory, labelValues...,
 				))
-			}
-		}
-
-		//Advanced Storage metrics
-		if c.advancedStorageMetrics {
-			for _, disk := range vm.Disk {
-				diskLabelValues := append(slices.Clone(labelValues), disk.UUID, strconv.FormatBool(disk.ThinProvisioned))
 				ch <- prometheus.NewMetricWithTimestamp(vm.Timestamp, prometheus.MustNewConstMetric(
-					c.diskCapacityBytes, prometheus.GaugeValue, float64(disk.Capacity), diskLabelValues...,
+					c.sharedMemory, prometheus.GaugeValue, vm.SharedMemory, labelValues...,
 				))
+				ch <- prometheus.NewMetricWithTimestamp(vm.Timestamp, prometheus.MustNewConstMetric(
+					c.swappedMemory, prometheus.GaugeValue, vm.SwappedMemory, labelValues...,
+				))
+				ch <- prometheus.NewMetricWithTimestamp(vm.Timestamp, prometheus.MustNewConstMetric(
+					c.balloonedMemory, prometheus.GaugeValue, vm.BalloonedMemory, labelValues...,
+				))
+				ch <- prometheus.NewMetricWithTimestamp(vm.Timestamp, prometheus.MustNewConstMetric(
+					c.consumedOverheadMemory, prometheus.GaugeValue, vm.ConsumedOverheadMemory, labelValues...,
+				))
+				ch <- prometheus.NewMetricWithTimestamp(vm.Timestamp, prometheus.MustNewConstMetric(
+					c.ftLogBandwidth, prometheus.GaugeValue, vm.FtLogBandwidth, labelValues...,
+				))
+				ch <- prometheus.NewMetricWithTimestamp(vm.Timestamp, prometheus.MustNewConstMetric(
+					c.ftSecondaryLatency, prometheus.GaugeValue, vm.FtSecondaryLatency, labelValues...,
+				))
+				ch <- prometheus.NewMetricWithTimestamp(vm.Timestamp, prometheus.MustNewConstMetric(
+					c.compressedMemory, prometheus.GaugeValue, vm.CompressedMemory, labelValues...,
+				))
+				ch <- prometheus.NewMetricWithTimestamp(vm.Timestamp, prometheus.MustNewConstMetric(
+					c.ssdSwappedMemory, prometheus.GaugeValue, vm.SsdSwappedMemory, labelValues...,
+				))
+			}
+
+			// Advanced network metrics
+			if c.advancedNetworkMetrics {
+				for _, nic := range vm.NIC {
+					nicLabelValues := append(slices.Clone(labelValues), nic.MacAddress, nic.Label)
+					nicInfoLabelValues := append(slices.Clone(nicLabelValues), nic.AdapterType, nic.BackingType, nic.Network, nic.NetworkID, nic.DVSUUID, nic.PortKey)
+					ch <- prometheus.NewMetricWithTimestamp(vm.Timestamp, prometheus.MustNewConstMetric(
+						c.nicInfo, prometheus.GaugeValue, 1, nicInfoLabelValues...,
+					))
+					ch <- prometheus.NewMetricWithTimestamp(vm.Timestamp, prometheus.MustNewConstMetric(
+						c.nicConnected, prometheus.GaugeValue, b2f(nic.Connected), nicLabelValues...,
+					))
+					ch <- prometheus.NewMetricWithTimestamp(vm.Timestamp, prometheus.MustNewConstMetric(
+						c.nicStartConnected, prometheus.GaugeValue, b2f(nic.StartConnected), nicLabelValues...,
+					))
+					for _, ip := range nic.IpAddress {
+						networkLabelValues := append(slices.Clone(labelValues), nic.MacAddress, ip.Address)
+						ch <- prometheus.NewMetricWithTimestamp(vm.Timestamp, prometheus.MustNewConstMetric(
+							c.networkConnected, prometheus.GaugeValue, b2f(nic.Connected), networkLabelValues...,
+						))
+						nicIpLabelValues := append(slices.Clone(nicLabelValues), ip.Address, strconv.Itoa(int(ip.PrefixLength)), ip.Family)
+						ch <- prometheus.NewMetricWithTimestamp(vm.Timestamp, prometheus.MustNewConstMetric(
+							c.nicIpInfo, prometheus.GaugeValue, 1, nicIpLabelValues...,
+						))
+					}
+				}
+			}
+
+			//Advanced Storage metrics
+			if c.advancedStorageMetrics {
+				for _, disk := range vm.Disk {
+					diskLabelValues := append(slices.Clone(labelValues), disk.UUID, strconv.FormatBool(disk.ThinProvisioned))
+					ch <- prometheus.NewMetricWithTimestamp(vm.Timestamp, prometheus.MustNewConstMetric(
+						c.diskCapacityBytes, prometheus.GaugeValue, float64(disk.Capacity), diskLabelValues...,
+					))
+					diskInfoLabelValues := append(slices.Clone(labelValues),
+						disk.UUID, disk.Label, disk.BackingType, disk.DiskMode, strconv.FormatBool(disk.ThinProvisioned),
+						disk.ControllerType, strconv.Itoa(int(disk.BusNumber)), strconv.Itoa(int(disk.UnitNumber)),
+						disk.DatastoreName, disk.VMDKFile,
+					)
+					ch <- prometheus.NewMetricWithTimestamp(vm.Timestamp, prometheus.MustNewConstMetric(
+						c.diskInfo, prometheus.GaugeValue, 1, diskInfoLabelValues...,
+					))
+				}
+			}
+
+			// Guest filesystem metrics
+			if c.guestDiskMetrics {
+				for _, disk := range vm.GuestDisk {
+					guestDiskLabelValues := append(slices.Clone(labelValues), disk.DiskPath, disk.FilesystemType)
+					ch <- prometheus.NewMetricWithTimestamp(vm.Timestamp, prometheus.MustNewConstMetric(
+						c.guestDiskCapacity, prometheus.GaugeValue, disk.Capacity, guestDiskLabelValues...,
+					))
+					ch <- prometheus.NewMetricWithTimestamp(vm.Timestamp, prometheus.MustNewConstMetric(
+						c.guestDiskFreeSpace, prometheus.GaugeValue, disk.FreeSpace, guestDiskLabelValues...,
+					))
+				}
 			}
 		}
 	}
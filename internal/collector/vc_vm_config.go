@@ -0,0 +1,181 @@
+package collector
+
+import (
+	"context"
+	"slices"
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sanderdescamps/govc_exporter/internal/config"
+	"github.com/sanderdescamps/govc_exporter/internal/scraper"
+)
+
+const (
+	virtualMachineConfigCollectorSubsystem = "vm_config"
+)
+
+type virtualMachineConfigCollector struct {
+	scraper          *scraper.VCenterScraper
+	rules            []config.VMConfigRule
+	objectLabels     objectLabeler
+	folderPathLabel  bool
+	annotationParser annotationParser
+
+	info                     *prometheus.Desc
+	hardwareVersion          *prometheus.Desc
+	cpuHotAdd                *prometheus.Desc
+	memoryHotAdd             *prometheus.Desc
+	secureBoot               *prometheus.Desc
+	vtpm                     *prometheus.Desc
+	encrypted                *prometheus.Desc
+	cpuLimit                 *prometheus.Desc
+	memoryLimit              *prometheus.Desc
+	removableDeviceConnected *prometheus.Desc
+	compliant                *prometheus.Desc
+}
+
+func NewVirtualMachineConfigCollector(scraper *scraper.VCenterScraper, cConf config.CollectorConfig) *virtualMachineConfigCollector {
+	labels := []string{"uuid", "name", "template", "vm_id", "pool"}
+	if cConf.FolderPathLabel {
+		labels = append(labels, "folder_path")
+	}
+	objectLabels := newObjectLabeler(cConf.VMTagLabels, cConf.VMAttributeLabels, cConf)
+	labels = objectLabels.Labels(labels)
+	annotationParser := newAnnotationParser(cConf)
+	labels = append(labels, annotationParser.Labels()...)
+
+	infoLabels := append(slices.Clone(labels), "hardware_version", "tools_upgrade_policy", "firmware", "latency_sensitivity")
+	deviceLabels := append(slices.Clone(labels), "device_type", "device_label", "image")
+	ruleLabels := append(slices.Clone(labels), "rule", "setting")
+
+	// Rules are validated together with the rest of the config
+	rules, _ := config.ParseVMConfigRules(cConf.VMConfigRules)
+
+	return &virtualMachineConfigCollector{
+		scraper:          scraper,
+		rules:            rules,
+		objectLabels:     objectLabels,
+		folderPathLabel:  cConf.FolderPathLabel,
+		annotationParser: annotationParser,
+		info: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, virtualMachineConfigCollectorSubsystem, "info"),
+			"vm configuration settings", infoLabels, nil),
+		hardwareVersion: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, virtualMachineConfigCollectorSubsystem, "hardware_version"),
+			"vm hardware version", labels, nil),
+		cpuHotAdd: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, virtualMachineConfigCollectorSubsystem, "cpu_hot_add_enabled"),
+			"cpu hot add is enabled", labels, nil),
+		memoryHotAdd: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, virtualMachineConfigCollectorSubsystem, "memory_hot_add_enabled"),
+			"memory hot add is enabled", labels, nil),
+		secureBoot: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, virtualMachineConfigCollectorSubsystem, "secure_boot_enabled"),
+			"EFI secure boot is enabled", labels, nil),
+		vtpm: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, virtualMachineConfigCollectorSubsystem, "vtpm_present"),
+			"vm has a virtual TPM", labels, nil),
+		encrypted: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, virtualMachineConfigCollectorSubsystem, "encrypted"),
+			"vm is encrypted", labels, nil),
+		cpuLimit: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, virtualMachineConfigCollectorSubsystem, "cpu_limit_mhz"),
+			"vm cpu limit in MHz, -1 means unlimited", labels, nil),
+		memoryLimit: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, virtualMachineConfigCollectorSubsystem, "memory_limit_bytes"),
+			"vm memory limit in bytes, -1 means unlimited", labels, nil),
+		removableDeviceConnected: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, virtualMachineConfigCollectorSubsystem, "removable_device_connected"),
+			"cd-rom or floppy drive is connected", deviceLabels, nil),
+		compliant: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, virtualMachineConfigCollectorSubsystem, "compliant"),
+			"vm complies with the configured rule", ruleLabels, nil),
+	}
+}
+
+func (c *virtualMachineConfigCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.info
+	ch <- c.hardwareVersion
+	ch <- c.cpuHotAdd
+	ch <- c.memoryHotAdd
+	ch <- c.secureBoot
+	ch <- c.vtpm
+	ch <- c.encrypted
+	ch <- c.cpuLimit
+	ch <- c.memoryLimit
+	ch <- c.removableDeviceConnected
+	ch <- c.compliant
+}
+
+func (c *virtualMachineConfigCollector) Collect(ch chan<- prometheus.Metric) {
+	if !c.scraper.VM.Enabled() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), COLLECT_TIMEOUT)
+	defer cancel()
+
+	vms, err := c.scraper.DB.GetAllVM(ctx)
+	if err != nil && Logger != nil {
+		Logger.Error("failed to get vm's", "err", err)
+	}
+	for _, vm := range vms {
+		settings := vm.ConfigSettings
+		if settings == nil {
+			continue
+		}
+
+		annotationLabelValues := c.annotationParser.LabelValues(vm.Annotation)
+
+		objectTags := c.scraper.DB.GetTags(ctx, vm.Self)
+		objectAttributes := c.scraper.DB.GetAttributes(ctx, vm.Self)
+		baseLabelValues := []string{vm.UUID, vm.Name, strconv.FormatBool(vm.Template), vm.Self.Value, vm.ResourcePool}
+		if c.folderPathLabel {
+			baseLabelValues = append(baseLabelValues, vm.FolderPath)
+		}
+		for _, labelValues := range c.objectLabels.LabelValues(baseLabelValues, objectTags, objectAttributes) {
+			labelValues = append(labelValues, annotationLabelValues...)
+
+			infoLabelValues := append(slices.Clone(labelValues), settings.HardwareVersion, settings.ToolsUpgradePolicy, settings.Firmware, settings.LatencySensitivity)
+			ch <- prometheus.NewMetricWithTimestamp(vm.Timestamp, prometheus.MustNewConstMetric(
+				c.info, prometheus.GaugeValue, 1, infoLabelValues...,
+			))
+			ch <- prometheus.NewMetricWithTimestamp(vm.Timestamp, prometheus.MustNewConstMetric(
+				c.hardwareVersion, prometheus.GaugeValue, settings.HardwareVersionFloat64(), labelValues...,
+			))
+			ch <- prometheus.NewMetricWithTimestamp(vm.Timestamp, prometheus.MustNewConstMetric(
+				c.cpuHotAdd, prometheus.GaugeValue, b2f(settings.CPUHotAdd), labelValues...,
+			))
+			ch <- prometheus.NewMetricWithTimestamp(vm.Timestamp, prometheus.MustNewConstMetric(
+				c.memoryHotAdd, prometheus.GaugeValue, b2f(settings.MemoryHotAdd), labelValues...,
+			))
+			ch <- prometheus.NewMetricWithTimestamp(vm.Timestamp, prometheus.MustNewConstMetric(
+				c.secureBoot, prometheus.GaugeValue, b2f(settings.SecureBoot), labelValues...,
+			))
+			ch <- prometheus.NewMetricWithTimestamp(vm.Timestamp, prometheus.MustNewConstMetric(
+				c.vtpm, prometheus.GaugeValue, b2f(settings.VTPM), labelValues...,
+			))
+			ch <- prometheus.NewMetricWithTimestamp(vm.Timestamp, prometheus.MustNewConstMetric(
+				c.encrypted, prometheus.GaugeValue, b2f(settings.Encrypted), labelValues...,
+			))
+			ch <- prometheus.NewMetricWithTimestamp(vm.Timestamp, prometheus.MustNewConstMetric(
+				c.cpuLimit, prometheus.GaugeValue, settings.CPULimit, labelValues...,
+			))
+			ch <- prometheus.NewMetricWithTimestamp(vm.Timestamp, prometheus.MustNewConstMetric(
+				c.memoryLimit, prometheus.GaugeValue, settings.MemoryLimit, labelValues...,
+			))
+			for _, device := range settings.RemovableDevices {
+				deviceLabelValues := append(slices.Clone(labelValues), device.Type, device.Label, device.Image)
+				ch <- prometheus.NewMetricWithTimestamp(vm.Timestamp, prometheus.MustNewConstMetric(
+					c.removableDeviceConnected, prometheus.GaugeValue, b2f(device.Connected), deviceLabelValues...,
+				))
+			}
+			for _, rule := range c.rules {
+				ruleLabelValues := append(slices.Clone(labelValues), rule.Rule, rule.Setting)
+				ch <- prometheus.NewMetricWithTimestamp(vm.Timestamp, prometheus.MustNewConstMetric(
+					c.compliant, prometheus.GaugeValue, b2f(rule.Evaluate(settings.Setting(rule.Setting))), ruleLabelValues...,
+				))
+			}
+		}
+	}
+}
@@ -12,24 +12,31 @@ import (
 )
 
 type VMPerfCollector struct {
-	scraper     *scraper.VCenterScraper
-	extraLabels []string
+	scraper          *scraper.VCenterScraper
+	objectLabels     objectLabeler
+	folderPathLabel  bool
+	annotationParser annotationParser
 
 	perfMetric *prometheus.Desc
 }
 
 func NewVMPerfCollector(scraper *scraper.VCenterScraper, cConf config.CollectorConfig) *VMPerfCollector {
 	labels := []string{"uuid", "name", "template", "vm_id"}
-	extraLabels := cConf.VMTagLabels
-	if len(extraLabels) != 0 {
-		labels = append(labels, extraLabels...)
+	if cConf.FolderPathLabel {
+		labels = append(labels, "folder_path")
 	}
+	objectLabels := newObjectLabeler(cConf.VMTagLabels, cConf.VMAttributeLabels, cConf)
+	labels = objectLabels.Labels(labels)
+	annotationParser := newAnnotationParser(cConf)
+	labels = append(labels, annotationParser.Labels()...)
 
 	perfLabels := append(slices.Clone(labels), "kind", "instance", "unit")
 
 	return &VMPerfCollector{
-		scraper:     scraper,
-		extraLabels: extraLabels,
+		scraper:          scraper,
+		objectLabels:     objectLabels,
+		folderPathLabel:  cConf.FolderPathLabel,
+		annotationParser: annotationParser,
 		perfMetric: prometheus.NewDesc(
 			prometheus.BuildFQName(namespace, virtualMachineCollectorSubsystem, "perf_metric"),
 			"Performance metric", perfLabels, nil),
@@ -54,20 +61,27 @@ func (c *VMPerfCollector) Collect(ch chan<- prometheus.Metric) {
 		Logger.Error("failed to get vm's", "err", err)
 	}
 	for _, vm := range vms {
-		extraLabelValues := []string{}
+		// metrics are popped from the db, so they need to be fetched before
+		// they are exported for every set of tag labels
+		metrics := slices.Collect(c.scraper.MetricsDB.PopAllVmMetricsIter(ctx, vm.Self))
+
+		annotationLabelValues := c.annotationParser.LabelValues(vm.Annotation)
+
 		objectTags := c.scraper.DB.GetTags(ctx, vm.Self)
-		for _, tagCat := range c.extraLabels {
-			extraLabelValues = append(extraLabelValues, objectTags.GetTag(tagCat))
+		objectAttributes := c.scraper.DB.GetAttributes(ctx, vm.Self)
+		baseLabelValues := []string{vm.UUID, vm.Name, strconv.FormatBool(vm.Template), vm.Self.ID()}
+		if c.folderPathLabel {
+			baseLabelValues = append(baseLabelValues, vm.FolderPath)
 		}
+		for _, labelValues := range c.objectLabels.LabelValues(baseLabelValues, objectTags, objectAttributes) {
+			labelValues = append(labelValues, annotationLabelValues...)
 
-		labelValues := []string{vm.UUID, vm.Name, strconv.FormatBool(vm.Template), vm.Self.ID()}
-		labelValues = append(labelValues, extraLabelValues...)
-
-		for metric := range c.scraper.MetricsDB.PopAllVmMetricsIter(ctx, vm.Self) {
-			perfMetricLabelValues := append(slices.Clone(labelValues), metric.Name, metric.Instance, metric.Unit)
-			ch <- prometheus.NewMetricWithTimestamp(metric.Timestamp, prometheus.MustNewConstMetric(
-				c.perfMetric, prometheus.GaugeValue, metric.Value, perfMetricLabelValues...,
-			))
+			for _, metric := range metrics {
+				perfMetricLabelValues := append(slices.Clone(labelValues), metric.Name, metric.Instance, metric.Unit)
+				ch <- prometheus.NewMetricWithTimestamp(metric.Timestamp, prometheus.MustNewConstMetric(
+					c.perfMetric, prometheus.GaugeValue, metric.Value, perfMetricLabelValues...,
+				))
+			}
 		}
 	}
 }
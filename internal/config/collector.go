@@ -1,46 +1,119 @@
 package config
 
-import "fmt"
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+const (
+	// All tags of a category are joined in a single label value
+	TagLabelModeJoin = "join"
+	// One series is exported per tag of a category
+	TagLabelModeSeries = "series"
+	// Tags are not added as label but exported in a separate govc_tag_info metric
+	TagLabelModeInfo = "info"
+)
+
+const (
+	AnnotationFormatNone  = "none"
+	AnnotationFormatKV    = "kv"
+	AnnotationFormatJSON  = "json"
+	AnnotationFormatYAML  = "yaml"
+	AnnotationFormatRegex = "regex"
+)
 
 type CollectorConfig struct {
-	UseIsecSpecifics       bool
 	DisableExporterMetrics bool
 
 	MaxRequests int
 
+	TagLabelMode      string
+	TagLabelSeparator string
+
+	AnnotationFormat       string
+	AnnotationRegex        string
+	AnnotationLabels       []string
+	AnnotationDefaultValue string
+
+	FolderPathLabel bool
+
 	ClusterTagLabels      []string
 	DatastoreTagLabels    []string
 	HostTagLabels         []string
 	ResourcePoolTagLabels []string
 	StoragePodTagLabels   []string
+	VirtualAppTagLabels   []string
+
+	ClusterAttributeLabels      []string
+	DatastoreAttributeLabels    []string
+	HostAttributeLabels         []string
+	ResourcePoolAttributeLabels []string
+	StoragePodAttributeLabels   []string
+	VirtualAppAttributeLabels   []string
 
 	VMLegacyMetrics          bool
 	VMAdvancedNetworkMetrics bool
 	VMAdvancedStorageMetrics bool
+	VMGuestDiskMetrics       bool
+	VMDatastoreMetrics       bool
+	VMConfigRules            []string
 	VMTagLabels              []string
+	VMAttributeLabels        []string
 
-	HostStorageMetrics bool
+	HostStorageMetrics  bool
+	HostHardwareMetrics bool
+	HostNetworkMetrics  bool
+
+	// One govc_ds_vm_info series per vm and datastore
+	DatastoreVMInfo bool
 }
 
 func DefaultCollectorConf() CollectorConfig {
 	return CollectorConfig{
-		UseIsecSpecifics:       false,
 		DisableExporterMetrics: false,
 
 		MaxRequests: 10,
 
+		TagLabelMode:      TagLabelModeJoin,
+		TagLabelSeparator: ",",
+
+		AnnotationFormat:       AnnotationFormatNone,
+		AnnotationRegex:        "",
+		AnnotationLabels:       []string{},
+		AnnotationDefaultValue: "",
+
+		FolderPathLabel: false,
+
 		ClusterTagLabels:      []string{},
 		DatastoreTagLabels:    []string{},
 		HostTagLabels:         []string{},
 		ResourcePoolTagLabels: []string{},
 		StoragePodTagLabels:   []string{},
+		VirtualAppTagLabels:   []string{},
+
+		ClusterAttributeLabels:      []string{},
+		DatastoreAttributeLabels:    []string{},
+		HostAttributeLabels:         []string{},
+		ResourcePoolAttributeLabels: []string{},
+		StoragePodAttributeLabels:   []string{},
+		VirtualAppAttributeLabels:   []string{},
 
 		VMLegacyMetrics:          false,
 		VMAdvancedNetworkMetrics: false,
 		VMAdvancedStorageMetrics: false,
+		VMGuestDiskMetrics:       false,
+		VMDatastoreMetrics:       false,
+		VMConfigRules:            []string{},
 		VMTagLabels:              []string{},
+		VMAttributeLabels:        []string{},
 
-		HostStorageMetrics: false,
+		HostStorageMetrics:  false,
+		HostHardwareMetrics: false,
+		HostNetworkMetrics:  false,
+
+		DatastoreVMInfo: false,
 	}
 }
 
@@ -48,5 +121,50 @@ func (c CollectorConfig) Validate() error {
 	if c.MaxRequests <= 0 {
 		return fmt.Errorf("MaxRequests cannot be smaller than 1")
 	}
+	if !slices.Contains([]string{TagLabelModeJoin, TagLabelModeSeries, TagLabelModeInfo}, c.TagLabelMode) {
+		return fmt.Errorf("invalid TagLabelMode %q", c.TagLabelMode)
+	}
+	if !slices.Contains([]string{AnnotationFormatNone, AnnotationFormatKV, AnnotationFormatJSON, AnnotationFormatYAML, AnnotationFormatRegex}, c.AnnotationFormat) {
+		return fmt.Errorf("invalid AnnotationFormat %q", c.AnnotationFormat)
+	}
+	if c.AnnotationFormat == AnnotationFormatRegex {
+		re, err := regexp.Compile(c.AnnotationRegex)
+		if err != nil {
+			return fmt.Errorf("invalid AnnotationRegex: %v", err)
+		}
+		for _, key := range c.AnnotationKeys() {
+			if re.SubexpIndex(key) < 0 {
+				return fmt.Errorf("AnnotationRegex has no named group %q", key)
+			}
+		}
+	}
+	if _, err := ParseVMConfigRules(c.VMConfigRules); err != nil {
+		return fmt.Errorf("invalid VMConfigRules: %v", err)
+	}
 	return nil
 }
+
+// AnnotationKeys returns the keys which need to be extracted from the
+// annotation. Every AnnotationLabels entry is either "key" or "key=label".
+func (c CollectorConfig) AnnotationKeys() []string {
+	keys := []string{}
+	for _, l := range c.AnnotationLabels {
+		key, _, _ := strings.Cut(l, "=")
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// AnnotationLabelNames returns the label names for the keys returned by
+// AnnotationKeys
+func (c CollectorConfig) AnnotationLabelNames() []string {
+	labels := []string{}
+	for _, l := range c.AnnotationLabels {
+		key, label, found := strings.Cut(l, "=")
+		if !found {
+			label = key
+		}
+		labels = append(labels, label)
+	}
+	return labels
+}
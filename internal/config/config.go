@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/prometheus/common/promslog"
+	"github.com/sanderdescamps/govc_exporter/internal/helper"
 )
 
 type Config struct {
@@ -37,6 +38,27 @@ func (c Config) Validate() error {
 	return nil
 }
 
+// TagCategoriesToCollect returns the tag categories the tags sensor has to
+// collect: the categories exported as labels and the categories used in the
+// selectors of the desired host options.
+func (c Config) TagCategoriesToCollect() []string {
+	categories := helper.Union(
+		c.CollectorConfig.ClusterTagLabels,
+		c.CollectorConfig.DatastoreTagLabels,
+		c.CollectorConfig.HostTagLabels,
+		c.CollectorConfig.ResourcePoolTagLabels,
+		c.CollectorConfig.StoragePodTagLabels,
+		c.CollectorConfig.VirtualAppTagLabels,
+		c.CollectorConfig.VMTagLabels,
+	)
+	if c.ScraperConfig.HostOptions.Enabled {
+		// Invalid rules are reported by Validate
+		rules, _ := c.ScraperConfig.HostOptions.ParseDesiredOptions()
+		categories = helper.Union(categories, HostOptionTagCategories(rules))
+	}
+	return categories
+}
+
 func DefaultConfig() Config {
 	return Config{
 		ScraperConfig:      DefaultScraperConfig(),
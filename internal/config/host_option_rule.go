@@ -0,0 +1,117 @@
+package config
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// HostOptionRule describes the desired value of an ESXi advanced option for
+// the hosts matching the selector. Rules are written as
+// "[<selector>;]<option>=<value>" where the selector is one of
+//
+//	cluster:<cluster name>
+//	tag:<category>=<tag>
+//
+// Rules without selector apply to all hosts, ex.
+// "cluster:prod;Disk.MaxLUN=1024" or "UserVars.SuppressShellWarning=1"
+type HostOptionRule struct {
+	Rule        string
+	Cluster     string
+	TagCategory string
+	Tag         string
+	Option      string
+	Value       string
+}
+
+func ParseHostOptionRule(rule string) (HostOptionRule, error) {
+	result := HostOptionRule{Rule: rule}
+
+	option := rule
+	if selector, o, found := strings.Cut(rule, ";"); found {
+		option = o
+		kind, value, _ := strings.Cut(selector, ":")
+		switch strings.TrimSpace(kind) {
+		case "cluster":
+			result.Cluster = strings.TrimSpace(value)
+		case "tag":
+			category, tag, found := strings.Cut(value, "=")
+			if !found {
+				return HostOptionRule{}, fmt.Errorf("tag selector must be tag:<category>=<tag> in rule %q", rule)
+			}
+			result.TagCategory = strings.TrimSpace(category)
+			result.Tag = strings.TrimSpace(tag)
+		default:
+			return HostOptionRule{}, fmt.Errorf("unknown selector %q in rule %q", selector, rule)
+		}
+	}
+
+	key, value, found := strings.Cut(option, "=")
+	if !found || strings.TrimSpace(key) == "" {
+		return HostOptionRule{}, fmt.Errorf("rule %q must be formatted as [<selector>;]<option>=<value>", rule)
+	}
+	result.Option = strings.TrimSpace(key)
+	result.Value = strings.TrimSpace(value)
+	return result, nil
+}
+
+func ParseHostOptionRules(rules []string) ([]HostOptionRule, error) {
+	result := []HostOptionRule{}
+	for _, rule := range rules {
+		r, err := ParseHostOptionRule(rule)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, r)
+	}
+	return result, nil
+}
+
+// HostOptionTagCategories returns the tag categories used in the selectors of
+// the rules
+func HostOptionTagCategories(rules []HostOptionRule) []string {
+	result := []string{}
+	for _, r := range rules {
+		if r.TagCategory != "" && !slices.Contains(result, r.TagCategory) {
+			result = append(result, r.TagCategory)
+		}
+	}
+	return result
+}
+
+// Match checks if the rule applies to a host in the given cluster with the
+// given tags (category => tag names)
+func (r HostOptionRule) Match(cluster string, tags map[string][]string) bool {
+	if r.Cluster != "" && r.Cluster != cluster {
+		return false
+	}
+	if r.TagCategory != "" && !slices.Contains(tags[r.TagCategory], r.Tag) {
+		return false
+	}
+	return true
+}
+
+// Evaluate checks if the actual value equals the desired value. Values are
+// compared as numbers when both sides are numeric, otherwise the comparison
+// is case insensitive.
+func (r HostOptionRule) Evaluate(actual string) bool {
+	a, errA := strconv.ParseFloat(actual, 64)
+	v, errV := strconv.ParseFloat(r.Value, 64)
+	if errA == nil && errV == nil {
+		return a == v
+	}
+	return strings.EqualFold(actual, r.Value)
+}
+
+// DesiredHostOptions returns the desired value of every option for a host.
+// When multiple rules set the same option, the last matching rule wins.
+func DesiredHostOptions(rules []HostOptionRule, cluster string, tags map[string][]string) map[string]HostOptionRule {
+	result := map[string]HostOptionRule{}
+	for _, r := range rules {
+		if r.Match(cluster, tags) {
+			result[r.Option] = r
+		}
+	}
+	return result
+}
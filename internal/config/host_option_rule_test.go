@@ -0,0 +1,59 @@
+package config
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestHostOptionRule(t *testing.T) {
+	rules, err := ParseHostOptionRules([]string{
+		"Disk.MaxLUN=1024",
+		"cluster:prod;Disk.MaxLUN=256",
+		"tag:env=dev;UserVars.SuppressShellWarning=1",
+	})
+	if err != nil {
+		t.Fatalf("failed to parse rules: %v", err)
+	}
+
+	desired := DesiredHostOptions(rules, "prod", map[string][]string{"env": {"prod"}})
+	if len(desired) != 1 || desired["Disk.MaxLUN"].Value != "256" {
+		t.Errorf("unexpected desired options for prod: %v", desired)
+	}
+
+	desired = DesiredHostOptions(rules, "test", map[string][]string{"env": {"dev"}})
+	if len(desired) != 2 || desired["Disk.MaxLUN"].Value != "1024" {
+		t.Errorf("unexpected desired options for test: %v", desired)
+	}
+	if rule := desired["UserVars.SuppressShellWarning"]; !rule.Evaluate("1") || rule.Evaluate("0") {
+		t.Errorf("unexpected evaluation of rule %q", rule.Rule)
+	}
+	if rule := desired["Disk.MaxLUN"]; !rule.Evaluate("1024.0") || rule.Evaluate("") {
+		t.Errorf("unexpected evaluation of rule %q", rule.Rule)
+	}
+
+	for _, invalid := range []string{"Disk.MaxLUN", "host:esx1;Disk.MaxLUN=1", "tag:env;Disk.MaxLUN=1"} {
+		if _, err := ParseHostOptionRule(invalid); err == nil {
+			t.Errorf("expected error for rule %q", invalid)
+		}
+	}
+}
+
+func TestTagCategoriesToCollect(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.CollectorConfig.VMTagLabels = []string{"owner"}
+	cfg.ScraperConfig.HostOptions.Enabled = true
+	cfg.ScraperConfig.HostOptions.DesiredOptions = []string{
+		"tag:env=dev;UserVars.SuppressShellWarning=1",
+		"cluster:prod;Disk.MaxLUN=256",
+	}
+
+	categories := cfg.TagCategoriesToCollect()
+	if len(categories) != 2 || !slices.Contains(categories, "owner") || !slices.Contains(categories, "env") {
+		t.Errorf("unexpected tag categories: %v", categories)
+	}
+
+	cfg.ScraperConfig.HostOptions.Enabled = false
+	if categories := cfg.TagCategoriesToCollect(); len(categories) != 1 || categories[0] != "owner" {
+		t.Errorf("unexpected tag categories without host options: %v", categories)
+	}
+}
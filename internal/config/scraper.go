@@ -26,15 +26,24 @@ type ScraperConfig struct {
 
 	Cluster            SensorConfig
 	ComputeResource    SensorConfig
+	ContentLibrary     SensorConfig
 	Datastore          SensorConfig
 	Datacenter         SensorConfig
 	Folder             SensorConfig
-	Host               SensorConfig
+	License            SensorConfig
+	OrphanedVMDK       SensorConfig
+	Host               HostSensorConfig
 	HostPerf           PerfSensorConfig
+	HostSecurity       SensorConfig
+	HostOptions        HostOptionsSensorConfig
 	ResourcePool       SensorConfig
 	Spod               SensorConfig
+	StoragePolicy      SensorConfig
+	ServiceInstance    SensorConfig
 	Tags               TagsSensorConfig
-	VirtualMachine     SensorConfig
+	VirtualApp         SensorConfig
+	CustomAttributes   CustomAttributesSensorConfig
+	VirtualMachine     VirtualMachineSensorConfig
 	VirtualMachinePerf PerfSensorConfig
 	// CleanInterval  time.Duration
 	ClientPoolSize int
@@ -195,6 +204,36 @@ type TagsSensorConfig struct {
 	CategoryToCollect []string
 }
 
+type CustomAttributesSensorConfig struct {
+	SensorConfig
+	AttributesToCollect []string
+}
+
+type VirtualMachineSensorConfig struct {
+	SensorConfig
+	// Extract the configuration settings used for compliance checks
+	ConfigSettings bool
+}
+
+type HostSensorConfig struct {
+	SensorConfig
+	// Retrieve the pci passthrough and graphics info for the hardware inventory
+	Hardware bool
+	// Retrieve the full network config (vswitches, portgroups, vmkernel
+	// adapters) instead of only the physical nics
+	Network bool
+}
+
+type HostOptionsSensorConfig struct {
+	SensorConfig
+	// Desired advanced options, see HostOptionRule
+	DesiredOptions []string
+}
+
+func (c HostOptionsSensorConfig) ParseDesiredOptions() ([]HostOptionRule, error) {
+	return ParseHostOptionRules(c.DesiredOptions)
+}
+
 func DefaultScraperConfig() ScraperConfig {
 	return ScraperConfig{
 		Cluster: SensorConfig{
@@ -212,12 +251,34 @@ func DefaultScraperConfig() ScraperConfig {
 			MaxAge:          120 * time.Second,
 			RefreshInterval: 30 * time.Second,
 		},
-		Host: SensorConfig{
+		Host: HostSensorConfig{
+			SensorConfig: SensorConfig{
+				Enabled:         true,
+				MaxAge:          120 * time.Second,
+				RefreshInterval: 30 * time.Second,
+			},
+			Hardware: false,
+			Network:  false,
+		},
+		HostSecurity: SensorConfig{
+			Enabled:         false,
+			MaxAge:          600 * time.Second,
+			RefreshInterval: 290 * time.Second,
+		},
+		HostOptions: HostOptionsSensorConfig{
+			SensorConfig: SensorConfig{
+				Enabled:         false,
+				MaxAge:          600 * time.Second,
+				RefreshInterval: 290 * time.Second,
+			},
+			DesiredOptions: []string{},
+		},
+		ResourcePool: SensorConfig{
 			Enabled:         true,
 			MaxAge:          120 * time.Second,
-			RefreshInterval: 30 * time.Second,
+			RefreshInterval: 60 * time.Second,
 		},
-		ResourcePool: SensorConfig{
+		VirtualApp: SensorConfig{
 			Enabled:         true,
 			MaxAge:          120 * time.Second,
 			RefreshInterval: 60 * time.Second,
@@ -232,11 +293,36 @@ func DefaultScraperConfig() ScraperConfig {
 			MaxAge:          120 * time.Second,
 			RefreshInterval: 60 * time.Second,
 		},
+		ContentLibrary: SensorConfig{
+			Enabled:         false,
+			MaxAge:          600 * time.Second,
+			RefreshInterval: 290 * time.Second,
+		},
+		License: SensorConfig{
+			Enabled:         true,
+			MaxAge:          600 * time.Second,
+			RefreshInterval: 290 * time.Second,
+		},
+		OrphanedVMDK: SensorConfig{
+			Enabled:         false,
+			MaxAge:          13 * time.Hour,
+			RefreshInterval: 6 * time.Hour,
+		},
 		Spod: SensorConfig{
 			Enabled:         true,
 			MaxAge:          120 * time.Second,
 			RefreshInterval: 60 * time.Second,
 		},
+		StoragePolicy: SensorConfig{
+			Enabled:         false,
+			MaxAge:          600 * time.Second,
+			RefreshInterval: 290 * time.Second,
+		},
+		ServiceInstance: SensorConfig{
+			Enabled:         true,
+			MaxAge:          120 * time.Second,
+			RefreshInterval: 55 * time.Second,
+		},
 		Tags: TagsSensorConfig{
 			SensorConfig: SensorConfig{
 				Enabled:         true,
@@ -245,10 +331,21 @@ func DefaultScraperConfig() ScraperConfig {
 			},
 			CategoryToCollect: []string{},
 		},
-		VirtualMachine: SensorConfig{
-			Enabled:         true,
-			MaxAge:          120 * time.Second,
-			RefreshInterval: 60 * time.Second,
+		CustomAttributes: CustomAttributesSensorConfig{
+			SensorConfig: SensorConfig{
+				Enabled:         true,
+				MaxAge:          600 * time.Second,
+				RefreshInterval: 290 * time.Second,
+			},
+			AttributesToCollect: []string{},
+		},
+		VirtualMachine: VirtualMachineSensorConfig{
+			SensorConfig: SensorConfig{
+				Enabled:         true,
+				MaxAge:          120 * time.Second,
+				RefreshInterval: 60 * time.Second,
+			},
+			ConfigSettings: false,
 		},
 		HostPerf: PerfSensorConfig{
 			Enabled:         true,
@@ -298,6 +395,30 @@ VirtualMachineScraperEnabled is enabled because scraper needs the hosts
 when it queries the vm's`)
 	}
 
+	if !c.Host.Enabled && c.HostSecurity.Enabled {
+		return fmt.Errorf(`HostSensor must be enabled when 
+HostSecuritySensor is enabled because scraper needs the hosts 
+when it queries the security settings`)
+	}
+
+	if !c.Host.Enabled && c.HostOptions.Enabled {
+		return fmt.Errorf(`HostSensor must be enabled when 
+HostOptionsSensor is enabled because scraper needs the hosts 
+when it queries the advanced options`)
+	}
+
+	if (!c.Datastore.Enabled || !c.VirtualMachine.Enabled) && c.OrphanedVMDK.Enabled {
+		return fmt.Errorf(`DatastoreSensor and VirtualMachineSensor must be enabled when 
+OrphanedVMDKSensor is enabled because scraper needs the datastores and 
+vm disks to find orphaned vmdk files`)
+	}
+
+	if (!c.Datastore.Enabled || !c.VirtualMachine.Enabled) && c.StoragePolicy.Enabled {
+		return fmt.Errorf(`DatastoreSensor and VirtualMachineSensor must be enabled when 
+StoragePolicySensor is enabled because scraper needs the datastores and 
+vm disks to query the policy compatibility and compliance`)
+	}
+
 	if !c.Datacenter.Enabled && c.Host.Enabled {
 		return fmt.Errorf(`DatacenterSensor must be enabled when 
 HostSensor is enabled because scraper needs the dc's 
@@ -316,15 +437,45 @@ when it queries the hosts`)
 	if c.Host.MaxAge.Seconds()+5 <= c.Host.RefreshInterval.Seconds() {
 		return fmt.Errorf("HostMaxAge must be more than 5sec bigger than HostRefreshInterval")
 	}
+	if c.HostSecurity.MaxAge.Seconds()+5 <= c.HostSecurity.RefreshInterval.Seconds() {
+		return fmt.Errorf("HostSecurityMaxAge must be more than 5sec bigger than HostSecurityRefreshInterval")
+	}
+	if c.HostOptions.MaxAge.Seconds()+5 <= c.HostOptions.RefreshInterval.Seconds() {
+		return fmt.Errorf("HostOptionsMaxAge must be more than 5sec bigger than HostOptionsRefreshInterval")
+	}
+	if _, err := c.HostOptions.ParseDesiredOptions(); err != nil {
+		return fmt.Errorf("invalid host advanced option: %v", err)
+	}
 	if c.ResourcePool.MaxAge.Seconds()+5 <= c.ResourcePool.RefreshInterval.Seconds() {
 		return fmt.Errorf("ResourcePoolMaxAge must be more than 5sec bigger than ResourcePoolRefreshInterval")
 	}
+	if c.VirtualApp.MaxAge.Seconds()+5 <= c.VirtualApp.RefreshInterval.Seconds() {
+		return fmt.Errorf("VirtualAppMaxAge must be more than 5sec bigger than VirtualAppRefreshInterval")
+	}
+	if c.ContentLibrary.MaxAge.Seconds()+5 <= c.ContentLibrary.RefreshInterval.Seconds() {
+		return fmt.Errorf("ContentLibraryMaxAge must be more than 5sec bigger than ContentLibraryRefreshInterval")
+	}
+	if c.License.MaxAge.Seconds()+5 <= c.License.RefreshInterval.Seconds() {
+		return fmt.Errorf("LicenseMaxAge must be more than 5sec bigger than LicenseRefreshInterval")
+	}
+	if c.OrphanedVMDK.MaxAge.Seconds()+5 <= c.OrphanedVMDK.RefreshInterval.Seconds() {
+		return fmt.Errorf("OrphanedVMDKMaxAge must be more than 5sec bigger than OrphanedVMDKRefreshInterval")
+	}
 	if c.Spod.MaxAge.Seconds()+5 <= c.Spod.RefreshInterval.Seconds() {
 		return fmt.Errorf("SpodMaxAge must be more than 5sec bigger than SpodRefreshInterval")
 	}
+	if c.StoragePolicy.MaxAge.Seconds()+5 <= c.StoragePolicy.RefreshInterval.Seconds() {
+		return fmt.Errorf("StoragePolicyMaxAge must be more than 5sec bigger than StoragePolicyRefreshInterval")
+	}
+	if c.ServiceInstance.MaxAge.Seconds()+5 <= c.ServiceInstance.RefreshInterval.Seconds() {
+		return fmt.Errorf("ServiceInstanceMaxAge must be more than 5sec bigger than ServiceInstanceRefreshInterval")
+	}
 	if c.Tags.MaxAge.Seconds()+5 <= c.Tags.RefreshInterval.Seconds() {
 		return fmt.Errorf("TagsMaxAge must be more than 5sec bigger than TagsRefreshInterval")
 	}
+	if c.CustomAttributes.MaxAge.Seconds()+5 <= c.CustomAttributes.RefreshInterval.Seconds() {
+		return fmt.Errorf("CustomAttributesMaxAge must be more than 5sec bigger than CustomAttributesRefreshInterval")
+	}
 	if c.VirtualMachine.MaxAge.Seconds()+5 <= c.VirtualMachine.RefreshInterval.Seconds() {
 		return fmt.Errorf("VirtualMachineMaxAge must be more than 5sec bigger than VirtualMachineRefreshInterval")
 	}
@@ -0,0 +1,107 @@
+package config
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Settings of a vm which can be used in a VMConfigRule
+var VMConfigSettings = []string{
+	"hardware_version",
+	"tools_upgrade_policy",
+	"cpu_hot_add",
+	"memory_hot_add",
+	"firmware",
+	"secure_boot",
+	"vtpm",
+	"encrypted",
+	"latency_sensitivity",
+	"cpu_limit",
+	"memory_limit",
+	"cdrom_connected",
+	"floppy_connected",
+}
+
+// Supported operators, longest first so they are matched before their prefix
+var vmConfigRuleOperators = []string{"!=", ">=", "<=", "=", ">", "<"}
+
+// VMConfigRule describes the desired value of a single vm setting. Rules are
+// written as "<setting><operator><value>", ex. "hardware_version>=19".
+type VMConfigRule struct {
+	Rule     string
+	Setting  string
+	Operator string
+	Value    string
+}
+
+func ParseVMConfigRule(rule string) (VMConfigRule, error) {
+	for _, op := range vmConfigRuleOperators {
+		setting, value, found := strings.Cut(rule, op)
+		if !found {
+			continue
+		}
+		setting = strings.TrimSpace(setting)
+		value = strings.TrimSpace(value)
+		if !slices.Contains(VMConfigSettings, setting) {
+			return VMConfigRule{}, fmt.Errorf("unknown vm setting %q in rule %q", setting, rule)
+		}
+		if _, err := strconv.ParseFloat(value, 64); err != nil && !slices.Contains([]string{"=", "!="}, op) {
+			return VMConfigRule{}, fmt.Errorf("operator %q requires a numeric value in rule %q", op, rule)
+		}
+		return VMConfigRule{
+			Rule:     rule,
+			Setting:  setting,
+			Operator: op,
+			Value:    value,
+		}, nil
+	}
+	return VMConfigRule{}, fmt.Errorf("no operator found in rule %q", rule)
+}
+
+func ParseVMConfigRules(rules []string) ([]VMConfigRule, error) {
+	result := []VMConfigRule{}
+	for _, rule := range rules {
+		r, err := ParseVMConfigRule(rule)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, r)
+	}
+	return result, nil
+}
+
+// Evaluate checks if the actual value of the setting matches the rule. Values
+// are compared as numbers when both sides are numeric, otherwise the
+// comparison is case insensitive. Ordering operators only match numeric
+// values, ParseVMConfigRule rejects them for non-numeric rule values.
+func (r VMConfigRule) Evaluate(actual string) bool {
+	a, errA := strconv.ParseFloat(actual, 64)
+	v, errV := strconv.ParseFloat(r.Value, 64)
+	if errA == nil && errV == nil {
+		switch r.Operator {
+		case "=":
+			return a == v
+		case "!=":
+			return a != v
+		case ">=":
+			return a >= v
+		case "<=":
+			return a <= v
+		case ">":
+			return a > v
+		case "<":
+			return a < v
+		}
+		return false
+	}
+
+	switch r.Operator {
+	case "=":
+		return strings.EqualFold(actual, r.Value)
+	case "!=":
+		return !strings.EqualFold(actual, r.Value)
+	}
+	return false
+}
@@ -0,0 +1,36 @@
+package config
+
+import "testing"
+
+func TestVMConfigRule(t *testing.T) {
+	tests := []struct {
+		rule     string
+		actual   string
+		expected bool
+	}{
+		{"hardware_version>=19", "19", true},
+		{"hardware_version>=19", "17", false},
+		{"hardware_version != 13", "13", false},
+		{"cpu_limit=-1", "-1", true},
+		{"secure_boot=true", "false", false},
+		{"tools_upgrade_policy=upgradeAtPowerCycle", "upgradeatpowercycle", true},
+		{"latency_sensitivity!=high", "normal", true},
+		{"cpu_limit<=1024", "unlimited", false},
+	}
+
+	for _, test := range tests {
+		rule, err := ParseVMConfigRule(test.rule)
+		if err != nil {
+			t.Fatalf("failed to parse rule %q: %v", test.rule, err)
+		}
+		if result := rule.Evaluate(test.actual); result != test.expected {
+			t.Errorf("rule %q with value %q: expected %v, got %v", test.rule, test.actual, test.expected, result)
+		}
+	}
+
+	for _, invalid := range []string{"hardware_version", "unknown_setting=1", "firmware>efi", "tools_upgrade_policy<=manual"} {
+		if _, err := ParseVMConfigRule(invalid); err == nil {
+			t.Errorf("expected error for rule %q", invalid)
+		}
+	}
+}
@@ -22,7 +22,17 @@ type Database interface {
 	SetHost(ctx context.Context, host objects.Host, ttl time.Duration) error
 	SetStoragePod(ctx context.Context, spod objects.StoragePod, ttl time.Duration) error
 	SetResourcePool(ctx context.Context, rp objects.ResourcePool, ttl time.Duration) error
+	SetVirtualApp(ctx context.Context, vApp objects.VirtualApp, ttl time.Duration) error
 	SetVM(ctx context.Context, vm objects.VirtualMachine, ttl time.Duration) error
+	SetVCenter(ctx context.Context, vc objects.VCenter, ttl time.Duration) error
+	SetContentLibrary(ctx context.Context, library objects.ContentLibrary, ttl time.Duration) error
+	SetVMStoragePolicy(ctx context.Context, policy objects.VMStoragePolicy, ttl time.Duration) error
+	SetStoragePolicy(ctx context.Context, policy objects.StoragePolicy, ttl time.Duration) error
+	SetOrphanedVMDKs(ctx context.Context, orphans objects.OrphanedVMDKs, ttl time.Duration) error
+	SetHostLicense(ctx context.Context, lic objects.HostLicense, ttl time.Duration) error
+	SetLicense(ctx context.Context, lic objects.License, ttl time.Duration) error
+	SetHostOptions(ctx context.Context, opts objects.HostOptions, ttl time.Duration) error
+	SetHostSecurity(ctx context.Context, sec objects.HostSecurity, ttl time.Duration) error
 
 	GetCluster(ctx context.Context, ref objects.ManagedObjectReference) *objects.Cluster
 	GetComputeResource(ctx context.Context, ref objects.ManagedObjectReference) *objects.ComputeResource
@@ -32,7 +42,17 @@ type Database interface {
 	GetHost(ctx context.Context, ref objects.ManagedObjectReference) *objects.Host
 	GetStoragePod(ctx context.Context, ref objects.ManagedObjectReference) *objects.StoragePod
 	GetResourcePool(ctx context.Context, ref objects.ManagedObjectReference) *objects.ResourcePool
+	GetVirtualApp(ctx context.Context, ref objects.ManagedObjectReference) *objects.VirtualApp
 	GetVM(ctx context.Context, ref objects.ManagedObjectReference) *objects.VirtualMachine
+	GetVCenter(ctx context.Context, ref objects.ManagedObjectReference) *objects.VCenter
+	GetContentLibrary(ctx context.Context, ref objects.ManagedObjectReference) *objects.ContentLibrary
+	GetVMStoragePolicy(ctx context.Context, ref objects.ManagedObjectReference) *objects.VMStoragePolicy
+	GetStoragePolicy(ctx context.Context, ref objects.ManagedObjectReference) *objects.StoragePolicy
+	GetOrphanedVMDKs(ctx context.Context, ref objects.ManagedObjectReference) *objects.OrphanedVMDKs
+	GetHostLicense(ctx context.Context, ref objects.ManagedObjectReference) *objects.HostLicense
+	GetLicense(ctx context.Context, ref objects.ManagedObjectReference) *objects.License
+	GetHostOptions(ctx context.Context, ref objects.ManagedObjectReference) *objects.HostOptions
+	GetHostSecurity(ctx context.Context, ref objects.ManagedObjectReference) *objects.HostSecurity
 
 	// GetAllClusterIter(ctx context.Context) iter.Seq[objects.Cluster]
 	// GetAllComputeResourceIter(ctx context.Context) iter.Seq[objects.ComputeResource]
@@ -54,7 +74,18 @@ type Database interface {
 	GetAllStoragePod(ctx context.Context) ([]objects.StoragePod, error)
 	GetAllResourcePool(ctx context.Context) ([]objects.ResourcePool, error)
 	GetAllTagSets(ctx context.Context) ([]objects.TagSet, error)
+	GetAllAttributeSets(ctx context.Context) ([]objects.AttributeSet, error)
+	GetAllVirtualApp(ctx context.Context) ([]objects.VirtualApp, error)
 	GetAllVM(ctx context.Context) ([]objects.VirtualMachine, error)
+	GetAllVCenter(ctx context.Context) ([]objects.VCenter, error)
+	GetAllContentLibrary(ctx context.Context) ([]objects.ContentLibrary, error)
+	GetAllVMStoragePolicy(ctx context.Context) ([]objects.VMStoragePolicy, error)
+	GetAllStoragePolicy(ctx context.Context) ([]objects.StoragePolicy, error)
+	GetAllOrphanedVMDKs(ctx context.Context) ([]objects.OrphanedVMDKs, error)
+	GetAllHostLicense(ctx context.Context) ([]objects.HostLicense, error)
+	GetAllLicense(ctx context.Context) ([]objects.License, error)
+	GetAllHostOptions(ctx context.Context) ([]objects.HostOptions, error)
+	GetAllHostSecurity(ctx context.Context) ([]objects.HostSecurity, error)
 
 	GetAllHostRefs(ctx context.Context) []objects.ManagedObjectReference
 	GetAllVMRefs(ctx context.Context) []objects.ManagedObjectReference
@@ -64,6 +95,9 @@ type Database interface {
 	SetTags(ctx context.Context, tagSet objects.TagSet, ttl time.Duration) error
 	GetTags(ctx context.Context, ref objects.ManagedObjectReference) objects.TagSet
 
+	SetAttributes(ctx context.Context, attrSet objects.AttributeSet, ttl time.Duration) error
+	GetAttributes(ctx context.Context, ref objects.ManagedObjectReference) objects.AttributeSet
+
 	GetParentChain(ctx context.Context, ref objects.ManagedObjectReference) objects.ParentChain
 	JsonDump(ctx context.Context, refType objects.ManagedObjectTypes) ([]byte, error)
 }
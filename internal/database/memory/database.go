@@ -173,6 +173,14 @@ func (db *DB) SetResourcePool(ctx context.Context, rp objects.ResourcePool, ttl
 	return nil
 }
 
+func (db *DB) SetVirtualApp(ctx context.Context, vApp objects.VirtualApp, ttl time.Duration) error {
+	err := db.SetObj(ctx, vApp.Self.Value, objects.ManagedObjectTypesVirtualApp, vApp, ttl)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
 func (db *DB) SetVM(ctx context.Context, vm objects.VirtualMachine, ttl time.Duration) error {
 	err := db.SetObj(ctx, vm.Self.Value, objects.ManagedObjectTypesVirtualMachine, vm, ttl)
 	if err != nil {
@@ -181,6 +189,78 @@ func (db *DB) SetVM(ctx context.Context, vm objects.VirtualMachine, ttl time.Dur
 	return nil
 }
 
+func (db *DB) SetVCenter(ctx context.Context, vc objects.VCenter, ttl time.Duration) error {
+	err := db.SetObj(ctx, vc.InstanceUUID, objects.ManagedObjectTypesVCenter, vc, ttl)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (db *DB) SetContentLibrary(ctx context.Context, library objects.ContentLibrary, ttl time.Duration) error {
+	err := db.SetObj(ctx, library.ID, objects.ManagedObjectTypesContentLibrary, library, ttl)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (db *DB) SetVMStoragePolicy(ctx context.Context, policy objects.VMStoragePolicy, ttl time.Duration) error {
+	err := db.SetObj(ctx, policy.VM.Value, objects.ManagedObjectTypesVMStoragePolicy, policy, ttl)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (db *DB) SetStoragePolicy(ctx context.Context, policy objects.StoragePolicy, ttl time.Duration) error {
+	err := db.SetObj(ctx, policy.ID, objects.ManagedObjectTypesStoragePolicy, policy, ttl)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (db *DB) SetOrphanedVMDKs(ctx context.Context, orphans objects.OrphanedVMDKs, ttl time.Duration) error {
+	err := db.SetObj(ctx, orphans.Datastore.Value, objects.ManagedObjectTypesOrphanedVMDKs, orphans, ttl)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (db *DB) SetHostLicense(ctx context.Context, lic objects.HostLicense, ttl time.Duration) error {
+	err := db.SetObj(ctx, lic.Host.Value, objects.ManagedObjectTypesHostLicense, lic, ttl)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (db *DB) SetLicense(ctx context.Context, lic objects.License, ttl time.Duration) error {
+	err := db.SetObj(ctx, lic.LicenseKey, objects.ManagedObjectTypesLicense, lic, ttl)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (db *DB) SetHostOptions(ctx context.Context, opts objects.HostOptions, ttl time.Duration) error {
+	err := db.SetObj(ctx, opts.Host.Value, objects.ManagedObjectTypesHostOptions, opts, ttl)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (db *DB) SetHostSecurity(ctx context.Context, sec objects.HostSecurity, ttl time.Duration) error {
+	err := db.SetObj(ctx, sec.Host.Value, objects.ManagedObjectTypesHostSecurity, sec, ttl)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
 func (db *DB) GetCluster(ctx context.Context, ref objects.ManagedObjectReference) *objects.Cluster {
 	var cluster objects.Cluster
 	err := db.Table(objects.ManagedObjectTypesCluster).Get(ref.Value, &cluster)
@@ -253,6 +333,15 @@ func (db *DB) GetResourcePool(ctx context.Context, ref objects.ManagedObjectRefe
 	return &rp
 }
 
+func (db *DB) GetVirtualApp(ctx context.Context, ref objects.ManagedObjectReference) *objects.VirtualApp {
+	var vApp objects.VirtualApp
+	err := db.Table(objects.ManagedObjectTypesVirtualApp).Get(ref.Value, &vApp)
+	if err != nil {
+		return nil
+	}
+	return &vApp
+}
+
 func (db *DB) GetVM(ctx context.Context, ref objects.ManagedObjectReference) *objects.VirtualMachine {
 	var vm objects.VirtualMachine
 	err := db.Table(objects.ManagedObjectTypesVirtualMachine).Get(ref.Value, &vm)
@@ -262,6 +351,87 @@ func (db *DB) GetVM(ctx context.Context, ref objects.ManagedObjectReference) *ob
 	return &vm
 }
 
+func (db *DB) GetVCenter(ctx context.Context, ref objects.ManagedObjectReference) *objects.VCenter {
+	var vc objects.VCenter
+	err := db.Table(objects.ManagedObjectTypesVCenter).Get(ref.Value, &vc)
+	if err != nil {
+		return nil
+	}
+	return &vc
+}
+
+func (db *DB) GetContentLibrary(ctx context.Context, ref objects.ManagedObjectReference) *objects.ContentLibrary {
+	var library objects.ContentLibrary
+	err := db.Table(objects.ManagedObjectTypesContentLibrary).Get(ref.Value, &library)
+	if err != nil {
+		return nil
+	}
+	return &library
+}
+
+func (db *DB) GetVMStoragePolicy(ctx context.Context, ref objects.ManagedObjectReference) *objects.VMStoragePolicy {
+	var policy objects.VMStoragePolicy
+	err := db.Table(objects.ManagedObjectTypesVMStoragePolicy).Get(ref.Value, &policy)
+	if err != nil {
+		return nil
+	}
+	return &policy
+}
+
+func (db *DB) GetStoragePolicy(ctx context.Context, ref objects.ManagedObjectReference) *objects.StoragePolicy {
+	var policy objects.StoragePolicy
+	err := db.Table(objects.ManagedObjectTypesStoragePolicy).Get(ref.Value, &policy)
+	if err != nil {
+		return nil
+	}
+	return &policy
+}
+
+func (db *DB) GetOrphanedVMDKs(ctx context.Context, ref objects.ManagedObjectReference) *objects.OrphanedVMDKs {
+	var orphans objects.OrphanedVMDKs
+	err := db.Table(objects.ManagedObjectTypesOrphanedVMDKs).Get(ref.Value, &orphans)
+	if err != nil {
+		return nil
+	}
+	return &orphans
+}
+
+func (db *DB) GetHostLicense(ctx context.Context, ref objects.ManagedObjectReference) *objects.HostLicense {
+	var lic objects.HostLicense
+	err := db.Table(objects.ManagedObjectTypesHostLicense).Get(ref.Value, &lic)
+	if err != nil {
+		return nil
+	}
+	return &lic
+}
+
+func (db *DB) GetLicense(ctx context.Context, ref objects.ManagedObjectReference) *objects.License {
+	var lic objects.License
+	err := db.Table(objects.ManagedObjectTypesLicense).Get(ref.Value, &lic)
+	if err != nil {
+		return nil
+	}
+	return &lic
+}
+
+func (db *DB) GetHostOptions(ctx context.Context, ref objects.ManagedObjectReference) *objects.HostOptions {
+	var opts objects.HostOptions
+	err := db.Table(objects.ManagedObjectTypesHostOptions).Get(ref.Value, &opts)
+	if err != nil {
+		return nil
+	}
+	return &opts
+}
+
+func (db *DB) GetHostSecurity(ctx context.Context, ref objects.ManagedObjectReference) *objects.HostSecurity {
+	var sec objects.HostSecurity
+	err := db.Table(objects.ManagedObjectTypesHostSecurity).Get(ref.Value, &sec)
+	if err != nil {
+		return nil
+	}
+	return &sec
+}
+
 func (db *DB) GetAllCluster(ctx context.Context) ([]objects.Cluster, error) {
 	var allObjs []objects.Cluster
 	err := db.Table(objects.ManagedObjectTypesCluster).GetAll(&allObjs)
@@ -343,6 +513,24 @@ func (db *DB) GetAllTagSets(ctx context.Context) ([]objects.TagSet, error) {
 	return allObjs, nil
 }
 
+func (db *DB) GetAllAttributeSets(ctx context.Context) ([]objects.AttributeSet, error) {
+	var allObjs []objects.AttributeSet
+	err := db.Table(objects.ManagedObjectTypesAttributeSet).GetAll(&allObjs)
+	if err != nil {
+		return nil, err
+	}
+	return allObjs, nil
+}
+
+func (db *DB) GetAllVirtualApp(ctx context.Context) ([]objects.VirtualApp, error) {
+	var allObjs []objects.VirtualApp
+	err := db.Table(objects.ManagedObjectTypesVirtualApp).GetAll(&allObjs)
+	if err != nil {
+		return nil, err
+	}
+	return allObjs, nil
+}
+
 func (db *DB) GetAllVM(ctx context.Context) ([]objects.VirtualMachine, error) {
 	var allObjs []objects.VirtualMachine
 	err := db.Table(objects.ManagedObjectTypesVirtualMachine).GetAll(&allObjs)
@@ -352,6 +540,87 @@ func (db *DB) GetAllVM(ctx context.Context) ([]objects.VirtualMachine, error) {
 	return allObjs, nil
 }
 
+func (db *DB) GetAllVCenter(ctx context.Context) ([]objects.VCenter, error) {
+	var allObjs []objects.VCenter
+	err := db.Table(objects.ManagedObjectTypesVCenter).GetAll(&allObjs)
+	if err != nil {
+		return nil, err
+	}
+	return allObjs, nil
+}
+
+func (db *DB) GetAllContentLibrary(ctx context.Context) ([]objects.ContentLibrary, error) {
+	var allObjs []objects.ContentLibrary
+	err := db.Table(objects.ManagedObjectTypesContentLibrary).GetAll(&allObjs)
+	if err != nil {
+		return nil, err
+	}
+	return allObjs, nil
+}
+
+func (db *DB) GetAllVMStoragePolicy(ctx context.Context) ([]objects.VMStoragePolicy, error) {
+	var allObjs []objects.VMStoragePolicy
+	err := db.Table(objects.ManagedObjectTypesVMStoragePolicy).GetAll(&allObjs)
+	if err != nil {
+		return nil, err
+	}
+	return allObjs, nil
+}
+
+func (db *DB) GetAllStoragePolicy(ctx context.Context) ([]objects.StoragePolicy, error) {
+	var allObjs []objects.StoragePolicy
+	err := db.Table(objects.ManagedObjectTypesStoragePolicy).GetAll(&allObjs)
+	if err != nil {
+		return nil, err
+	}
+	return allObjs, nil
+}
+
+func (db *DB) GetAllOrphanedVMDKs(ctx context.Context) ([]objects.OrphanedVMDKs, error) {
+	var allObjs []objects.OrphanedVMDKs
+	err := db.Table(objects.ManagedObjectTypesOrphanedVMDKs).GetAll(&allObjs)
+	if err != nil {
+		return nil, err
+	}
+	return allObjs, nil
+}
+
+func (db *DB) GetAllHostLicense(ctx context.Context) ([]objects.HostLicense, error) {
+	var allObjs []objects.HostLicense
+	err := db.Table(objects.ManagedObjectTypesHostLicense).GetAll(&allObjs)
+	if err != nil {
+		return nil, err
+	}
+	return allObjs, nil
+}
+
+func (db *DB) GetAllLicense(ctx context.Context) ([]objects.License, error) {
+	var allObjs []objects.License
+	err := db.Table(objects.ManagedObjectTypesLicense).GetAll(&allObjs)
+	if err != nil {
+		return nil, err
+	}
+	return allObjs, nil
+}
+
+func (db *DB) GetAllHostOptions(ctx context.Context) ([]objects.HostOptions, error) {
+	var allObjs []objects.HostOptions
+	err := db.Table(objects.ManagedObjectTypesHostOptions).GetAll(&allObjs)
+	if err != nil {
+		return nil, err
+	}
+	return allObjs, nil
+}
+
+func (db *DB) GetAllHostSecurity(ctx context.Context) ([]objects.HostSecurity, error) {
+	var allObjs []objects.HostSecurity
+	err := db.Table(objects.ManagedObjectTypesHostSecurity).GetAll(&allObjs)
+	if err != nil {
+		return nil, err
+	}
+	return allObjs, nil
+}
+
 func (db *DB) GetAllHostRefs(ctx context.Context) []objects.ManagedObjectReference {
 	hosts, _ := db.GetAllHost(ctx)
 	result := []objects.ManagedObjectReference{}
@@ -408,6 +677,26 @@ func (db *DB) GetTags(ctx context.Context, ref objects.ManagedObjectReference) o
 	return tag
 }
 
+func (db *DB) SetAttributes(ctx context.Context, attrSet objects.AttributeSet, ttl time.Duration) error {
+	err := db.Table(objects.ManagedObjectTypesAttributeSet).SetWithTTL(attrSet.ObjectRef.Hash(), attrSet, ttl)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (db *DB) GetAttributes(ctx context.Context, ref objects.ManagedObjectReference) objects.AttributeSet {
+	var attrSet objects.AttributeSet
+	err := db.Table(objects.ManagedObjectTypesAttributeSet).Get(ref.Hash(), &attrSet)
+	if errors.Is(err, ErrKeyNotFound) {
+		return objects.AttributeSet{}
+	} else if err != nil {
+		panic(err)
+	}
+	return attrSet
+}
+
 func (db *DB) GetParentChain(ctx context.Context, ref objects.ManagedObjectReference) objects.ParentChain {
 	return db.walkParentChain(ctx, ref, objects.ParentChain{
 		DC:           "",
@@ -415,6 +704,7 @@ func (db *DB) GetParentChain(ctx context.Context, ref objects.ManagedObjectRefer
 		SPOD:         "",
 		ResourcePool: "",
 		Chain:        []string{},
+		Path:         []string{},
 	})
 }
 
@@ -424,6 +714,7 @@ func (db *DB) walkParentChain(ctx context.Context, ref objects.ManagedObjectRefe
 		cluster := db.GetCluster(ctx, ref)
 		if cluster != nil {
 			chain.Cluster = cluster.Name
+			chain.PrependPath(cluster.Name)
 			if cluster.Parent != nil {
 				return db.walkParentChain(ctx, *cluster.Parent, chain)
 			}
@@ -432,6 +723,7 @@ func (db *DB) walkParentChain(ctx context.Context, ref objects.ManagedObjectRefe
 	} else if db.HasTable(ref.Type) && ref.Type == objects.ManagedObjectTypesComputeResource {
 		cr := db.GetComputeResource(ctx, ref)
 		if cr != nil {
+			chain.PrependPath(cr.Name)
 			if cr.Parent != nil {
 				return db.walkParentChain(ctx, *cr.Parent, chain)
 			}
@@ -441,6 +733,7 @@ func (db *DB) walkParentChain(ctx context.Context, ref objects.ManagedObjectRefe
 		dc := db.GetDatacenter(ctx, ref)
 		if dc != nil {
 			chain.DC = dc.Name
+			chain.PrependPath(dc.Name)
 			if dc.Parent != nil {
 				return db.walkParentChain(ctx, *dc.Parent, chain)
 			}
@@ -458,6 +751,7 @@ func (db *DB) walkParentChain(ctx context.Context, ref objects.ManagedObjectRefe
 		folder := db.GetFolder(ctx, ref)
 		if folder != nil {
 			if folder.Parent != nil {
+				chain.PrependPath(folder.Name)
 				return db.walkParentChain(ctx, *folder.Parent, chain)
 			}
 			return chain
@@ -474,6 +768,7 @@ func (db *DB) walkParentChain(ctx context.Context, ref objects.ManagedObjectRefe
 		spod := db.GetStoragePod(ctx, ref)
 		if spod != nil {
 			chain.SPOD = spod.Name
+			chain.PrependPath(spod.Name)
 			if spod.Parent != nil {
 				return db.walkParentChain(ctx, *spod.Parent, chain)
 			}
@@ -483,6 +778,7 @@ func (db *DB) walkParentChain(ctx context.Context, ref objects.ManagedObjectRefe
 		rp := db.GetResourcePool(ctx, ref)
 		if rp != nil {
 			chain.ResourcePool = rp.Name
+			chain.PrependPath(rp.Name)
 			if rp.Parent != nil {
 				return db.walkParentChain(ctx, *rp.Parent, chain)
 			}
@@ -493,6 +789,17 @@ func (db *DB) walkParentChain(ctx context.Context, ref objects.ManagedObjectRefe
 		if vm != nil {
 			if vm.Parent != nil {
 				return db.walkParentChain(ctx, *vm.Parent, chain)
+			} else if vm.ParentVApp != nil {
+				return db.walkParentChain(ctx, *vm.ParentVApp, chain)
+			}
+			return chain
+		}
+	} else if db.HasTable(ref.Type) && ref.Type == objects.ManagedObjectTypesVirtualApp {
+		vApp := db.GetVirtualApp(ctx, ref)
+		if vApp != nil {
+			chain.PrependPath(vApp.Name)
+			if vApp.Parent != nil {
+				return db.walkParentChain(ctx, *vApp.Parent, chain)
 			}
 			return chain
 		}
@@ -555,12 +862,78 @@ func (db *DB) JsonDump(ctx context.Context, refType objects.ManagedObjectTypes)
 			return nil, err
 		}
 		return json.MarshalIndent(tagSets, "", "  ")
+	} else if db.HasTable(refType) && refType == objects.ManagedObjectTypesAttributeSet {
+		attrSets, err := db.GetAllAttributeSets(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return json.MarshalIndent(attrSets, "", "  ")
+	} else if db.HasTable(refType) && refType == objects.ManagedObjectTypesVirtualApp {
+		vApps, err := db.GetAllVirtualApp(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return json.MarshalIndent(vApps, "", "  ")
 	} else if db.HasTable(refType) && refType == objects.ManagedObjectTypesVirtualMachine {
 		vms, err := db.GetAllVM(ctx)
 		if err != nil {
 			return nil, err
 		}
 		return json.MarshalIndent(vms, "", "  ")
+	} else if db.HasTable(refType) && refType == objects.ManagedObjectTypesHostSecurity {
+		secs, err := db.GetAllHostSecurity(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return json.MarshalIndent(secs, "", "  ")
+	} else if db.HasTable(refType) && refType == objects.ManagedObjectTypesHostOptions {
+		opts, err := db.GetAllHostOptions(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return json.MarshalIndent(opts, "", "  ")
+	} else if db.HasTable(refType) && refType == objects.ManagedObjectTypesLicense {
+		licenses, err := db.GetAllLicense(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return json.MarshalIndent(licenses, "", "  ")
+	} else if db.HasTable(refType) && refType == objects.ManagedObjectTypesHostLicense {
+		licenses, err := db.GetAllHostLicense(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return json.MarshalIndent(licenses, "", "  ")
+	} else if db.HasTable(refType) && refType == objects.ManagedObjectTypesOrphanedVMDKs {
+		orphanedVMDKs, err := db.GetAllOrphanedVMDKs(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return json.MarshalIndent(orphanedVMDKs, "", "  ")
+	} else if db.HasTable(refType) && refType == objects.ManagedObjectTypesStoragePolicy {
+		storagePolicies, err := db.GetAllStoragePolicy(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return json.MarshalIndent(storagePolicies, "", "  ")
+	} else if db.HasTable(refType) && refType == objects.ManagedObjectTypesVMStoragePolicy {
+		vmStoragePolicies, err := db.GetAllVMStoragePolicy(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return json.MarshalIndent(vmStoragePolicies, "", "  ")
+	} else if db.HasTable(refType) && refType == objects.ManagedObjectTypesContentLibrary {
+		libraries, err := db.GetAllContentLibrary(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return json.MarshalIndent(libraries, "", "  ")
+	} else if db.HasTable(refType) && refType == objects.ManagedObjectTypesVCenter {
+		vcenters, err := db.GetAllVCenter(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return json.MarshalIndent(vcenters, "", "  ")
 	}
 	return nil, nil
 }
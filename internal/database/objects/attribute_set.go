@@ -0,0 +1,20 @@
+package objects
+
+type AttributeSet struct {
+	ObjectRef  ManagedObjectReference `json:"object_ref" redis:"object_ref"`
+	Attributes map[string]string      `json:"attributes" redis:"attributes"`
+}
+
+func NewAttributeSet(ref ManagedObjectReference) AttributeSet {
+	return AttributeSet{
+		ObjectRef:  ref,
+		Attributes: map[string]string{},
+	}
+}
+
+func (o *AttributeSet) GetAttribute(name string) string {
+	if value, ok := o.Attributes[name]; ok {
+		return value
+	}
+	return ""
+}
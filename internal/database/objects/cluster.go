@@ -1,6 +1,7 @@
 package objects
 
 import (
+	"strings"
 	"time"
 )
 
@@ -20,6 +21,53 @@ type Cluster struct {
 	NumEffectiveHosts float64 `json:"num_effective_hosts" redis:"num_effective_hosts"`
 	NumHosts          float64 `json:"num_hosts" redis:"num_hosts"`
 	OverallStatus     string  `json:"overall_status" redis:"overall_status"`
+	NumVmotions       float64 `json:"num_vmotions" redis:"num_vmotions"`
+
+	DRSEnabled  bool   `json:"drs_enabled" redis:"drs_enabled"`
+	DRSBehavior string `json:"drs_behavior" redis:"drs_behavior"`
+	// Cluster DRS score in percent (vSphere 7.0 or later)
+	DRSScore float64 `json:"drs_score" redis:"drs_score"`
+	// Number of VM's per DRS score bucket (0-20%, 21-40%, 41-60%, 61-80% and 81-100%)
+	DRSVMsPerScoreBucket      []float64 `json:"drs_vms_per_score_bucket" redis:"drs_vms_per_score_bucket"`
+	DRSPendingRecommendations float64   `json:"drs_pending_recommendations" redis:"drs_pending_recommendations"`
+
+	HAEnabled                 bool   `json:"ha_enabled" redis:"ha_enabled"`
+	HAHostMonitoring          bool   `json:"ha_host_monitoring" redis:"ha_host_monitoring"`
+	HAVMMonitoring            string `json:"ha_vm_monitoring" redis:"ha_vm_monitoring"`
+	HAAdmissionControlEnabled bool   `json:"ha_admission_control_enabled" redis:"ha_admission_control_enabled"`
+	// failover_level, failover_resources, failover_hosts or empty when unknown
+	HAAdmissionControlPolicy string `json:"ha_admission_control_policy" redis:"ha_admission_control_policy"`
+	// Number of host failures the cluster should tolerate
+	HAFailoverLevel float64 `json:"ha_failover_level" redis:"ha_failover_level"`
+	// Number of host failures the cluster can currently tolerate
+	HACurrentFailoverLevel float64 `json:"ha_current_failover_level" redis:"ha_current_failover_level"`
+	// Only used by the failover_resources policy
+	HAConfiguredCPUFailoverPercent    float64 `json:"ha_configured_cpu_failover_percent" redis:"ha_configured_cpu_failover_percent"`
+	HAConfiguredMemoryFailoverPercent float64 `json:"ha_configured_memory_failover_percent" redis:"ha_configured_memory_failover_percent"`
+	HACurrentCPUFailoverPercent       float64 `json:"ha_current_cpu_failover_percent" redis:"ha_current_cpu_failover_percent"`
+	HACurrentMemoryFailoverPercent    float64 `json:"ha_current_memory_failover_percent" redis:"ha_current_memory_failover_percent"`
+
+	EVCMode string `json:"evc_mode" redis:"evc_mode"`
+
+	Rules  []ClusterRule  `json:"rules" redis:"rules"`
+	Groups []ClusterGroup `json:"groups" redis:"groups"`
+}
+
+// Labels of the DRS score buckets
+var DRSScoreBuckets = []string{"0-20", "21-40", "41-60", "61-80", "81-100"}
+
+// Return DRSBehavior as float64
+//
+//	0 => manual or unknown
+//	1 => partiallyAutomated
+//	2 => fullyAutomated
+func (c *Cluster) DRSBehaviorFloat64() float64 {
+	if strings.EqualFold(c.DRSBehavior, "partiallyAutomated") {
+		return 1.0
+	} else if strings.EqualFold(c.DRSBehavior, "fullyAutomated") {
+		return 2.0
+	}
+	return 0
 }
 
 // Return OverallStatus as float64
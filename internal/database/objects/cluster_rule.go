@@ -0,0 +1,100 @@
+package objects
+
+import "slices"
+
+const (
+	ClusterRuleTypeVMAffinity         = "vm_affinity"
+	ClusterRuleTypeVMAntiAffinity     = "vm_anti_affinity"
+	ClusterRuleTypeVMHostAffinity     = "vm_host_affinity"
+	ClusterRuleTypeVMHostAntiAffinity = "vm_host_anti_affinity"
+	ClusterRuleTypeVMDependency       = "vm_dependency"
+
+	ClusterGroupTypeVM   = "vm"
+	ClusterGroupTypeHost = "host"
+)
+
+type ClusterRule struct {
+	Key       int32  `json:"key" redis:"key"`
+	Name      string `json:"name" redis:"name"`
+	Type      string `json:"type" redis:"type"`
+	Enabled   bool   `json:"enabled" redis:"enabled"`
+	Mandatory bool   `json:"mandatory" redis:"mandatory"`
+	// VM ids of vm_affinity and vm_anti_affinity rules
+	VMs []string `json:"vms" redis:"vms"`
+	// VM group of vm_host_(anti_)affinity and vm_dependency rules
+	VMGroup string `json:"vm_group" redis:"vm_group"`
+	// Host group of vm_host_(anti_)affinity rules or the dependent VM group
+	// of vm_dependency rules
+	TargetGroup string `json:"target_group" redis:"target_group"`
+}
+
+type ClusterGroup struct {
+	Name string `json:"name" redis:"name"`
+	Type string `json:"type" redis:"type"`
+	// VM or host ids
+	Members []string `json:"members" redis:"members"`
+}
+
+func (c *Cluster) GetGroup(name string) *ClusterGroup {
+	for _, g := range c.Groups {
+		if g.Name == name {
+			return &g
+		}
+	}
+	return nil
+}
+
+// Return the VM ids the rule applies to
+func (c *Cluster) RuleVMs(rule ClusterRule) []string {
+	if rule.VMGroup == "" {
+		return rule.VMs
+	}
+	if g := c.GetGroup(rule.VMGroup); g != nil {
+		return g.Members
+	}
+	return nil
+}
+
+// Count the number of VMs violating the rule. vmHosts maps the id of every
+// running VM to the id of its host, VMs without a host are ignored. For
+// (anti-)affinity rules this is the minimal number of VMs that need to be
+// migrated to comply with the rule. vm_dependency rules do not define a
+// placement and never have violations.
+func (c *Cluster) RuleViolations(rule ClusterRule, vmHosts map[string]string) float64 {
+	perHost := map[string]int{}
+	placed := 0
+	for _, vm := range c.RuleVMs(rule) {
+		if host, ok := vmHosts[vm]; ok && host != "" {
+			perHost[host]++
+			placed++
+		}
+	}
+
+	violations := 0
+	switch rule.Type {
+	case ClusterRuleTypeVMAffinity:
+		max := 0
+		for _, n := range perHost {
+			if n > max {
+				max = n
+			}
+		}
+		violations = placed - max
+	case ClusterRuleTypeVMAntiAffinity:
+		for _, n := range perHost {
+			violations += n - 1
+		}
+	case ClusterRuleTypeVMHostAffinity, ClusterRuleTypeVMHostAntiAffinity:
+		var hosts []string
+		if g := c.GetGroup(rule.TargetGroup); g != nil {
+			hosts = g.Members
+		}
+		affine := rule.Type == ClusterRuleTypeVMHostAffinity
+		for host, n := range perHost {
+			if slices.Contains(hosts, host) != affine {
+				violations += n
+			}
+		}
+	}
+	return float64(violations)
+}
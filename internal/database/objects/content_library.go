@@ -0,0 +1,61 @@
+package objects
+
+import (
+	"strings"
+	"time"
+)
+
+const (
+	ContentLibraryTypeLocal      = "LOCAL"
+	ContentLibraryTypeSubscribed = "SUBSCRIBED"
+)
+
+type ContentLibrary struct {
+	Timestamp        time.Time `json:"timestamp" redis:"timestamp"`
+	ID               string    `json:"id" redis:"id"`
+	Name             string    `json:"name" redis:"name"`
+	Type             string    `json:"type" redis:"type"`
+	State            string    `json:"state" redis:"state"`
+	CreationTime     time.Time `json:"creation_time" redis:"creation_time"`
+	LastModifiedTime time.Time `json:"last_modified_time" redis:"last_modified_time"`
+	// Only set for subscribed libraries
+	LastSyncTime time.Time `json:"last_sync_time" redis:"last_sync_time"`
+	// Datastores backing the library, empty when the library is stored on a
+	// file system (StorageURI)
+	Datastores   []ManagedObjectReference    `json:"datastores" redis:"datastores"`
+	StorageURI   string                      `json:"storage_uri" redis:"storage_uri"`
+	Published    bool                        `json:"published" redis:"published"`
+	Subscription *ContentLibrarySubscription `json:"subscription" redis:"subscription"`
+	Items        []ContentLibraryItem        `json:"items" redis:"items"`
+}
+
+type ContentLibrarySubscription struct {
+	URL           string `json:"url" redis:"url"`
+	AutomaticSync bool   `json:"automatic_sync" redis:"automatic_sync"`
+	OnDemand      bool   `json:"on_demand" redis:"on_demand"`
+}
+
+type ContentLibraryItem struct {
+	ID   string `json:"id" redis:"id"`
+	Name string `json:"name" redis:"name"`
+	// ovf, vm-template, iso, ...
+	Type             string    `json:"type" redis:"type"`
+	Size             float64   `json:"size" redis:"size"`
+	Cached           bool      `json:"cached" redis:"cached"`
+	CreationTime     time.Time `json:"creation_time" redis:"creation_time"`
+	LastModifiedTime time.Time `json:"last_modified_time" redis:"last_modified_time"`
+	LastSyncTime     time.Time `json:"last_sync_time" redis:"last_sync_time"`
+}
+
+func (l *ContentLibrary) IsSubscribed() bool {
+	return strings.EqualFold(l.Type, ContentLibraryTypeSubscribed)
+}
+
+// Total size of all items in the library
+func (l *ContentLibrary) Size() float64 {
+	var size float64
+	for _, item := range l.Items {
+		size += item.Size
+	}
+	return size
+}
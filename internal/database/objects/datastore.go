@@ -11,9 +11,11 @@ type Datastore struct {
 	Parent           *ManagedObjectReference  `json:"parent" redis:"parent"`
 	Name             string                   `json:"name" redis:"name"`
 	DatastoreCluster string                   `json:"datastore_cluster" redis:"datastore_cluster"`
+	FolderPath       string                   `json:"folder_path" redis:"folder_path"`
 	Kind             string                   `json:"kind" redis:"kind"`
 	Capacity         float64                  `json:"capacity" redis:"capacity"`
 	FreeSpace        float64                  `json:"free_space" redis:"free_space"`
+	Uncommitted      float64                  `json:"uncommitted" redis:"uncommitted"`
 	Accessible       bool                     `json:"accessible" redis:"accessible"`
 	Maintenance      string                   `json:"maintenance" redis:"maintenance"`
 	OverallStatus    string                   `json:"overall_status" redis:"overall_status"`
@@ -22,6 +24,24 @@ type Datastore struct {
 	HostVmknicActive float64                  `json:"host_vmknic_active" redis:"host_vmknic_active"`
 	HostMountInfo    []DatastoreHostMountInfo `json:"host_mount_info" redis:"host_mount_info"`
 	VmfsInfo         *DatastoreVmfsInfo       `json:"vmfs_info" redis:"vmfs_info"`
+	NasInfo          *DatastoreNasInfo        `json:"nas_info" redis:"nas_info"`
+	// Ids of the vm's and templates stored on the datastore
+	VMs []string `json:"vms" redis:"vms"`
+}
+
+// Return the provisioned space in bytes, this is the used space plus the
+// space that thin provisioned disks can still grow into.
+func (d *Datastore) ProvisionedFloat64() float64 {
+	return d.Capacity - d.FreeSpace + d.Uncommitted
+}
+
+// Return the ratio between provisioned space and capacity, a value above 1
+// means the datastore is overprovisioned.
+func (d *Datastore) OverprovisioningRatio() float64 {
+	if d.Capacity <= 0 {
+		return 0
+	}
+	return d.ProvisionedFloat64() / d.Capacity
 }
 
 // Return the maintenance status as a float64 number.
@@ -53,6 +73,24 @@ type DatastoreVmfsInfo struct {
 	SSD   bool   `json:"ssd" redis:"ssd"`
 	Local bool   `json:"local" redis:"local"`
 	NAA   string `json:"naa" redis:"naa"`
+
+	Version   string  `json:"version" redis:"version"`
+	BlockSize float64 `json:"block_size" redis:"block_size"`
+	// Space reclamation settings, UnmapPriority is none when disabled
+	UnmapPriority      string  `json:"unmap_priority" redis:"unmap_priority"`
+	UnmapGranularity   float64 `json:"unmap_granularity" redis:"unmap_granularity"`
+	UnmapBandwidthSpec string  `json:"unmap_bandwidth_spec" redis:"unmap_bandwidth_spec"`
+}
+
+func (i *DatastoreVmfsInfo) UnmapEnabled() bool {
+	return i.UnmapPriority != "" && !strings.EqualFold(i.UnmapPriority, "none")
+}
+
+type DatastoreNasInfo struct {
+	// NFS or NFS41
+	Type       string `json:"type" redis:"type"`
+	RemoteHost string `json:"remote_host" redis:"remote_host"`
+	RemotePath string `json:"remote_path" redis:"remote_path"`
 }
 
 type DatastoreHostMountInfo struct {
@@ -93,6 +93,8 @@ func (r *ManagedObjectReference) ToVMwareRef() types.ManagedObjectReference {
 		t = string(types.ManagedObjectTypesVirtualMachine)
 	case ManagedObjectTypesTagSet:
 		panic(fmt.Sprintf("Can not convert TagSet to a types.ManagedObjectReference", typ))
+	case ManagedObjectTypesAttributeSet:
+		panic("Can not convert AttributeSet to a types.ManagedObjectReference")
 	case ManagedObjectTypesTag:
 		panic(fmt.Sprintf("Can not convert Tag to a types.ManagedObjectReference", typ))
 	default:
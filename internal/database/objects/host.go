@@ -12,6 +12,7 @@ type Host struct {
 	Name       string                  `json:"name" redis:"name"`
 	Cluster    string                  `json:"cluster" redis:"cluster"`
 	Datacenter string                  `json:"datacenter" redis:"datacenter"`
+	FolderPath string                  `json:"folder_path" redis:"folder_path"`
 
 	OSVersion   string `json:"os_version" redis:"os_version"`
 	AssetTag    string `json:"asset_tag" redis:"asset_tag"`
@@ -43,6 +44,18 @@ type Host struct {
 	// SCSILunMounted    float64 `json:"scsi_lun_mounted" redis:"scsi_lun_mounted"`
 	// SCSILunAccessible float64 `json:"scsi_lun_accessible" redis:"scsi_lun_accessible"`
 
+	CPUPackages          []HostCPUPackage  `json:"cpu_packages" redis:"cpu_packages"`
+	HardwareMemoryBytes  float64           `json:"hardware_memory_bytes" redis:"hardware_memory_bytes"`
+	PCIDevices           []HostPCIDevice   `json:"pci_devices" redis:"pci_devices"`
+	GPUs                 []HostGPU         `json:"gpus" redis:"gpus"`
+	PhysicalNICs         []HostPhysicalNIC `json:"physical_nics" redis:"physical_nics"`
+	TPMAttestationStatus string            `json:"tpm_attestation_status" redis:"tpm_attestation_status"`
+	TPMAttestationTime   time.Time         `json:"tpm_attestation_time" redis:"tpm_attestation_time"`
+
+	VirtualSwitches []HostVirtualSwitch `json:"virtual_switches" redis:"virtual_switches"`
+	PortGroups      []HostPortGroup     `json:"portgroups" redis:"portgroups"`
+	VMKernelNICs    []HostVMKernelNIC   `json:"vmkernel_nics" redis:"vmkernel_nics"`
+
 	NumberOfVMs        float64 `json:"number_of_vms" redis:"number_of_vms"`
 	NumberOfDatastores float64 `json:"number_of_datastores" redis:"number_of_datastores"`
 }
@@ -165,10 +178,38 @@ type HostBusAdapter struct {
 	Status               string                 `json:"status" redis:"status"`
 	Model                string                 `json:"model" redis:"model"`
 	Driver               string                 `json:"driver" redis:"driver"`
+	PCI                  string                 `json:"pci" redis:"pci"`
 	Protocol             string                 `json:"protocol" redis:"protocol"`
+	SpeedMbps            float64                `json:"speed_mbps" redis:"speed_mbps"`
 	IscsiInitiatorIQN    string                 `json:"iscsi_initiator_iqn" redis:"iscsi_initiator_iqn"`
+	IscsiSoftware        bool                   `json:"iscsi_software" redis:"iscsi_software"`
 	IscsiDiscoveryTarget []IscsiDiscoveryTarget `json:"iscsi_discovery_target" redis:"iscsi_discovery_target"`
 	IscsiStaticTarget    []IscsiStaticTarget    `json:"iscsi_static_target" redis:"iscsi_static_target"`
+	FcNodeWWN            string                 `json:"fc_node_wwn" redis:"fc_node_wwn"`
+	FcPortWWN            string                 `json:"fc_port_wwn" redis:"fc_port_wwn"`
+	// fabric, loop, pointToPoint or unknown. The name of the fabric itself is
+	// not exposed by the vSphere API.
+	FcPortType string `json:"fc_port_type" redis:"fc_port_type"`
+	// Physical nic or rdma device used by a software NVMe over TCP/RDMA adapter
+	AssociatedDevice string               `json:"associated_device" redis:"associated_device"`
+	NvmeControllers  []HostNvmeController `json:"nvme_controllers" redis:"nvme_controllers"`
+}
+
+type HostNvmeController struct {
+	Name             string              `json:"name" redis:"name"`
+	ControllerNumber int32               `json:"controller_number" redis:"controller_number"`
+	Subnqn           string              `json:"subnqn" redis:"subnqn"`
+	TransportType    string              `json:"transport_type" redis:"transport_type"`
+	NumberOfQueues   float64             `json:"number_of_queues" redis:"number_of_queues"`
+	QueueSize        float64             `json:"queue_size" redis:"queue_size"`
+	Namespaces       []HostNvmeNamespace `json:"namespaces" redis:"namespaces"`
+}
+
+type HostNvmeNamespace struct {
+	// Device name of the namespace, equal to the canonical name of the lun
+	Name          string  `json:"name" redis:"name"`
+	ID            int32   `json:"id" redis:"id"`
+	CapacityBytes float64 `json:"capacity_bytes" redis:"capacity_bytes"`
 }
 
 type IscsiDiscoveryTarget struct {
@@ -181,6 +222,22 @@ type IscsiStaticTarget struct {
 	Port            int32  `json:"port" redis:"port"`
 	IQN             string `json:"iqn" redis:"iqn"`
 	DiscoveryMethod string `json:"discovery_method" redis:"discovery_method"`
+	// Derived from the multipath paths to the target: online, offline or unknown
+	SessionState string `json:"session_state" redis:"session_state"`
+}
+
+// Return SessionState as float64
+//
+//	0 => unknown (no paths to the target)
+//	1 => offline (all paths dead or disabled)
+//	2 => online
+func (t IscsiStaticTarget) SessionStateFloat64() float64 {
+	if strings.EqualFold(t.SessionState, "offline") {
+		return 1.0
+	} else if strings.EqualFold(t.SessionState, "online") {
+		return 2.0
+	}
+	return 0
 }
 
 type MultipathPathInfo struct {
@@ -193,6 +250,9 @@ type MultipathPathInfo struct {
 
 	IscsiTargetAddress string
 	IscsiTargetIQN     string
+
+	FcTargetNodeWWN string
+	FcTargetPortWWN string
 }
 
 // Return state as a float64 number.
@@ -0,0 +1,72 @@
+package objects
+
+import "strings"
+
+type HostCPUPackage struct {
+	Index       int     `json:"index" redis:"index"`
+	Vendor      string  `json:"vendor" redis:"vendor"`
+	Description string  `json:"description" redis:"description"`
+	Hz          float64 `json:"hz" redis:"hz"`
+	Threads     float64 `json:"threads" redis:"threads"`
+}
+
+type HostPCIDevice struct {
+	ID         string `json:"id" redis:"id"`
+	ClassID    string `json:"class_id" redis:"class_id"`
+	VendorName string `json:"vendor_name" redis:"vendor_name"`
+	DeviceName string `json:"device_name" redis:"device_name"`
+
+	PassthruCapable bool `json:"passthru_capable" redis:"passthru_capable"`
+	PassthruEnabled bool `json:"passthru_enabled" redis:"passthru_enabled"`
+	PassthruActive  bool `json:"passthru_active" redis:"passthru_active"`
+}
+
+// Graphics device of a host, used for vSGA, vGPU or passthrough
+type HostGPU struct {
+	PCIID        string  `json:"pci_id" redis:"pci_id"`
+	VendorName   string  `json:"vendor_name" redis:"vendor_name"`
+	DeviceName   string  `json:"device_name" redis:"device_name"`
+	GraphicsType string  `json:"graphics_type" redis:"graphics_type"`
+	VGPUMode     string  `json:"vgpu_mode" redis:"vgpu_mode"`
+	MemoryBytes  float64 `json:"memory_bytes" redis:"memory_bytes"`
+	// VM's using the graphics device
+	VMs []ManagedObjectReference `json:"vms" redis:"vms"`
+}
+
+type HostPhysicalNIC struct {
+	Device string `json:"device" redis:"device"`
+	PCI    string `json:"pci" redis:"pci"`
+	Mac    string `json:"mac" redis:"mac"`
+	Driver string `json:"driver" redis:"driver"`
+	// Driver and firmware version are only available on vSphere 8.0U1 or later
+	DriverVersion   string `json:"driver_version" redis:"driver_version"`
+	FirmwareVersion string `json:"firmware_version" redis:"firmware_version"`
+	// 0 when the link is down
+	LinkSpeedMbps float64 `json:"link_speed_mbps" redis:"link_speed_mbps"`
+	LinkUp        bool    `json:"link_up" redis:"link_up"`
+	FullDuplex    bool    `json:"full_duplex" redis:"full_duplex"`
+}
+
+// Return the PCI device with the given id (ex. 0000:03:00.0)
+func (h *Host) GetPCIDevice(id string) *HostPCIDevice {
+	for _, d := range h.PCIDevices {
+		if d.ID == id {
+			return &d
+		}
+	}
+	return nil
+}
+
+// Return TPMAttestationStatus as float64
+//
+//	0 => unknown or no TPM
+//	1 => notAccepted
+//	2 => accepted
+func (h *Host) TPMAttestationStatusFloat64() float64 {
+	if strings.EqualFold(h.TPMAttestationStatus, "notAccepted") {
+		return 1.0
+	} else if strings.EqualFold(h.TPMAttestationStatus, "accepted") {
+		return 2.0
+	}
+	return 0
+}
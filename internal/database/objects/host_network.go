@@ -0,0 +1,46 @@
+package objects
+
+// Security policy of a standard vSwitch or portgroup
+type HostNetworkSecurityPolicy struct {
+	AllowPromiscuous bool `json:"allow_promiscuous" redis:"allow_promiscuous"`
+	MacChanges       bool `json:"mac_changes" redis:"mac_changes"`
+	ForgedTransmits  bool `json:"forged_transmits" redis:"forged_transmits"`
+}
+
+// Standard vSwitch of a host
+type HostVirtualSwitch struct {
+	Name     string  `json:"name" redis:"name"`
+	MTU      float64 `json:"mtu" redis:"mtu"`
+	NumPorts float64 `json:"num_ports" redis:"num_ports"`
+	// Device names of the physical nics (ex. vmnic0)
+	Uplinks  []string                  `json:"uplinks" redis:"uplinks"`
+	Security HostNetworkSecurityPolicy `json:"security" redis:"security"`
+}
+
+// Portgroup on a standard vSwitch
+type HostPortGroup struct {
+	Name    string  `json:"name" redis:"name"`
+	VSwitch string  `json:"vswitch" redis:"vswitch"`
+	VlanID  float64 `json:"vlan_id" redis:"vlan_id"`
+	// Number of ports in use by vm's and vmkernel adapters
+	NumPorts float64 `json:"num_ports" redis:"num_ports"`
+	// Effective security policy, including the values inherited from the vSwitch
+	Security HostNetworkSecurityPolicy `json:"security" redis:"security"`
+}
+
+// VMkernel network adapter (vmk) of a host
+type HostVMKernelNIC struct {
+	Device string `json:"device" redis:"device"`
+	// Standard portgroup name, empty when connected to a distributed switch
+	PortGroup string `json:"portgroup" redis:"portgroup"`
+	// Uuid of the distributed switch, empty when connected to a standard portgroup
+	DistributedSwitch string  `json:"distributed_switch" redis:"distributed_switch"`
+	Mac               string  `json:"mac" redis:"mac"`
+	IP                string  `json:"ip" redis:"ip"`
+	SubnetMask        string  `json:"subnet_mask" redis:"subnet_mask"`
+	DHCP              bool    `json:"dhcp" redis:"dhcp"`
+	MTU               float64 `json:"mtu" redis:"mtu"`
+	NetStack          string  `json:"netstack" redis:"netstack"`
+	// Enabled services (ex. management, vmotion, vsan)
+	Services []string `json:"services" redis:"services"`
+}
@@ -0,0 +1,20 @@
+package objects
+
+import "time"
+
+// Desired advanced options of a host together with their actual value
+type HostOptions struct {
+	Timestamp time.Time              `json:"timestamp" redis:"timestamp"`
+	Host      ManagedObjectReference `json:"host" redis:"host"`
+	Options   []HostOption           `json:"options" redis:"options"`
+}
+
+type HostOption struct {
+	Key      string `json:"key" redis:"key"`
+	Rule     string `json:"rule" redis:"rule"`
+	Expected string `json:"expected" redis:"expected"`
+	Actual   string `json:"actual" redis:"actual"`
+	// Option does not exist on the host
+	Missing bool `json:"missing" redis:"missing"`
+	Drift   bool `json:"drift" redis:"drift"`
+}
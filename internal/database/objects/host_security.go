@@ -0,0 +1,63 @@
+package objects
+
+import (
+	"strings"
+	"time"
+)
+
+type HostSecurity struct {
+	Timestamp time.Time              `json:"timestamp" redis:"timestamp"`
+	Host      ManagedObjectReference `json:"host" redis:"host"`
+
+	LockdownMode string        `json:"lockdown_mode" redis:"lockdown_mode"`
+	Services     []HostService `json:"services" redis:"services"`
+
+	// UserVars.ESXiShellTimeOut and UserVars.ESXiShellInteractiveTimeOut in
+	// seconds, they apply to both the ESXi shell and SSH
+	ShellTimeout            float64 `json:"shell_timeout" redis:"shell_timeout"`
+	ShellInteractiveTimeout float64 `json:"shell_interactive_timeout" redis:"shell_interactive_timeout"`
+	// False when the options could not be queried, the timeouts are unknown
+	// in that case and not 0 (no timeout)
+	ShellTimeoutsQueried bool `json:"shell_timeouts_queried" redis:"shell_timeouts_queried"`
+
+	NTPServers []string `json:"ntp_servers" redis:"ntp_servers"`
+	// Difference between the host clock and the clock of the exporter
+	ClockDrift float64 `json:"clock_drift" redis:"clock_drift"`
+	// False when the host time could not be queried
+	ClockDriftQueried bool `json:"clock_drift_queried" redis:"clock_drift_queried"`
+
+	CertificateNotAfter time.Time `json:"certificate_not_after" redis:"certificate_not_after"`
+	CertificateSubject  string    `json:"certificate_subject" redis:"certificate_subject"`
+	CertificateIssuer   string    `json:"certificate_issuer" redis:"certificate_issuer"`
+
+	FirewallIncomingBlocked bool `json:"firewall_incoming_blocked" redis:"firewall_incoming_blocked"`
+	FirewallOutgoingBlocked bool `json:"firewall_outgoing_blocked" redis:"firewall_outgoing_blocked"`
+}
+
+type HostService struct {
+	Key     string `json:"key" redis:"key"`
+	Label   string `json:"label" redis:"label"`
+	Running bool   `json:"running" redis:"running"`
+	// on, off or automatic
+	Policy string `json:"policy" redis:"policy"`
+}
+
+// Return LockdownMode as float64
+//
+//	0 => lockdownDisabled or unknown
+//	1 => lockdownNormal
+//	2 => lockdownStrict
+func (s *HostSecurity) LockdownModeFloat64() float64 {
+	if strings.EqualFold(s.LockdownMode, "lockdownNormal") {
+		return 1.0
+	} else if strings.EqualFold(s.LockdownMode, "lockdownStrict") {
+		return 2.0
+	}
+	return 0
+}
+
+// The default firewall policy is restrictive when both incoming and outgoing
+// traffic is blocked
+func (s *HostSecurity) FirewallRestrictive() bool {
+	return s.FirewallIncomingBlocked && s.FirewallOutgoingBlocked
+}
@@ -0,0 +1,43 @@
+package objects
+
+import (
+	"strings"
+	"time"
+)
+
+const LicenseKeyEvaluation = "00000-00000-00000-00000-00000"
+
+type License struct {
+	Timestamp time.Time `json:"timestamp" redis:"timestamp"`
+	// License key with all but the last group masked
+	LicenseKey string  `json:"license_key" redis:"license_key"`
+	Name       string  `json:"name" redis:"name"`
+	EditionKey string  `json:"edition_key" redis:"edition_key"`
+	CostUnit   string  `json:"cost_unit" redis:"cost_unit"`
+	Total      float64 `json:"total" redis:"total"`
+	Used       float64 `json:"used" redis:"used"`
+	// Zero when the license does not expire
+	ExpirationDate time.Time `json:"expiration_date" redis:"expiration_date"`
+}
+
+// License assigned to a host
+type HostLicense struct {
+	Timestamp  time.Time              `json:"timestamp" redis:"timestamp"`
+	Host       ManagedObjectReference `json:"host" redis:"host"`
+	LicenseKey string                 `json:"license_key" redis:"license_key"`
+	Name       string                 `json:"name" redis:"name"`
+	EditionKey string                 `json:"edition_key" redis:"edition_key"`
+	Evaluation bool                   `json:"evaluation" redis:"evaluation"`
+}
+
+// Mask all but the last group of a license key
+func MaskLicenseKey(key string) string {
+	if key == LicenseKeyEvaluation {
+		return key
+	}
+	groups := strings.Split(key, "-")
+	for i := 0; i < len(groups)-1; i++ {
+		groups[i] = strings.Repeat("X", len(groups[i]))
+	}
+	return strings.Join(groups, "-")
+}
@@ -0,0 +1,28 @@
+package objects
+
+import "time"
+
+// VMDK files found on a datastore that are not used by any registered vm
+// or template
+type OrphanedVMDKs struct {
+	Timestamp     time.Time              `json:"timestamp" redis:"timestamp"`
+	Datastore     ManagedObjectReference `json:"datastore" redis:"datastore"`
+	DatastoreName string                 `json:"datastore_name" redis:"datastore_name"`
+	Files         []OrphanedVMDK         `json:"files" redis:"files"`
+}
+
+type OrphanedVMDK struct {
+	// Datastore path, ex. [ds01] old-vm/old-vm.vmdk
+	Path         string    `json:"path" redis:"path"`
+	Size         float64   `json:"size" redis:"size"`
+	Modification time.Time `json:"modification" redis:"modification"`
+}
+
+// Return the combined size of all orphaned files in bytes
+func (o *OrphanedVMDKs) TotalSize() float64 {
+	total := 0.0
+	for _, f := range o.Files {
+		total += f.Size
+	}
+	return total
+}
@@ -1,9 +1,23 @@
 package objects
 
+import "strings"
+
 type ParentChain struct {
 	DC           string
 	Cluster      string
 	ResourcePool string
 	SPOD         string
 	Chain        []string
+	Path         []string
+}
+
+// PrependPath adds an inventory name in front of the path. The parent chain
+// is walked bottom-up, so every parent is added in front of its children.
+func (c *ParentChain) PrependPath(name string) {
+	c.Path = append([]string{name}, c.Path...)
+}
+
+// FolderPath returns the inventory path of the chain, ex. /DC1/vm/prod
+func (c *ParentChain) FolderPath() string {
+	return "/" + strings.Join(c.Path, "/")
 }
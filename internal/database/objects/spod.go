@@ -1,6 +1,9 @@
 package objects
 
-import "time"
+import (
+	"strings"
+	"time"
+)
 
 type StoragePod struct {
 	Timestamp  time.Time               `json:"timestamp" redis:"timestamp"`
@@ -11,4 +14,31 @@ type StoragePod struct {
 
 	Capacity  float64 `json:"capacity" redis:"capacity"`
 	FreeSpace float64 `json:"free_space" redis:"free_space"`
+	// Datastores that are member of the storage pod
+	Datastores []ManagedObjectReference `json:"datastores" redis:"datastores"`
+
+	SDRSEnabled                bool    `json:"sdrs_enabled" redis:"sdrs_enabled"`
+	SDRSBehavior               string  `json:"sdrs_behavior" redis:"sdrs_behavior"`
+	SDRSIoLoadBalanceEnabled   bool    `json:"sdrs_io_load_balance_enabled" redis:"sdrs_io_load_balance_enabled"`
+	SDRSLoadBalanceInterval    float64 `json:"sdrs_load_balance_interval" redis:"sdrs_load_balance_interval"`
+	SDRSPendingRecommendations float64 `json:"sdrs_pending_recommendations" redis:"sdrs_pending_recommendations"`
+	SDRSFaults                 float64 `json:"sdrs_faults" redis:"sdrs_faults"`
+	// utilization or freeSpace
+	SDRSSpaceThresholdMode        string  `json:"sdrs_space_threshold_mode" redis:"sdrs_space_threshold_mode"`
+	SDRSSpaceUtilizationThreshold float64 `json:"sdrs_space_utilization_threshold" redis:"sdrs_space_utilization_threshold"`
+	SDRSFreeSpaceThreshold        float64 `json:"sdrs_free_space_threshold" redis:"sdrs_free_space_threshold"`
+	// I/O latency threshold in seconds
+	SDRSIoLatencyThreshold       float64 `json:"sdrs_io_latency_threshold" redis:"sdrs_io_latency_threshold"`
+	SDRSIoLoadImbalanceThreshold float64 `json:"sdrs_io_load_imbalance_threshold" redis:"sdrs_io_load_imbalance_threshold"`
+}
+
+// Return SDRSBehavior as float64
+//
+//	0 => manual or unknown
+//	1 => automated
+func (p *StoragePod) SDRSBehaviorFloat64() float64 {
+	if strings.EqualFold(p.SDRSBehavior, "automated") {
+		return 1.0
+	}
+	return 0
 }
@@ -0,0 +1,58 @@
+package objects
+
+import (
+	"strings"
+	"time"
+)
+
+const (
+	StoragePolicyEntityHome = "home"
+	StoragePolicyEntityDisk = "disk"
+)
+
+// SPBM storage policy
+type StoragePolicy struct {
+	Timestamp   time.Time `json:"timestamp" redis:"timestamp"`
+	ID          string    `json:"id" redis:"id"`
+	Name        string    `json:"name" redis:"name"`
+	Description string    `json:"description" redis:"description"`
+	// Ids of the datastores that satisfy the policy requirements
+	CompatibleDatastores   []string `json:"compatible_datastores" redis:"compatible_datastores"`
+	IncompatibleDatastores []string `json:"incompatible_datastores" redis:"incompatible_datastores"`
+}
+
+// Storage policies assigned to the home and the disks of a vm
+type VMStoragePolicy struct {
+	Timestamp time.Time               `json:"timestamp" redis:"timestamp"`
+	VM        ManagedObjectReference  `json:"vm" redis:"vm"`
+	Entities  []VMStoragePolicyEntity `json:"entities" redis:"entities"`
+}
+
+type VMStoragePolicyEntity struct {
+	// home or disk
+	Type string `json:"type" redis:"type"`
+	// Device label of the disk, empty for the vm home
+	Disk       string `json:"disk" redis:"disk"`
+	PolicyID   string `json:"policy_id" redis:"policy_id"`
+	PolicyName string `json:"policy_name" redis:"policy_name"`
+	// compliant, nonCompliant, outOfDate, notApplicable or unknown
+	ComplianceStatus string `json:"compliance_status" redis:"compliance_status"`
+}
+
+// Return ComplianceStatus as float64
+//
+//	0 => unknown or notApplicable
+//	1 => nonCompliant
+//	2 => outOfDate
+//	3 => compliant
+func (e *VMStoragePolicyEntity) ComplianceStatusFloat64() float64 {
+	switch strings.ToLower(e.ComplianceStatus) {
+	case "noncompliant":
+		return 1.0
+	case "outofdate":
+		return 2.0
+	case "compliant":
+		return 3.0
+	}
+	return 0
+}
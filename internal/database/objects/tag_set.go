@@ -1,13 +1,80 @@
 package objects
 
+import (
+	"encoding/json"
+	"slices"
+	"strings"
+)
+
 type TagSet struct {
 	ObjectRef ManagedObjectReference `json:"object_ref" redis:"object_ref"`
-	Tags      map[string]string      `json:"tags" redis:"tags"`
+	Tags      map[string][]string    `json:"tags" redis:"tags"`
+}
+
+func NewTagSet(ref ManagedObjectReference) TagSet {
+	return TagSet{
+		ObjectRef: ref,
+		Tags:      map[string][]string{},
+	}
+}
+
+// AddTag adds a tag to a category. The tags of a category are kept sorted
+// and a tag is only added once.
+func (o *TagSet) AddTag(catName string, tagName string) {
+	if o.Tags == nil {
+		o.Tags = map[string][]string{}
+	}
+	tags := o.Tags[catName]
+	if i, found := slices.BinarySearch(tags, tagName); !found {
+		o.Tags[catName] = slices.Insert(tags, i, tagName)
+	}
 }
 
+// GetTags returns all tags attached to the object for a given category
+func (o *TagSet) GetTags(catName string) []string {
+	if tags, ok := o.Tags[catName]; ok {
+		return tags
+	}
+	return []string{}
+}
+
+// GetTag returns the tags of a category joined by a comma
 func (o *TagSet) GetTag(catName string) string {
-	if tag, ok := o.Tags[catName]; ok {
-		return tag
+	return o.JoinTags(catName, ",")
+}
+
+func (o *TagSet) JoinTags(catName string, sep string) string {
+	return strings.Join(o.GetTags(catName), sep)
+}
+
+// UnmarshalJSON also accepts the older format where every category only had
+// a single tag (map[string]string). This avoids failures on cached entries
+// written by previous versions.
+func (o *TagSet) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		ObjectRef ManagedObjectReference     `json:"object_ref"`
+		Tags      map[string]json.RawMessage `json:"tags"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	o.ObjectRef = raw.ObjectRef
+	o.Tags = map[string][]string{}
+	for cat, value := range raw.Tags {
+		var tags []string
+		if err := json.Unmarshal(value, &tags); err == nil {
+			for _, tag := range tags {
+				o.AddTag(cat, tag)
+			}
+			continue
+		}
+
+		var tag string
+		if err := json.Unmarshal(value, &tag); err != nil {
+			return err
+		}
+		o.AddTag(cat, tag)
 	}
-	return ""
+	return nil
 }
@@ -16,6 +16,16 @@ const (
 	ManagedObjectTypesTag             = ManagedObjectTypes("Tag")
 	ManagedObjectTypesTagSet          = ManagedObjectTypes("TagSet")
 	ManagedObjectTypesVirtualMachine  = ManagedObjectTypes("VirtualMachine")
+	ManagedObjectTypesAttributeSet    = ManagedObjectTypes("AttributeSet")
+	ManagedObjectTypesHostSecurity    = ManagedObjectTypes("HostSecurity")
+	ManagedObjectTypesHostOptions     = ManagedObjectTypes("HostOptions")
+	ManagedObjectTypesLicense         = ManagedObjectTypes("License")
+	ManagedObjectTypesHostLicense     = ManagedObjectTypes("HostLicense")
+	ManagedObjectTypesOrphanedVMDKs   = ManagedObjectTypes("OrphanedVMDKs")
+	ManagedObjectTypesStoragePolicy   = ManagedObjectTypes("StoragePolicy")
+	ManagedObjectTypesVMStoragePolicy = ManagedObjectTypes("VMStoragePolicy")
+	ManagedObjectTypesContentLibrary  = ManagedObjectTypes("ContentLibrary")
+	ManagedObjectTypesVCenter         = ManagedObjectTypes("VCenter")
 )
 
 const (
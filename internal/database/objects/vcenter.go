@@ -0,0 +1,43 @@
+package objects
+
+import "time"
+
+// About info, server time and sessions of the vCenter the exporter is
+// connected to
+type VCenter struct {
+	Timestamp     time.Time `json:"timestamp" redis:"timestamp"`
+	Name          string    `json:"name" redis:"name"`
+	FullName      string    `json:"full_name" redis:"full_name"`
+	Version       string    `json:"version" redis:"version"`
+	Build         string    `json:"build" redis:"build"`
+	APIType       string    `json:"api_type" redis:"api_type"`
+	APIVersion    string    `json:"api_version" redis:"api_version"`
+	InstanceUUID  string    `json:"instance_uuid" redis:"instance_uuid"`
+	OSType        string    `json:"os_type" redis:"os_type"`
+	ProductLineID string    `json:"product_line_id" redis:"product_line_id"`
+
+	ServerTime time.Time `json:"server_time" redis:"server_time"`
+	// Server time minus the local time of the exporter, corrected for the
+	// round trip of the request
+	ClockSkewSeconds float64 `json:"clock_skew_seconds" redis:"clock_skew_seconds"`
+
+	Sessions []VCenterSession `json:"sessions" redis:"sessions"`
+}
+
+type VCenterSession struct {
+	UserName       string    `json:"user_name" redis:"user_name"`
+	IPAddress      string    `json:"ip_address" redis:"ip_address"`
+	UserAgent      string    `json:"user_agent" redis:"user_agent"`
+	LoginTime      time.Time `json:"login_time" redis:"login_time"`
+	LastActiveTime time.Time `json:"last_active_time" redis:"last_active_time"`
+	CallCount      float64   `json:"call_count" redis:"call_count"`
+}
+
+// Seconds since the last activity of the session, relative to the server time
+func (s VCenterSession) IdleSeconds(serverTime time.Time) float64 {
+	idle := serverTime.Sub(s.LastActiveTime).Seconds()
+	if idle < 0 {
+		return 0
+	}
+	return idle
+}
@@ -0,0 +1,54 @@
+package objects
+
+import "time"
+
+type VirtualApp struct {
+	Timestamp    time.Time                `json:"timestamp" redis:"timestamp"`
+	Self         ManagedObjectReference   `json:"self" redis:"self"`
+	Parent       *ManagedObjectReference  `json:"parent" redis:"parent"`
+	ParentFolder *ManagedObjectReference  `json:"parent_folder" redis:"parent_folder"`
+	ParentVApp   *ManagedObjectReference  `json:"parent_vapp" redis:"parent_vapp"`
+	Name         string                   `json:"name" redis:"name"`
+	Datacenter   string                   `json:"datacenter" redis:"datacenter"`
+	Cluster      string                   `json:"cluster" redis:"cluster"`
+	FolderPath   string                   `json:"folder_path" redis:"folder_path"`
+	Annotation   string                   `json:"annotation" redis:"annotation"`
+	Product      string                   `json:"product" redis:"product"`
+	Version      string                   `json:"version" redis:"version"`
+	State        string                   `json:"state" redis:"state"`
+	VMs          []ManagedObjectReference `json:"vms" redis:"vms"`
+
+	OverallCPUUsage  float64 `json:"overall_cpu_usage" redis:"overall_cpu_usage"`
+	OverallCPUDemand float64 `json:"overall_cpu_demand" redis:"overall_cpu_demand"`
+	GuestMemoryUsage float64 `json:"guest_memory_usage" redis:"guest_memory_usage"`
+	HostMemoryUsage  float64 `json:"host_memory_usage" redis:"host_memory_usage"`
+	OverallStatus    string  `json:"overall_status" redis:"overall_status"`
+}
+
+// Return State as float64
+//
+//	0 => stopped
+//	1 => started
+//	2 => starting
+//	3 => stopping
+func (a *VirtualApp) StateFloat64() float64 {
+	switch a.State {
+	case "started":
+		return 1.0
+	case "starting":
+		return 2.0
+	case "stopping":
+		return 3.0
+	}
+	return 0.0
+}
+
+// Return OverallStatus as float64
+//
+//	0 => (Gray) The status is unknown.
+//	1 => (Red) The entity definitely has a problem.
+//	2 => (Yellow) The entity might have a problem.
+//	3 => (Green) The entity is OK.
+func (a *VirtualApp) OverallStatusFloat64() float64 {
+	return ColorToFloat64(a.OverallStatus)
+}
@@ -11,14 +11,18 @@ type VirtualMachine struct {
 	TimeCreated       time.Time               `json:"time_created" redis:"time_created"`
 	Self              ManagedObjectReference  `json:"self" redis:"self"`
 	Parent            *ManagedObjectReference `json:"parent" redis:"parent"`
+	ParentVApp        *ManagedObjectReference `json:"parent_vapp" redis:"parent_vapp"`
 	Name              string                  `json:"name" redis:"name"`
 	UUID              string                  `json:"uuid" redis:"uuid"`
 	Template          bool                    `json:"template" redis:"template"`
-	IsecAnnotation    *IsecAnnotation         `json:"isec_annotation" redis:"isec_annotation"`
+	Annotation        string                  `json:"annotation" redis:"annotation"`
 
 	// Cluster      string `json:"cluster" redis:"cluster"` //-> see HostInfo
-	Datacenter   string `json:"datacenter" redis:"datacenter"`
-	ResourcePool string `json:"resource_pool" redis:"resource_pool"`
+	Datacenter      string                  `json:"datacenter" redis:"datacenter"`
+	ResourcePool    string                  `json:"resource_pool" redis:"resource_pool"`
+	ResourcePoolRef *ManagedObjectReference `json:"resource_pool_ref" redis:"resource_pool_ref"`
+	VApp            string                  `json:"vapp" redis:"vapp"`
+	FolderPath      string                  `json:"folder_path" redis:"folder_path"`
 
 	NumCPU                      float64 `json:"num_cpu" redis:"num_cpu"`
 	NumCoresPerSocket           float64 `json:"num_cores_per_socket" redis:"num_cores_per_socket"`
@@ -35,12 +39,16 @@ type VirtualMachine struct {
 
 	UptimeSeconds        float64 `json:"uptime_seconds" redis:"uptime_seconds"`
 	NumSnapshot          float64 `json:"num_snapshot" redis:"num_snapshot"`
+	SnapshotSize         float64 `json:"snapshot_size" redis:"snapshot_size"`
+	ConsolidationNeeded  bool    `json:"consolidation_needed" redis:"consolidation_needed"`
 	PowerState           string  `json:"power_state" redis:"power_state"`
 	OverallStatus        string  `json:"overall_status" redis:"overall_status"`
 	GuestHeartbeatStatus string  `json:"guest_heartbeat_status" redis:"guest_heartbeat_status"`
 	GuestToolsStatus     string  `json:"guest_tools_status" redis:"guest_tools_status"`
 	GuestToolsVersion    string  `json:"guest_tools_version" redis:"guest_tools_version"`
 	GuestID              string  `json:"guest_id" redis:"guest_id"`
+	GuestHostname        string  `json:"guest_hostname" redis:"guest_hostname"`
+	GuestFullName        string  `json:"guest_full_name" redis:"guest_full_name"`
 
 	// Legacy metrics
 	DistributedCPUEntitlement    float64 `json:"distributed_cpu_entitlement" redis:"distributed_cpu_entitlement"`
@@ -58,36 +66,93 @@ type VirtualMachine struct {
 	SsdSwappedMemory             float64 `json:"ssd_swapped_memory" redis:"ssd_swapped_memory"`
 
 	// Advanced network metrics
-	GuestNetwork []VirtualMachineGuestNet `json:"network" redis:"network"`
+	NIC []VirtualMachineNIC `json:"nic" redis:"nic"`
 
-	Disk     []VirtualMachineDisk     `json:"disk" redis:"disk"`
-	Snapshot []VirtualMachineSnapshot `json:"snapshot" redis:"snapshot"`
+	// Filesystems reported by VMware Tools
+	GuestDisk []VirtualMachineGuestDisk `json:"guest_disk" redis:"guest_disk"`
+
+	Disk           []VirtualMachineDisk           `json:"disk" redis:"disk"`
+	DatastoreUsage []VirtualMachineDatastoreUsage `json:"datastore_usage" redis:"datastore_usage"`
+	Snapshot       []VirtualMachineSnapshot       `json:"snapshot" redis:"snapshot"`
 
 	HostInfo VirtualMachineHostInfo `json:"host_info" redis:"host_info"`
+
+	// Only set when the vm sensor collects the configuration settings
+	ConfigSettings *VirtualMachineConfigSettings `json:"config_settings" redis:"config_settings"`
+}
+
+type VirtualMachineNIC struct {
+	Key            int32  `json:"key" redis:"key"`
+	Label          string `json:"label" redis:"label"`
+	AdapterType    string `json:"adapter_type" redis:"adapter_type"`
+	MacAddress     string `json:"mac_address" redis:"mac_address"`
+	Connected      bool   `json:"connected" redis:"connected"`
+	StartConnected bool   `json:"start_connected" redis:"start_connected"`
+	// network, dvs or opaque
+	BackingType string `json:"backing_type" redis:"backing_type"`
+	// Value of the Network or DistributedVirtualPortgroup reference
+	NetworkID string `json:"network_id" redis:"network_id"`
+	Network   string `json:"network" redis:"network"`
+	DVSUUID   string `json:"dvs_uuid" redis:"dvs_uuid"`
+	PortKey   string `json:"port_key" redis:"port_key"`
+
+	IpAddress []VirtualMachineIpAddress `json:"ip_address" redis:"ip_address"`
+}
+
+type VirtualMachineIpAddress struct {
+	Address      string  `json:"address" redis:"address"`
+	PrefixLength float64 `json:"prefix_length" redis:"prefix_length"`
+	// ipv4 or ipv6
+	Family string `json:"family" redis:"family"`
 }
 
-type VirtualMachineGuestNet struct {
-	// Network    string `json:"network" redis:"network"`
-	MacAddress string `json:"mac_address" redis:"mac_address"`
-	IpAddress  string `json:"ip_address" redis:"ip_address"`
-	Connected  bool   `json:"connected" redis:"connected"`
+type VirtualMachineGuestDisk struct {
+	DiskPath       string  `json:"disk_path" redis:"disk_path"`
+	FilesystemType string  `json:"filesystem_type" redis:"filesystem_type"`
+	Capacity       float64 `json:"capacity" redis:"capacity"`
+	FreeSpace      float64 `json:"free_space" redis:"free_space"`
 }
 
 type VirtualMachineDisk struct {
-	UUID            string  `json:"uuid" redis:"uuid"`
-	VMDKFile        string  `json:"vmdk_file" redis:"vmdk_file"`
-	ThinProvisioned bool    `json:"thin_provisioned" redis:"thin_provisioned"`
-	Capacity        float64 `json:"capacity" redis:"capacity"`
-	Used            float64 `json:"used" redis:"used"`
+	Key             int32                   `json:"key" redis:"key"`
+	Label           string                  `json:"label" redis:"label"`
+	UUID            string                  `json:"uuid" redis:"uuid"`
+	VMDKFile        string                  `json:"vmdk_file" redis:"vmdk_file"`
+	BackingType     string                  `json:"backing_type" redis:"backing_type"`
+	DiskMode        string                  `json:"disk_mode" redis:"disk_mode"`
+	ThinProvisioned bool                    `json:"thin_provisioned" redis:"thin_provisioned"`
+	Datastore       *ManagedObjectReference `json:"datastore" redis:"datastore"`
+	DatastoreName   string                  `json:"datastore_name" redis:"datastore_name"`
+	ControllerType  string                  `json:"controller_type" redis:"controller_type"`
+	BusNumber       int32                   `json:"bus_number" redis:"bus_number"`
+	UnitNumber      int32                   `json:"unit_number" redis:"unit_number"`
+	Capacity        float64                 `json:"capacity" redis:"capacity"`
+	Used            float64                 `json:"used" redis:"used"`
+}
+
+// Storage used by a vm on a single datastore
+type VirtualMachineDatastoreUsage struct {
+	Datastore     ManagedObjectReference `json:"datastore" redis:"datastore"`
+	DatastoreName string                 `json:"datastore_name" redis:"datastore_name"`
+	Committed     float64                `json:"committed" redis:"committed"`
+	Uncommitted   float64                `json:"uncommitted" redis:"uncommitted"`
+	Unshared      float64                `json:"unshared" redis:"unshared"`
 }
 
 type VirtualMachineSnapshot struct {
-	Name         string
-	CreationTime time.Time
+	ID           string    `json:"id" redis:"id"`
+	Name         string    `json:"name" redis:"name"`
+	CreationTime time.Time `json:"creation_time" redis:"creation_time"`
+	// Depth of the snapshot in the snapshot chain, the root snapshot has depth 1
+	Depth   float64 `json:"depth" redis:"depth"`
+	Memory  bool    `json:"memory" redis:"memory"`
+	Current bool    `json:"current" redis:"current"`
+	Size    float64 `json:"size" redis:"size"`
 }
 
 type VirtualMachineHostInfo struct {
 	Host       string `json:"host" redis:"host"`
+	HostID     string `json:"host_id" redis:"host_id"`
 	Datacenter string `json:"datacenter" redis:"datacenter"`
 	Cluster    string `json:"cluster" redis:"cluster"`
 }
@@ -148,9 +213,3 @@ func (vm *VirtualMachine) GuestToolsStatusFloat64() float64 {
 	}
 	return 0
 }
-
-type IsecAnnotation struct {
-	Criticality string `json:"crit" redis:"crit"`
-	Responsable string `json:"resp" redis:"resp"`
-	Service     string `json:"svc" redis:"svc"`
-}
@@ -0,0 +1,87 @@
+package objects
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Configuration settings of a vm which are audited for compliance
+type VirtualMachineConfigSettings struct {
+	HardwareVersion    string `json:"hardware_version" redis:"hardware_version"`
+	ToolsUpgradePolicy string `json:"tools_upgrade_policy" redis:"tools_upgrade_policy"`
+	CPUHotAdd          bool   `json:"cpu_hot_add" redis:"cpu_hot_add"`
+	MemoryHotAdd       bool   `json:"memory_hot_add" redis:"memory_hot_add"`
+	Firmware           string `json:"firmware" redis:"firmware"`
+	SecureBoot         bool   `json:"secure_boot" redis:"secure_boot"`
+	VTPM               bool   `json:"vtpm" redis:"vtpm"`
+	Encrypted          bool   `json:"encrypted" redis:"encrypted"`
+	LatencySensitivity string `json:"latency_sensitivity" redis:"latency_sensitivity"`
+	// CPU limit in MHz, -1 means unlimited
+	CPULimit float64 `json:"cpu_limit" redis:"cpu_limit"`
+	// Memory limit in bytes, -1 means unlimited
+	MemoryLimit float64 `json:"memory_limit" redis:"memory_limit"`
+
+	RemovableDevices []VirtualMachineRemovableDevice `json:"removable_devices" redis:"removable_devices"`
+}
+
+// CD-ROM or floppy drive of a vm
+type VirtualMachineRemovableDevice struct {
+	// cdrom or floppy
+	Type           string `json:"type" redis:"type"`
+	Label          string `json:"label" redis:"label"`
+	Image          string `json:"image" redis:"image"`
+	Connected      bool   `json:"connected" redis:"connected"`
+	StartConnected bool   `json:"start_connected" redis:"start_connected"`
+}
+
+// Return the hardware version (ex. vmx-19) as number
+func (c *VirtualMachineConfigSettings) HardwareVersionFloat64() float64 {
+	version, err := strconv.ParseFloat(strings.TrimPrefix(c.HardwareVersion, "vmx-"), 64)
+	if err != nil {
+		return 0
+	}
+	return version
+}
+
+func (c *VirtualMachineConfigSettings) removableDeviceConnected(deviceType string) bool {
+	for _, d := range c.RemovableDevices {
+		if d.Type == deviceType && d.Connected {
+			return true
+		}
+	}
+	return false
+}
+
+// Setting returns the value of a setting as used by the compliance rules.
+// Unknown settings result in an empty string.
+func (c *VirtualMachineConfigSettings) Setting(name string) string {
+	switch name {
+	case "hardware_version":
+		return strconv.FormatFloat(c.HardwareVersionFloat64(), 'f', -1, 64)
+	case "tools_upgrade_policy":
+		return c.ToolsUpgradePolicy
+	case "cpu_hot_add":
+		return strconv.FormatBool(c.CPUHotAdd)
+	case "memory_hot_add":
+		return strconv.FormatBool(c.MemoryHotAdd)
+	case "firmware":
+		return c.Firmware
+	case "secure_boot":
+		return strconv.FormatBool(c.SecureBoot)
+	case "vtpm":
+		return strconv.FormatBool(c.VTPM)
+	case "encrypted":
+		return strconv.FormatBool(c.Encrypted)
+	case "latency_sensitivity":
+		return c.LatencySensitivity
+	case "cpu_limit":
+		return strconv.FormatFloat(c.CPULimit, 'f', -1, 64)
+	case "memory_limit":
+		return strconv.FormatFloat(c.MemoryLimit, 'f', -1, 64)
+	case "cdrom_connected":
+		return strconv.FormatBool(c.removableDeviceConnected("cdrom"))
+	case "floppy_connected":
+		return strconv.FormatBool(c.removableDeviceConnected("floppy"))
+	}
+	return ""
+}
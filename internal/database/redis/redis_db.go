@@ -158,8 +158,18 @@ func (db *DB) SetStoragePod(ctx context.Context, spod objects.StoragePod, ttl ti
 	return nil
 }
 
+// SetResourcePool always stores pools as ResourcePool, so vApps returned by
+// the resource pool sensor don't overwrite the objects of the vApp sensor
 func (db *DB) SetResourcePool(ctx context.Context, rp objects.ResourcePool, ttl time.Duration) error {
-	err := db.SetObj(ctx, rp.Self, rp, ttl)
+	err := db.Set(ctx, objects.ManagedObjectTypesResourcePool, rp.Self.ID(), rp, ttl)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (db *DB) SetVirtualApp(ctx context.Context, vApp objects.VirtualApp, ttl time.Duration) error {
+	err := db.SetObj(ctx, vApp.Self, vApp, ttl)
 	if err != nil {
 		return err
 	}
@@ -174,6 +184,78 @@ func (db *DB) SetVM(ctx context.Context, vm objects.VirtualMachine, ttl time.Dur
 	return nil
 }
 
+func (db *DB) SetVCenter(ctx context.Context, vc objects.VCenter, ttl time.Duration) error {
+	err := db.Set(ctx, objects.ManagedObjectTypesVCenter, vc.InstanceUUID, vc, ttl)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (db *DB) SetContentLibrary(ctx context.Context, library objects.ContentLibrary, ttl time.Duration) error {
+	err := db.Set(ctx, objects.ManagedObjectTypesContentLibrary, library.ID, library, ttl)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (db *DB) SetVMStoragePolicy(ctx context.Context, policy objects.VMStoragePolicy, ttl time.Duration) error {
+	err := db.Set(ctx, objects.ManagedObjectTypesVMStoragePolicy, policy.VM.Value, policy, ttl)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (db *DB) SetStoragePolicy(ctx context.Context, policy objects.StoragePolicy, ttl time.Duration) error {
+	err := db.Set(ctx, objects.ManagedObjectTypesStoragePolicy, policy.ID, policy, ttl)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (db *DB) SetOrphanedVMDKs(ctx context.Context, orphans objects.OrphanedVMDKs, ttl time.Duration) error {
+	err := db.Set(ctx, objects.ManagedObjectTypesOrphanedVMDKs, orphans.Datastore.Value, orphans, ttl)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (db *DB) SetHostLicense(ctx context.Context, lic objects.HostLicense, ttl time.Duration) error {
+	err := db.Set(ctx, objects.ManagedObjectTypesHostLicense, lic.Host.Value, lic, ttl)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (db *DB) SetLicense(ctx context.Context, lic objects.License, ttl time.Duration) error {
+	err := db.Set(ctx, objects.ManagedObjectTypesLicense, lic.LicenseKey, lic, ttl)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (db *DB) SetHostOptions(ctx context.Context, opts objects.HostOptions, ttl time.Duration) error {
+	err := db.Set(ctx, objects.ManagedObjectTypesHostOptions, opts.Host.Value, opts, ttl)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (db *DB) SetHostSecurity(ctx context.Context, sec objects.HostSecurity, ttl time.Duration) error {
+	err := db.Set(ctx, objects.ManagedObjectTypesHostSecurity, sec.Host.Value, sec, ttl)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
 func (db *DB) GetCluster(ctx context.Context, ref objects.ManagedObjectReference) *objects.Cluster {
 	var cluster objects.Cluster
 	err := db.GetObj(ctx, ref, &cluster)
@@ -239,13 +321,22 @@ func (db *DB) GetStoragePod(ctx context.Context, ref objects.ManagedObjectRefere
 
 func (db *DB) GetResourcePool(ctx context.Context, ref objects.ManagedObjectReference) *objects.ResourcePool {
 	var rp objects.ResourcePool
-	err := db.GetObj(ctx, ref, &rp)
+	err := db.Get(ctx, objects.ManagedObjectTypesResourcePool, ref.ID(), &rp)
 	if err != nil {
 		return nil
 	}
 	return &rp
 }
 
+func (db *DB) GetVirtualApp(ctx context.Context, ref objects.ManagedObjectReference) *objects.VirtualApp {
+	var vApp objects.VirtualApp
+	err := db.GetObj(ctx, ref, &vApp)
+	if err != nil {
+		return nil
+	}
+	return &vApp
+}
+
 func (db *DB) GetVM(ctx context.Context, ref objects.ManagedObjectReference) *objects.VirtualMachine {
 	var vm objects.VirtualMachine
 	err := db.GetObj(ctx, ref, &vm)
@@ -255,6 +346,87 @@ func (db *DB) GetVM(ctx context.Context, ref objects.ManagedObjectReference) *ob
 	return &vm
 }
 
+func (db *DB) GetVCenter(ctx context.Context, ref objects.ManagedObjectReference) *objects.VCenter {
+	var vc objects.VCenter
+	err := db.Get(ctx, objects.ManagedObjectTypesVCenter, ref.Value, &vc)
+	if err != nil {
+		return nil
+	}
+	return &vc
+}
+
+func (db *DB) GetContentLibrary(ctx context.Context, ref objects.ManagedObjectReference) *objects.ContentLibrary {
+	var library objects.ContentLibrary
+	err := db.Get(ctx, objects.ManagedObjectTypesContentLibrary, ref.Value, &library)
+	if err != nil {
+		return nil
+	}
+	return &library
+}
+
+func (db *DB) GetVMStoragePolicy(ctx context.Context, ref objects.ManagedObjectReference) *objects.VMStoragePolicy {
+	var policy objects.VMStoragePolicy
+	err := db.Get(ctx, objects.ManagedObjectTypesVMStoragePolicy, ref.Value, &policy)
+	if err != nil {
+		return nil
+	}
+	return &policy
+}
+
+func (db *DB) GetStoragePolicy(ctx context.Context, ref objects.ManagedObjectReference) *objects.StoragePolicy {
+	var policy objects.StoragePolicy
+	err := db.Get(ctx, objects.ManagedObjectTypesStoragePolicy, ref.Value, &policy)
+	if err != nil {
+		return nil
+	}
+	return &policy
+}
+
+func (db *DB) GetOrphanedVMDKs(ctx context.Context, ref objects.ManagedObjectReference) *objects.OrphanedVMDKs {
+	var orphans objects.OrphanedVMDKs
+	err := db.Get(ctx, objects.ManagedObjectTypesOrphanedVMDKs, ref.Value, &orphans)
+	if err != nil {
+		return nil
+	}
+	return &orphans
+}
+
+func (db *DB) GetHostLicense(ctx context.Context, ref objects.ManagedObjectReference) *objects.HostLicense {
+	var lic objects.HostLicense
+	err := db.Get(ctx, objects.ManagedObjectTypesHostLicense, ref.Value, &lic)
+	if err != nil {
+		return nil
+	}
+	return &lic
+}
+
+func (db *DB) GetLicense(ctx context.Context, ref objects.ManagedObjectReference) *objects.License {
+	var lic objects.License
+	err := db.Get(ctx, objects.ManagedObjectTypesLicense, ref.Value, &lic)
+	if err != nil {
+		return nil
+	}
+	return &lic
+}
+
+func (db *DB) GetHostOptions(ctx context.Context, ref objects.ManagedObjectReference) *objects.HostOptions {
+	var opts objects.HostOptions
+	err := db.Get(ctx, objects.ManagedObjectTypesHostOptions, ref.Value, &opts)
+	if err != nil {
+		return nil
+	}
+	return &opts
+}
+
+func (db *DB) GetHostSecurity(ctx context.Context, ref objects.ManagedObjectReference) *objects.HostSecurity {
+	var sec objects.HostSecurity
+	err := db.Get(ctx, objects.ManagedObjectTypesHostSecurity, ref.Value, &sec)
+	if err != nil {
+		return nil
+	}
+	return &sec
+}
+
 func (db *DB) GetAllCluster(ctx context.Context) ([]objects.Cluster, error) {
 	db.Connect(ctx)
 	match := fmt.Sprintf("%s:*", objects.ManagedObjectTypesCluster.String())
@@ -408,6 +580,40 @@ func (db *DB) GetAllTagSets(ctx context.Context) ([]objects.TagSet, error) {
 	return objs, nil
 }
 
+func (db *DB) GetAllAttributeSets(ctx context.Context) ([]objects.AttributeSet, error) {
+	db.Connect(ctx)
+	match := fmt.Sprintf("%s:*", objects.ManagedObjectTypesAttributeSet.String())
+	redisIter := db.client.Scan(ctx, 0, match, 0).Iterator()
+	var objs []objects.AttributeSet
+	for redisIter.Next(ctx) {
+		var obj objects.AttributeSet
+		redisKey := redisIter.Val()
+		err := db.Get(ctx, objects.ManagedObjectTypesAttributeSet, redisKey, &obj)
+		if err != nil {
+			return nil, err
+		}
+		objs = append(objs, obj)
+	}
+	return objs, nil
+}
+
+func (db *DB) GetAllVirtualApp(ctx context.Context) ([]objects.VirtualApp, error) {
+	db.Connect(ctx)
+	match := fmt.Sprintf("%s:*", objects.ManagedObjectTypesVirtualApp.String())
+	redisIter := db.client.Scan(ctx, 0, match, 0).Iterator()
+	var objs []objects.VirtualApp
+	for redisIter.Next(ctx) {
+		var obj objects.VirtualApp
+		redisKey := redisIter.Val()
+		err := db.Get(ctx, objects.ManagedObjectTypesVirtualApp, redisKey, &obj)
+		if err != nil {
+			return nil, err
+		}
+		objs = append(objs, obj)
+	}
+	return objs, nil
+}
+
 func (db *DB) GetAllVM(ctx context.Context) ([]objects.VirtualMachine, error) {
 	db.Connect(ctx)
 	match := fmt.Sprintf("%s:*", objects.ManagedObjectTypesVirtualMachine.String())
@@ -425,6 +631,159 @@ func (db *DB) GetAllVM(ctx context.Context) ([]objects.VirtualMachine, error) {
 	return objs, nil
 }
 
+func (db *DB) GetAllVCenter(ctx context.Context) ([]objects.VCenter, error) {
+	db.Connect(ctx)
+	match := fmt.Sprintf("%s:*", objects.ManagedObjectTypesVCenter.String())
+	redisIter := db.client.Scan(ctx, 0, match, 0).Iterator()
+	var objs []objects.VCenter
+	for redisIter.Next(ctx) {
+		var obj objects.VCenter
+		redisKey := redisIter.Val()
+		err := db.Get(ctx, objects.ManagedObjectTypesVCenter, redisKey, &obj)
+		if err != nil {
+			return nil, err
+		}
+		objs = append(objs, obj)
+	}
+	return objs, nil
+}
+
+func (db *DB) GetAllContentLibrary(ctx context.Context) ([]objects.ContentLibrary, error) {
+	db.Connect(ctx)
+	match := fmt.Sprintf("%s:*", objects.ManagedObjectTypesContentLibrary.String())
+	redisIter := db.client.Scan(ctx, 0, match, 0).Iterator()
+	var objs []objects.ContentLibrary
+	for redisIter.Next(ctx) {
+		var obj objects.ContentLibrary
+		redisKey := redisIter.Val()
+		err := db.Get(ctx, objects.ManagedObjectTypesContentLibrary, redisKey, &obj)
+		if err != nil {
+			return nil, err
+		}
+		objs = append(objs, obj)
+	}
+	return objs, nil
+}
+
+func (db *DB) GetAllVMStoragePolicy(ctx context.Context) ([]objects.VMStoragePolicy, error) {
+	db.Connect(ctx)
+	match := fmt.Sprintf("%s:*", objects.ManagedObjectTypesVMStoragePolicy.String())
+	redisIter := db.client.Scan(ctx, 0, match, 0).Iterator()
+	var objs []objects.VMStoragePolicy
+	for redisIter.Next(ctx) {
+		var obj objects.VMStoragePolicy
+		redisKey := redisIter.Val()
+		err := db.Get(ctx, objects.ManagedObjectTypesVMStoragePolicy, redisKey, &obj)
+		if err != nil {
+			return nil, err
+		}
+		objs = append(objs, obj)
+	}
+	return objs, nil
+}
+
+func (db *DB) GetAllStoragePolicy(ctx context.Context) ([]objects.StoragePolicy, error) {
+	db.Connect(ctx)
+	match := fmt.Sprintf("%s:*", objects.ManagedObjectTypesStoragePolicy.String())
+	redisIter := db.client.Scan(ctx, 0, match, 0).Iterator()
+	var objs []objects.StoragePolicy
+	for redisIter.Next(ctx) {
+		var obj objects.StoragePolicy
+		redisKey := redisIter.Val()
+		err := db.Get(ctx, objects.ManagedObjectTypesStoragePolicy, redisKey, &obj)
+		if err != nil {
+			return nil, err
+		}
+		objs = append(objs, obj)
+	}
+	return objs, nil
+}
+
+func (db *DB) GetAllOrphanedVMDKs(ctx context.Context) ([]objects.OrphanedVMDKs, error) {
+	db.Connect(ctx)
+	match := fmt.Sprintf("%s:*", objects.ManagedObjectTypesOrphanedVMDKs.String())
+	redisIter := db.client.Scan(ctx, 0, match, 0).Iterator()
+	var objs []objects.OrphanedVMDKs
+	for redisIter.Next(ctx) {
+		var obj objects.OrphanedVMDKs
+		redisKey := redisIter.Val()
+		err := db.Get(ctx, objects.ManagedObjectTypesOrphanedVMDKs, redisKey, &obj)
+		if err != nil {
+			return nil, err
+		}
+		objs = append(objs, obj)
+	}
+	return objs, nil
+}
+
+func (db *DB) GetAllHostLicense(ctx context.Context) ([]objects.HostLicense, error) {
+	db.Connect(ctx)
+	match := fmt.Sprintf("%s:*", objects.ManagedObjectTypesHostLicense.String())
+	redisIter := db.client.Scan(ctx, 0, match, 0).Iterator()
+	var objs []objects.HostLicense
+	for redisIter.Next(ctx) {
+		var obj objects.HostLicense
+		redisKey := redisIter.Val()
+		err := db.Get(ctx, objects.ManagedObjectTypesHostLicense, redisKey, &obj)
+		if err != nil {
+			return nil, err
+		}
+		objs = append(objs, obj)
+	}
+	return objs, nil
+}
+
+func (db *DB) GetAllLicense(ctx context.Context) ([]objects.License, error) {
+	db.Connect(ctx)
+	match := fmt.Sprintf("%s:*", objects.ManagedObjectTypesLicense.String())
+	redisIter := db.client.Scan(ctx, 0, match, 0).Iterator()
+	var objs []objects.License
+	for redisIter.Next(ctx) {
+		var obj objects.License
+		redisKey := redisIter.Val()
+		err := db.Get(ctx, objects.ManagedObjectTypesLicense, redisKey, &obj)
+		if err != nil {
+			return nil, err
+		}
+		objs = append(objs, obj)
+	}
+	return objs, nil
+}
+
+func (db *DB) GetAllHostOptions(ctx context.Context) ([]objects.HostOptions, error) {
+	db.Connect(ctx)
+	match := fmt.Sprintf("%s:*", objects.ManagedObjectTypesHostOptions.String())
+	redisIter := db.client.Scan(ctx, 0, match, 0).Iterator()
+	var objs []objects.HostOptions
+	for redisIter.Next(ctx) {
+		var obj objects.HostOptions
+		redisKey := redisIter.Val()
+		err := db.Get(ctx, objects.ManagedObjectTypesHostOptions, redisKey, &obj)
+		if err != nil {
+			return nil, err
+		}
+		objs = append(objs, obj)
+	}
+	return objs, nil
+}
+
+func (db *DB) GetAllHostSecurity(ctx context.Context) ([]objects.HostSecurity, error) {
+	db.Connect(ctx)
+	match := fmt.Sprintf("%s:*", objects.ManagedObjectTypesHostSecurity.String())
+	redisIter := db.client.Scan(ctx, 0, match, 0).Iterator()
+	var objs []objects.HostSecurity
+	for redisIter.Next(ctx) {
+		var obj objects.HostSecurity
+		redisKey := redisIter.Val()
+		err := db.Get(ctx, objects.ManagedObjectTypesHostSecurity, redisKey, &obj)
+		if err != nil {
+			return nil, err
+		}
+		objs = append(objs, obj)
+	}
+	return objs, nil
+}
+
 func (db *DB) GetAllClusterIter(ctx context.Context) iter.Seq[objects.Cluster] {
 	db.Connect(ctx)
 	match := fmt.Sprintf("%s:*", objects.ManagedObjectTypesCluster.String())
@@ -634,6 +993,23 @@ func (db *DB) GetTags(ctx context.Context, ref objects.ManagedObjectReference) o
 	return tagSet
 }
 
+func (db *DB) SetAttributes(ctx context.Context, attrSet objects.AttributeSet, ttl time.Duration) error {
+	err := db.Set(ctx, objects.ManagedObjectTypesAttributeSet, attrSet.ObjectRef.ID(), attrSet, ttl)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (db *DB) GetAttributes(ctx context.Context, ref objects.ManagedObjectReference) objects.AttributeSet {
+	var attrSet objects.AttributeSet
+	err := db.Get(ctx, objects.ManagedObjectTypesAttributeSet, ref.ID(), &attrSet)
+	if err != nil {
+		panic(err)
+	}
+	return attrSet
+}
+
 func (db *DB) GetParentChain(ctx context.Context, ref objects.ManagedObjectReference) objects.ParentChain {
 	return db.walkParentChain(ctx, ref, objects.ParentChain{
 		DC:           "",
@@ -641,6 +1017,7 @@ func (db *DB) GetParentChain(ctx context.Context, ref objects.ManagedObjectRefer
 		SPOD:         "",
 		ResourcePool: "",
 		Chain:        []string{},
+		Path:         []string{},
 	})
 }
 
@@ -650,6 +1027,7 @@ func (db *DB) walkParentChain(ctx context.Context, ref objects.ManagedObjectRefe
 		cluster := db.GetCluster(ctx, ref)
 		if cluster != nil {
 			chain.Cluster = cluster.Name
+			chain.PrependPath(cluster.Name)
 			if cluster.Parent != nil {
 				return db.walkParentChain(ctx, *cluster.Parent, chain)
 			}
@@ -658,6 +1036,7 @@ func (db *DB) walkParentChain(ctx context.Context, ref objects.ManagedObjectRefe
 	} else if ref.Type == objects.ManagedObjectTypesComputeResource {
 		cr := db.GetComputeResource(ctx, ref)
 		if cr != nil {
+			chain.PrependPath(cr.Name)
 			if cr.Parent != nil {
 				return db.walkParentChain(ctx, *cr.Parent, chain)
 			}
@@ -667,6 +1046,7 @@ func (db *DB) walkParentChain(ctx context.Context, ref objects.ManagedObjectRefe
 		dc := db.GetDatacenter(ctx, ref)
 		if dc != nil {
 			chain.DC = dc.Name
+			chain.PrependPath(dc.Name)
 			if dc.Parent != nil {
 				return db.walkParentChain(ctx, *dc.Parent, chain)
 			}
@@ -684,6 +1064,7 @@ func (db *DB) walkParentChain(ctx context.Context, ref objects.ManagedObjectRefe
 		folder := db.GetFolder(ctx, ref)
 		if folder != nil {
 			if folder.Parent != nil {
+				chain.PrependPath(folder.Name)
 				return db.walkParentChain(ctx, *folder.Parent, chain)
 			}
 			return chain
@@ -700,6 +1081,7 @@ func (db *DB) walkParentChain(ctx context.Context, ref objects.ManagedObjectRefe
 		spod := db.GetStoragePod(ctx, ref)
 		if spod != nil {
 			chain.SPOD = spod.Name
+			chain.PrependPath(spod.Name)
 			if spod.Parent != nil {
 				return db.walkParentChain(ctx, *spod.Parent, chain)
 			}
@@ -709,6 +1091,7 @@ func (db *DB) walkParentChain(ctx context.Context, ref objects.ManagedObjectRefe
 		rp := db.GetResourcePool(ctx, ref)
 		if rp != nil {
 			chain.ResourcePool = rp.Name
+			chain.PrependPath(rp.Name)
 			if rp.Parent != nil {
 				return db.walkParentChain(ctx, *rp.Parent, chain)
 			}
@@ -719,6 +1102,17 @@ func (db *DB) walkParentChain(ctx context.Context, ref objects.ManagedObjectRefe
 		if vm != nil {
 			if vm.Parent != nil {
 				return db.walkParentChain(ctx, *vm.Parent, chain)
+			} else if vm.ParentVApp != nil {
+				return db.walkParentChain(ctx, *vm.ParentVApp, chain)
+			}
+			return chain
+		}
+	} else if ref.Type == objects.ManagedObjectTypesVirtualApp {
+		vApp := db.GetVirtualApp(ctx, ref)
+		if vApp != nil {
+			chain.PrependPath(vApp.Name)
+			if vApp.Parent != nil {
+				return db.walkParentChain(ctx, *vApp.Parent, chain)
 			}
 			return chain
 		}
@@ -782,12 +1176,78 @@ func (db *DB) JsonDump(ctx context.Context, refType objects.ManagedObjectTypes)
 			return nil, err
 		}
 		return json.MarshalIndent(tagSets, "", "  ")
+	case objects.ManagedObjectTypesAttributeSet:
+		attrSets, err := db.GetAllAttributeSets(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return json.MarshalIndent(attrSets, "", "  ")
+	case objects.ManagedObjectTypesVirtualApp:
+		vApps, err := db.GetAllVirtualApp(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return json.MarshalIndent(vApps, "", "  ")
 	case objects.ManagedObjectTypesVirtualMachine:
 		vms, err := db.GetAllVM(ctx)
 		if err != nil {
 			return nil, err
 		}
 		return json.MarshalIndent(vms, "", "  ")
+	case objects.ManagedObjectTypesHostSecurity:
+		secs, err := db.GetAllHostSecurity(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return json.MarshalIndent(secs, "", "  ")
+	case objects.ManagedObjectTypesHostOptions:
+		opts, err := db.GetAllHostOptions(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return json.MarshalIndent(opts, "", "  ")
+	case objects.ManagedObjectTypesLicense:
+		licenses, err := db.GetAllLicense(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return json.MarshalIndent(licenses, "", "  ")
+	case objects.ManagedObjectTypesHostLicense:
+		licenses, err := db.GetAllHostLicense(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return json.MarshalIndent(licenses, "", "  ")
+	case objects.ManagedObjectTypesOrphanedVMDKs:
+		orphanedVMDKs, err := db.GetAllOrphanedVMDKs(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return json.MarshalIndent(orphanedVMDKs, "", "  ")
+	case objects.ManagedObjectTypesStoragePolicy:
+		storagePolicies, err := db.GetAllStoragePolicy(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return json.MarshalIndent(storagePolicies, "", "  ")
+	case objects.ManagedObjectTypesVMStoragePolicy:
+		vmStoragePolicies, err := db.GetAllVMStoragePolicy(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return json.MarshalIndent(vmStoragePolicies, "", "  ")
+	case objects.ManagedObjectTypesContentLibrary:
+		libraries, err := db.GetAllContentLibrary(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return json.MarshalIndent(libraries, "", "  ")
+	case objects.ManagedObjectTypesVCenter:
+		vcenters, err := db.GetAllVCenter(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return json.MarshalIndent(vcenters, "", "  ")
 	}
 	return nil, nil
 }
@@ -54,15 +54,42 @@ func GetDumpHandler(scraper VCenterScraper, logger *slog.Logger) http.Handler {
 		if helper.NewMatcher("host", "esx").MatchAny(include...) {
 			refTypes = append(refTypes, objects.ManagedObjectTypesHost)
 		}
+		if helper.NewMatcher("host_security", "hostsecurity", "security").MatchAny(include...) {
+			refTypes = append(refTypes, objects.ManagedObjectTypesHostSecurity)
+		}
+		if helper.NewMatcher("host_options", "hostoptions", "advanced_options").MatchAny(include...) {
+			refTypes = append(refTypes, objects.ManagedObjectTypesHostOptions)
+		}
+		if helper.NewMatcher("license", "licenses").MatchAny(include...) {
+			refTypes = append(refTypes, objects.ManagedObjectTypesLicense, objects.ManagedObjectTypesHostLicense)
+		}
+		if helper.NewMatcher("orphaned_vmdk", "orphanedvmdk", "orphaned").MatchAny(include...) {
+			refTypes = append(refTypes, objects.ManagedObjectTypesOrphanedVMDKs)
+		}
 		if helper.NewMatcher("resource_pool", "rpool", "respool").MatchAny(include...) {
 			refTypes = append(refTypes, objects.ManagedObjectTypesResourcePool)
 		}
 		if helper.NewMatcher("storagepod", "storage_pod", "datastore_cluster", "datastorecluster").MatchAny(include...) {
 			refTypes = append(refTypes, objects.ManagedObjectTypesStoragePod)
 		}
+		if helper.NewMatcher("storage_policy", "storagepolicy", "spbm", "pbm").MatchAny(include...) {
+			refTypes = append(refTypes, objects.ManagedObjectTypesStoragePolicy, objects.ManagedObjectTypesVMStoragePolicy)
+		}
+		if helper.NewMatcher("content_library", "contentlibrary", "library").MatchAny(include...) {
+			refTypes = append(refTypes, objects.ManagedObjectTypesContentLibrary)
+		}
+		if helper.NewMatcher("service_instance", "serviceinstance", "vcenter", "sessions").MatchAny(include...) {
+			refTypes = append(refTypes, objects.ManagedObjectTypesVCenter)
+		}
 		if helper.NewMatcher("tags", "tag").MatchAny(include...) {
 			refTypes = append(refTypes, objects.ManagedObjectTypesTagSet)
 		}
+		if helper.NewMatcher("attributes", "attribute", "custom_attributes").MatchAny(include...) {
+			refTypes = append(refTypes, objects.ManagedObjectTypesAttributeSet)
+		}
+		if helper.NewMatcher("vapp", "virtualapp", "virtual_app").MatchAny(include...) {
+			refTypes = append(refTypes, objects.ManagedObjectTypesVirtualApp)
+		}
 		if helper.NewMatcher("vm", "virtualmachine", "virtual_machine").MatchAny(include...) {
 			refTypes = append(refTypes, objects.ManagedObjectTypesVirtualMachine)
 		}
@@ -0,0 +1,89 @@
+package scraper
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/sanderdescamps/govc_exporter/internal/database/objects"
+)
+
+// Return the orphaned vmdk files of all datastores as json. The result can
+// be limited to a single datastore with the datastore query parameter
+// (name or id).
+func GetOrphanedVMDKHandler(scraper VCenterScraper, logger *slog.Logger) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		filter := r.URL.Query().Get("datastore")
+
+		all, err := scraper.DB.GetAllOrphanedVMDKs(ctx)
+		if err != nil {
+			logger.Error("Failed to get orphaned vmdk files", "err", err)
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusInternalServerError)
+			json.NewEncoder(w).Encode(map[string]any{
+				"msg":    "Failed to get orphaned vmdk files",
+				"status": http.StatusInternalServerError,
+			})
+			return
+		}
+
+		result := []objects.OrphanedVMDKs{}
+		for _, orphans := range all {
+			if filter == "" || filter == orphans.DatastoreName || filter == orphans.Datastore.ID() {
+				result = append(result, orphans)
+			}
+		}
+		slices.SortFunc(result, func(a, b objects.OrphanedVMDKs) int {
+			return strings.Compare(a.DatastoreName, b.DatastoreName)
+		})
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(result)
+	})
+}
+
+// Return the inventory topology as json (nodes and edges) or, with
+// format=dot, as a GraphViz digraph. The graph can be limited to the subtree
+// of one or more root nodes with the root query parameter (name or id).
+func GetTopologyHandler(scraper VCenterScraper, logger *slog.Logger) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		query := r.URL.Query()
+
+		topology, err := BuildTopology(ctx, scraper.DB)
+		if err != nil {
+			logger.Error("Failed to build inventory topology", "err", err)
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusInternalServerError)
+			json.NewEncoder(w).Encode(map[string]any{
+				"msg":    "Failed to build inventory topology",
+				"status": http.StatusInternalServerError,
+			})
+			return
+		}
+
+		if roots := query["root"]; len(roots) > 0 {
+			topology = topology.Subtree(roots...)
+		}
+
+		switch format := query.Get("format"); format {
+		case "", "json":
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(topology)
+		case "dot":
+			w.Header().Set("Content-Type", "text/vnd.graphviz")
+			topology.WriteDOT(w)
+		default:
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]any{
+				"msg":    fmt.Sprintf("Unknown format %q, expected json or dot", format),
+				"status": http.StatusBadRequest,
+			})
+		}
+	})
+}
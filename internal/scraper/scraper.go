@@ -24,6 +24,8 @@ type VCenterScraper struct {
 
 	Host             Sensor
 	HostPerf         Sensor
+	HostSecurity     Sensor
+	HostOptions      Sensor
 	Cluster          Sensor
 	ComputeResources Sensor
 	VM               Sensor
@@ -31,9 +33,16 @@ type VCenterScraper struct {
 	Datastore        Sensor
 	SPOD             Sensor
 	ResourcePool     Sensor
+	VirtualApp       Sensor
 	Tags             Sensor
+	CustomAttributes Sensor
 	Datacenter       Sensor
 	Folder           Sensor
+	License          Sensor
+	OrphanedVMDK     Sensor
+	StoragePolicy    Sensor
+	ContentLibrary   Sensor
+	ServiceInstance  Sensor
 	// Remain           *OnDemandSensor
 }
 
@@ -112,9 +121,21 @@ func NewVCenterScraper(ctx context.Context, conf config.ScraperConfig, logger *s
 		} else {
 			scraper.HostPerf = NewNullSensor(HOST_PERF_SENSOR_NAME)
 		}
+		if conf.HostSecurity.Enabled {
+			scraper.HostSecurity = NewHostSecuritySensor(&scraper, conf.HostSecurity, logger)
+		} else {
+			scraper.HostSecurity = NewNullSensor(HOST_SECURITY_SENSOR_NAME)
+		}
+		if conf.HostOptions.Enabled {
+			scraper.HostOptions = NewHostOptionsSensor(&scraper, conf.HostOptions, logger)
+		} else {
+			scraper.HostOptions = NewNullSensor(HOST_OPTIONS_SENSOR_NAME)
+		}
 	} else {
 		scraper.Host = NewNullSensor(HOST_SENSOR_NAME)
 		scraper.HostPerf = NewNullSensor(HOST_PERF_SENSOR_NAME)
+		scraper.HostSecurity = NewNullSensor(HOST_SECURITY_SENSOR_NAME)
+		scraper.HostOptions = NewNullSensor(HOST_OPTIONS_SENSOR_NAME)
 	}
 
 	if conf.ResourcePool.Enabled {
@@ -123,6 +144,18 @@ func NewVCenterScraper(ctx context.Context, conf config.ScraperConfig, logger *s
 		scraper.ResourcePool = NewNullSensor(RESOURCE_POOL_SENSOR_NAME)
 	}
 
+	if conf.VirtualApp.Enabled {
+		scraper.VirtualApp = NewVirtualAppSensor(&scraper, conf.VirtualApp, logger)
+	} else {
+		scraper.VirtualApp = NewNullSensor(VIRTUAL_APP_SENSOR_NAME)
+	}
+
+	if conf.License.Enabled {
+		scraper.License = NewLicenseSensor(&scraper, conf.License, logger)
+	} else {
+		scraper.License = NewNullSensor(LICENSE_SENSOR_NAME)
+	}
+
 	if conf.Spod.Enabled {
 		scraper.SPOD = NewStoragePodSensor(&scraper, conf.Spod, logger)
 	} else {
@@ -141,6 +174,30 @@ func NewVCenterScraper(ctx context.Context, conf config.ScraperConfig, logger *s
 		scraper.VMPerf = NewNullSensor(VM_PERF_SENSOR_NAME)
 	}
 
+	if conf.OrphanedVMDK.Enabled {
+		scraper.OrphanedVMDK = NewOrphanedVMDKSensor(&scraper, conf.OrphanedVMDK, logger)
+	} else {
+		scraper.OrphanedVMDK = NewNullSensor(ORPHANED_VMDK_SENSOR_NAME)
+	}
+
+	if conf.StoragePolicy.Enabled {
+		scraper.StoragePolicy = NewStoragePolicySensor(&scraper, conf.StoragePolicy, logger)
+	} else {
+		scraper.StoragePolicy = NewNullSensor(STORAGE_POLICY_SENSOR_NAME)
+	}
+
+	if conf.ContentLibrary.Enabled {
+		scraper.ContentLibrary = NewContentLibrarySensor(&scraper, conf.ContentLibrary, logger)
+	} else {
+		scraper.ContentLibrary = NewNullSensor(CONTENT_LIBRARY_SENSOR_NAME)
+	}
+
+	if conf.ServiceInstance.Enabled {
+		scraper.ServiceInstance = NewServiceInstanceSensor(&scraper, conf.ServiceInstance, logger)
+	} else {
+		scraper.ServiceInstance = NewNullSensor(SERVICE_INSTANCE_SENSOR_NAME)
+	}
+
 	if conf.Tags.Enabled {
 		logger.Info("Create TagsSensor", "TagsCategoryToCollect", conf.Tags.CategoryToCollect)
 		scraper.Tags = NewTagsSensor(&scraper, conf.Tags, logger)
@@ -148,6 +205,13 @@ func NewVCenterScraper(ctx context.Context, conf config.ScraperConfig, logger *s
 		scraper.Tags = NewNullSensor("TagsSensor")
 	}
 
+	if conf.CustomAttributes.Enabled {
+		logger.Info("Create CustomAttributesSensor", "AttributesToCollect", conf.CustomAttributes.AttributesToCollect)
+		scraper.CustomAttributes = NewCustomAttributesSensor(&scraper, conf.CustomAttributes, logger)
+	} else {
+		scraper.CustomAttributes = NewNullSensor(CUSTOM_ATTRIBUTES_SENSOR_NAME)
+	}
+
 	if conf.Folder.Enabled {
 		scraper.Folder = NewFolderSensor(&scraper, conf.Folder, logger)
 	} else {
@@ -237,11 +301,20 @@ func (c *VCenterScraper) SensorList() []Sensor {
 		c.SPOD,
 		c.Datastore,
 		c.ResourcePool,
+		c.VirtualApp,
 		c.Tags,
+		c.CustomAttributes,
+		c.License,
 		c.Host,
+		c.HostSecurity,
+		c.HostOptions,
 		c.VM,
 		c.HostPerf,
 		c.VMPerf,
+		c.OrphanedVMDK,
+		c.StoragePolicy,
+		c.ContentLibrary,
+		c.ServiceInstance,
 	}
 }
 
@@ -8,13 +8,16 @@ import (
 	"reflect"
 	"slices"
 	"testing"
+	"time"
 
 	"github.com/prometheus/common/promslog"
 	"github.com/sanderdescamps/govc_exporter/internal/config"
+	memory_db "github.com/sanderdescamps/govc_exporter/internal/database/memory"
 	"github.com/sanderdescamps/govc_exporter/internal/database/objects"
 	"github.com/sanderdescamps/govc_exporter/internal/scraper"
 	"github.com/vmware/govmomi"
 	"github.com/vmware/govmomi/property"
+	"github.com/vmware/govmomi/vapi/library"
 	"github.com/vmware/govmomi/vapi/rest"
 	"github.com/vmware/govmomi/vapi/tags"
 	"github.com/vmware/govmomi/view"
@@ -835,3 +838,532 @@ func TestVMwareVMperHost2(t *testing.T) {
 // 		t.Logf("host:  %s", vm.Config.Name)
 // 	}
 // }
+
+func TestExtractDisksFromVM(t *testing.T) {
+	unit := int32(1)
+	vm := mo.VirtualMachine{
+		Config: &types.VirtualMachineConfigInfo{
+			Hardware: types.VirtualHardware{
+				Device: []types.BaseVirtualDevice{
+					&types.ParaVirtualSCSIController{
+						VirtualSCSIController: types.VirtualSCSIController{
+							VirtualController: types.VirtualController{
+								VirtualDevice: types.VirtualDevice{Key: 1000},
+								BusNumber:     2,
+							},
+						},
+					},
+					&types.VirtualDisk{
+						VirtualDevice: types.VirtualDevice{
+							Key:           2000,
+							ControllerKey: 1000,
+							UnitNumber:    &unit,
+							Backing: &types.VirtualDiskRawDiskMappingVer1BackingInfo{
+								VirtualDeviceFileBackingInfo: types.VirtualDeviceFileBackingInfo{
+									FileName:  "[ds1] vm/vm_1.vmdk",
+									Datastore: &types.ManagedObjectReference{Type: "Datastore", Value: "datastore-1"},
+								},
+								CompatibilityMode: "physicalMode",
+								DiskMode:          "independent_persistent",
+								Uuid:              "rdm-uuid",
+							},
+						},
+						CapacityInBytes: 1024,
+					},
+					&types.VirtualDisk{
+						VirtualDevice: types.VirtualDevice{
+							Key:           2001,
+							ControllerKey: 1000,
+							Backing: &types.VirtualDiskSeSparseBackingInfo{
+								VirtualDeviceFileBackingInfo: types.VirtualDeviceFileBackingInfo{
+									FileName: "[ds1] vm/vm_2.vmdk",
+								},
+								DiskMode: "persistent",
+								Uuid:     "sesparse-uuid",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	disks := scraper.ExtractDisksFromVM(vm)
+	if len(disks) != 2 {
+		t.Fatalf("expected 2 disks, got %d", len(disks))
+	}
+
+	rdm := disks[0]
+	if rdm.BackingType != "rdm_physicalMode" || rdm.DiskMode != "independent_persistent" || rdm.UUID != "rdm-uuid" {
+		t.Errorf("unexpected rdm disk: %+v", rdm)
+	}
+	if rdm.ControllerType != "pvscsi" || rdm.BusNumber != 2 || rdm.UnitNumber != 1 {
+		t.Errorf("unexpected controller info: %+v", rdm)
+	}
+	if rdm.Datastore == nil || rdm.Datastore.Value != "datastore-1" {
+		t.Errorf("unexpected datastore: %v", rdm.Datastore)
+	}
+
+	if disks[1].BackingType != "sesparse" || !disks[1].ThinProvisioned {
+		t.Errorf("unexpected sesparse disk: %+v", disks[1])
+	}
+}
+
+func TestExtractNICsFromVM(t *testing.T) {
+	vm := mo.VirtualMachine{
+		Config: &types.VirtualMachineConfigInfo{
+			Hardware: types.VirtualHardware{
+				Device: []types.BaseVirtualDevice{
+					&types.VirtualVmxnet3{
+						VirtualVmxnet: types.VirtualVmxnet{
+							VirtualEthernetCard: types.VirtualEthernetCard{
+								VirtualDevice: types.VirtualDevice{
+									Key: 4000,
+									Backing: &types.VirtualEthernetCardNetworkBackingInfo{
+										VirtualDeviceDeviceBackingInfo: types.VirtualDeviceDeviceBackingInfo{DeviceName: "VM Network"},
+										Network:                        &types.ManagedObjectReference{Type: "Network", Value: "network-1"},
+									},
+									Connectable: &types.VirtualDeviceConnectInfo{Connected: true},
+								},
+								MacAddress: "00:50:56:00:00:01",
+							},
+						},
+					},
+				},
+			},
+		},
+		Guest: &types.GuestInfo{
+			Net: []types.GuestNicInfo{{
+				DeviceConfigId: 4000,
+				IpConfig: &types.NetIpConfigInfo{
+					IpAddress: []types.NetIpConfigInfoIpAddress{
+						{IpAddress: "10.0.0.5", PrefixLength: 24},
+						{IpAddress: "fe80::250:56ff:fe00:1", PrefixLength: 64},
+					},
+				},
+			}},
+		},
+	}
+
+	nics := scraper.ExtractNICsFromVM(vm)
+	if len(nics) != 1 {
+		t.Fatalf("expected 1 nic, got %d", len(nics))
+	}
+	nic := nics[0]
+	if nic.AdapterType != "vmxnet3" || nic.BackingType != "network" || nic.Network != "VM Network" || nic.NetworkID != "network-1" {
+		t.Errorf("unexpected nic: %+v", nic)
+	}
+	if !nic.Connected || nic.StartConnected {
+		t.Errorf("unexpected connection state: %+v", nic)
+	}
+
+	expected := []objects.VirtualMachineIpAddress{
+		{Address: "10.0.0.5", PrefixLength: 24, Family: "ipv4"},
+		{Address: "fe80::250:56ff:fe00:1", PrefixLength: 64, Family: "ipv6"},
+	}
+	if !reflect.DeepEqual(nic.IpAddress, expected) {
+		t.Errorf("expected %v, got %v", expected, nic.IpAddress)
+	}
+}
+
+func TestExtractSnapshotsFromVM(t *testing.T) {
+	vm := mo.VirtualMachine{
+		Snapshot: &types.VirtualMachineSnapshotInfo{
+			CurrentSnapshot: &types.ManagedObjectReference{Type: "VirtualMachineSnapshot", Value: "snapshot-3"},
+			RootSnapshotList: []types.VirtualMachineSnapshotTree{
+				{
+					Snapshot: types.ManagedObjectReference{Type: "VirtualMachineSnapshot", Value: "snapshot-1"},
+					Name:     "off",
+					State:    types.VirtualMachinePowerStatePoweredOff,
+					ChildSnapshotList: []types.VirtualMachineSnapshotTree{
+						{
+							Snapshot: types.ManagedObjectReference{Type: "VirtualMachineSnapshot", Value: "snapshot-2"},
+							Name:     "on",
+							State:    types.VirtualMachinePowerStatePoweredOn,
+							ChildSnapshotList: []types.VirtualMachineSnapshotTree{
+								{
+									Snapshot: types.ManagedObjectReference{Type: "VirtualMachineSnapshot", Value: "snapshot-3"},
+									Name:     "suspended",
+									State:    types.VirtualMachinePowerStateSuspended,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	snaps := scraper.ExtractSnapshotsFromVM(vm)
+	if len(snaps) != 3 {
+		t.Fatalf("expected 3 snapshots, got %d", len(snaps))
+	}
+	expected := []struct {
+		name    string
+		depth   float64
+		memory  bool
+		current bool
+	}{
+		{"off", 1, false, false},
+		{"on", 2, true, false},
+		{"suspended", 3, true, true},
+	}
+	for i, e := range expected {
+		snap := snaps[i]
+		if snap.Name != e.name || snap.Depth != e.depth || snap.Memory != e.memory || snap.Current != e.current {
+			t.Errorf("unexpected snapshot %d: %+v", i, snap)
+		}
+	}
+
+	if snaps := scraper.ExtractSnapshotsFromVM(mo.VirtualMachine{}); len(snaps) != 0 {
+		t.Errorf("expected no snapshots, got %v", snaps)
+	}
+}
+
+func TestConvertToHostSecurity(t *testing.T) {
+	blocked := true
+	open := false
+	host := mo.HostSystem{
+		ManagedEntity: mo.ManagedEntity{
+			ExtensibleManagedObject: mo.ExtensibleManagedObject{
+				Self: types.ManagedObjectReference{Type: "HostSystem", Value: "host-1"},
+			},
+		},
+		Config: &types.HostConfigInfo{
+			LockdownMode: types.HostLockdownModeLockdownNormal,
+			Service: &types.HostServiceInfo{
+				Service: []types.HostService{
+					{Key: "TSM-SSH", Label: "SSH", Running: true, Policy: "off"},
+				},
+			},
+			DateTimeInfo: &types.HostDateTimeInfo{
+				NtpConfig: &types.HostNtpConfig{Server: []string{"pool.ntp.org"}},
+			},
+			Firewall: &types.HostFirewallInfo{
+				DefaultPolicy: types.HostFirewallDefaultPolicy{IncomingBlocked: &blocked, OutgoingBlocked: &open},
+			},
+		},
+	}
+
+	sec := scraper.ConvertToHostSecurity(host, time.Now())
+	if sec.LockdownModeFloat64() != 1 {
+		t.Errorf("expected lockdown mode normal, got %s", sec.LockdownMode)
+	}
+	expected := []objects.HostService{{Key: "TSM-SSH", Label: "SSH", Running: true, Policy: "off"}}
+	if !reflect.DeepEqual(sec.Services, expected) {
+		t.Errorf("expected %v, got %v", expected, sec.Services)
+	}
+	if !slices.Equal(sec.NTPServers, []string{"pool.ntp.org"}) {
+		t.Errorf("unexpected ntp servers %v", sec.NTPServers)
+	}
+	if !sec.FirewallIncomingBlocked || sec.FirewallOutgoingBlocked || sec.FirewallRestrictive() {
+		t.Errorf("unexpected firewall policy: %+v", sec)
+	}
+
+	timeout, interactive := scraper.ExtractShellTimeouts([]types.BaseOptionValue{
+		&types.OptionValue{Key: "UserVars.ESXiShellTimeOut", Value: int64(900)},
+		&types.OptionValue{Key: "UserVars.ESXiShellInteractiveTimeOut", Value: int64(600)},
+	})
+	if timeout != 900 || interactive != 600 {
+		t.Errorf("unexpected shell timeouts %v %v", timeout, interactive)
+	}
+}
+
+func TestConvertToLicense(t *testing.T) {
+	now := time.Now()
+	expiration := now.Add(30 * 24 * time.Hour)
+	l := scraper.ConvertToLicense(types.LicenseManagerLicenseInfo{
+		LicenseKey: "AAAAA-BBBBB-CCCCC-DDDDD-EEEEE",
+		EditionKey: "esx.enterprisePlus.cpuPackage",
+		Total:      16,
+		Used:       4,
+		Properties: []types.KeyAnyValue{{Key: "expirationDate", Value: expiration}},
+	}, now)
+	if l.LicenseKey != "XXXXX-XXXXX-XXXXX-XXXXX-EEEEE" {
+		t.Errorf("license key not masked: %s", l.LicenseKey)
+	}
+	if l.Total != 16 || l.Used != 4 || !l.ExpirationDate.Equal(expiration) {
+		t.Errorf("unexpected license: %+v", l)
+	}
+
+	eval := scraper.ConvertToLicense(types.LicenseManagerLicenseInfo{
+		LicenseKey: objects.LicenseKeyEvaluation,
+		EditionKey: "eval",
+		Properties: []types.KeyAnyValue{{Key: "expirationHours", Value: int32(24)}},
+	}, now)
+	if !eval.ExpirationDate.Equal(now.Add(24 * time.Hour)) {
+		t.Errorf("unexpected expiration of evaluation license: %v", eval.ExpirationDate)
+	}
+}
+
+func TestClusterRuleViolations(t *testing.T) {
+	vm := func(id string) types.ManagedObjectReference {
+		return types.ManagedObjectReference{Type: "VirtualMachine", Value: id}
+	}
+	host := func(id string) types.ManagedObjectReference {
+		return types.ManagedObjectReference{Type: "HostSystem", Value: id}
+	}
+	enabled := true
+
+	cluster := scraper.ConvertToCluster(context.Background(), nil, mo.ClusterComputeResource{ComputeResource: mo.ComputeResource{
+		ManagedEntity: mo.ManagedEntity{ExtensibleManagedObject: mo.ExtensibleManagedObject{
+			Self: types.ManagedObjectReference{Type: "ClusterComputeResource", Value: "domain-c1"},
+		}},
+		Summary: &types.ClusterComputeResourceSummary{},
+		ConfigurationEx: &types.ClusterConfigInfoEx{
+			Rule: []types.BaseClusterRuleInfo{
+				&types.ClusterAntiAffinityRuleSpec{
+					ClusterRuleInfo: types.ClusterRuleInfo{Name: "anti", Enabled: &enabled},
+					Vm:              []types.ManagedObjectReference{vm("vm-1"), vm("vm-2"), vm("vm-3")},
+				},
+				&types.ClusterAffinityRuleSpec{
+					ClusterRuleInfo: types.ClusterRuleInfo{Name: "affinity", Enabled: &enabled},
+					Vm:              []types.ManagedObjectReference{vm("vm-1"), vm("vm-2"), vm("vm-3")},
+				},
+				&types.ClusterVmHostRuleInfo{
+					ClusterRuleInfo:     types.ClusterRuleInfo{Name: "vm-host", Enabled: &enabled, Mandatory: &enabled},
+					VmGroupName:         "vms",
+					AffineHostGroupName: "hosts",
+				},
+			},
+			Group: []types.BaseClusterGroupInfo{
+				&types.ClusterVmGroup{ClusterGroupInfo: types.ClusterGroupInfo{Name: "vms"}, Vm: []types.ManagedObjectReference{vm("vm-1"), vm("vm-3")}},
+				&types.ClusterHostGroup{ClusterGroupInfo: types.ClusterGroupInfo{Name: "hosts"}, Host: []types.ManagedObjectReference{host("host-1")}},
+			},
+		},
+	}}, time.Now())
+
+	if len(cluster.Rules) != 3 || len(cluster.Groups) != 2 {
+		t.Fatalf("unexpected rules or groups: %+v %+v", cluster.Rules, cluster.Groups)
+	}
+
+	vmHosts := map[string]string{"vm-1": "host-1", "vm-2": "host-1", "vm-3": "host-2"}
+	expected := map[string]float64{"anti": 1, "affinity": 1, "vm-host": 1}
+	for _, rule := range cluster.Rules {
+		if v := cluster.RuleViolations(rule, vmHosts); v != expected[rule.Name] {
+			t.Errorf("rule %s: expected %v violations, got %v", rule.Name, expected[rule.Name], v)
+		}
+	}
+}
+
+func TestExtractDatastoreInfo(t *testing.T) {
+	nas := scraper.ExtractNasInfoFromDatastore(mo.Datastore{Info: &types.NasDatastoreInfo{
+		Nas: &types.HostNasVolume{HostFileSystemVolume: types.HostFileSystemVolume{Type: "NFS41"}, RemoteHost: "nfs01", RemotePath: "/export/ds01"},
+	}})
+	if nas == nil || nas.Type != "NFS41" || nas.RemoteHost != "nfs01" || nas.RemotePath != "/export/ds01" {
+		t.Errorf("unexpected nas info: %+v", nas)
+	}
+
+	vmfs := scraper.ExtractVmfsInfoFromDatastore(mo.Datastore{Info: &types.VmfsDatastoreInfo{
+		Vmfs: &types.HostVmfsVolume{
+			HostFileSystemVolume: types.HostFileSystemVolume{Type: "VMFS"},
+			Version:              "6.82",
+			BlockSize:            1024,
+			UnmapPriority:        "low",
+			UnmapGranularity:     1024,
+		},
+	}})
+	if vmfs == nil || vmfs.Version != "6.82" || vmfs.BlockSize != 1024*1024 || !vmfs.UnmapEnabled() {
+		t.Errorf("unexpected vmfs info: %+v", vmfs)
+	}
+}
+
+func TestFilterOrphanedVMDKs(t *testing.T) {
+	registered := map[string]bool{}
+	for _, disk := range []string{"[ds01] vm1/vm1.vmdk", "[ds01] vm2/vm2-000002.vmdk"} {
+		registered[scraper.VMDKBaseName(disk)] = true
+	}
+
+	orphaned := scraper.FilterOrphanedVMDKs([]objects.OrphanedVMDK{
+		{Path: "[ds01] vm1/vm1.vmdk"},
+		{Path: "[ds01]/vm2/vm2.vmdk"},
+		{Path: "[ds01] vm2/vm2-000001.vmdk"},
+		{Path: "[ds01] old/old.vmdk", Size: 1024},
+		{Path: "[ds01] fcd/_00a1/6f2e0c7a.vmdk"},
+	}, registered)
+	if len(orphaned) != 1 || orphaned[0].Path != "[ds01] old/old.vmdk" {
+		t.Errorf("unexpected orphaned vmdk files: %+v", orphaned)
+	}
+}
+
+func TestConvertToStoragePod(t *testing.T) {
+	spod := scraper.ConvertToStoragePod(context.Background(), nil, mo.StoragePod{
+		Folder: mo.Folder{
+			ManagedEntity: mo.ManagedEntity{ExtensibleManagedObject: mo.ExtensibleManagedObject{
+				Self: types.ManagedObjectReference{Type: "StoragePod", Value: "group-p1"},
+			}},
+			ChildEntity: []types.ManagedObjectReference{{Type: "Datastore", Value: "datastore-1"}},
+		},
+		PodStorageDrsEntry: &types.PodStorageDrsEntry{
+			StorageDrsConfig: types.StorageDrsConfigInfo{PodConfig: types.StorageDrsPodConfigInfo{
+				Enabled:                true,
+				DefaultVmBehavior:      "automated",
+				LoadBalanceInterval:    480,
+				SpaceLoadBalanceConfig: &types.StorageDrsSpaceLoadBalanceConfig{SpaceThresholdMode: "freeSpace", FreeSpaceThresholdGB: 50},
+				IoLoadBalanceConfig:    &types.StorageDrsIoLoadBalanceConfig{IoLatencyThreshold: 15},
+			}},
+			Recommendation: []types.ClusterRecommendation{{Key: "1"}},
+		},
+	}, time.Now())
+
+	if len(spod.Datastores) != 1 || !spod.SDRSEnabled || spod.SDRSBehaviorFloat64() != 1 {
+		t.Errorf("unexpected storage pod: %+v", spod)
+	}
+	if spod.SDRSLoadBalanceInterval != 480*60 || spod.SDRSFreeSpaceThreshold != 50*1024*1024*1024 || spod.SDRSIoLatencyThreshold != 0.015 {
+		t.Errorf("unexpected storage drs thresholds: %+v", spod)
+	}
+	if spod.SDRSPendingRecommendations != 1 {
+		t.Errorf("expected 1 pending recommendation, got %v", spod.SDRSPendingRecommendations)
+	}
+}
+
+func TestConvertToVMStoragePolicy(t *testing.T) {
+	vm := objects.VirtualMachine{
+		Self: objects.ManagedObjectReference{Type: "VirtualMachine", Value: "vm-1"},
+		Disk: []objects.VirtualMachineDisk{{Key: 2000, Label: "Hard disk 1"}, {Key: 2001, Label: "Hard disk 2"}},
+	}
+	result := scraper.ConvertToVMStoragePolicy(vm,
+		map[string][]string{"vm-1": {"p1"}, "vm-1:2000": {"p2"}},
+		map[string]string{"vm-1": "compliant"},
+		map[string]string{"p1": "gold", "p2": "silver"},
+		time.Now(),
+	)
+
+	expected := []objects.VMStoragePolicyEntity{
+		{Type: objects.StoragePolicyEntityHome, PolicyID: "p1", PolicyName: "gold", ComplianceStatus: "compliant"},
+		{Type: objects.StoragePolicyEntityDisk, Disk: "Hard disk 1", PolicyID: "p2", PolicyName: "silver", ComplianceStatus: "unknown"},
+	}
+	if !reflect.DeepEqual(result.Entities, expected) {
+		t.Errorf("unexpected storage policy entities: %+v", result.Entities)
+	}
+}
+
+func TestFormatWWN(t *testing.T) {
+	if wwn := scraper.FormatWWN(0x2000002590cd7a31); wwn != "20:00:00:25:90:cd:7a:31" {
+		t.Errorf("unexpected wwn %s", wwn)
+	}
+	if wwn := scraper.FormatWWN(0); wwn != "" {
+		t.Errorf("expected empty wwn, got %s", wwn)
+	}
+}
+
+func TestSetIscsiSessionState(t *testing.T) {
+	hbas := []objects.HostBusAdapter{{
+		Device: "vmhba64",
+		IscsiStaticTarget: []objects.IscsiStaticTarget{
+			{IQN: "iqn.2005-10.org.freenas.ctl:a"},
+			{IQN: "iqn.2005-10.org.freenas.ctl:b"},
+			{IQN: "iqn.2005-10.org.freenas.ctl:c"},
+		},
+	}}
+	scraper.SetIscsiSessionState(hbas, []objects.MultipathPathInfo{
+		{Adapter: "vmhba64", IscsiTargetIQN: "iqn.2005-10.org.freenas.ctl:a", State: "dead"},
+		{Adapter: "vmhba64", IscsiTargetIQN: "iqn.2005-10.org.freenas.ctl:a", State: "active"},
+		{Adapter: "vmhba64", IscsiTargetIQN: "iqn.2005-10.org.freenas.ctl:b", State: "dead"},
+	})
+
+	states := []string{}
+	for _, target := range hbas[0].IscsiStaticTarget {
+		states = append(states, target.SessionState)
+	}
+	if !slices.Equal(states, []string{"online", "offline", "unknown"}) {
+		t.Errorf("unexpected session states %v", states)
+	}
+}
+
+func TestConvertToContentLibrary(t *testing.T) {
+	autoSync := true
+	lib := scraper.ConvertToContentLibrary(library.Library{
+		ID:           "lib-1",
+		Name:         "templates",
+		Type:         "SUBSCRIBED",
+		Storage:      []library.StorageBacking{{DatastoreID: "datastore-1", Type: "DATASTORE"}},
+		Subscription: &library.Subscription{SubscriptionURL: "https://vc01/cls/vcsp/lib/1/lib.json", AutomaticSyncEnabled: &autoSync},
+	}, []library.Item{
+		{ID: "item-1", Name: "ubuntu", Type: "ovf", Size: 1024, Cached: true},
+		{ID: "item-2", Name: "centos", Type: "vm-template", Size: 2048},
+	}, time.Now())
+
+	if !lib.IsSubscribed() || lib.Subscription == nil || !lib.Subscription.AutomaticSync || lib.Subscription.OnDemand {
+		t.Errorf("unexpected subscription: %+v", lib.Subscription)
+	}
+	if len(lib.Datastores) != 1 || lib.Datastores[0].Value != "datastore-1" || lib.Datastores[0].Type != objects.ManagedObjectTypesDatastore {
+		t.Errorf("unexpected datastores: %+v", lib.Datastores)
+	}
+	if len(lib.Items) != 2 || lib.Size() != 3072 || !lib.LastSyncTime.IsZero() {
+		t.Errorf("unexpected library: %+v", lib)
+	}
+}
+
+func TestConvertToVCenter(t *testing.T) {
+	before := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	after := before.Add(2 * time.Second)
+	serverTime := before.Add(31 * time.Second)
+
+	vc := scraper.ConvertToVCenter(types.AboutInfo{Version: "8.0.3", Build: "24322831", InstanceUuid: "vc-uuid"}, serverTime, before, after, []types.UserSession{
+		{UserName: "VSPHERE.LOCAL\\svc-backup", LastActiveTime: serverTime.Add(-2 * time.Hour)},
+	})
+
+	if vc.ClockSkewSeconds != 30 {
+		t.Errorf("expected clock skew of 30s, got %v", vc.ClockSkewSeconds)
+	}
+	if vc.InstanceUUID != "vc-uuid" || len(vc.Sessions) != 1 || vc.Sessions[0].IdleSeconds(vc.ServerTime) != 7200 {
+		t.Errorf("unexpected vcenter: %+v", vc)
+	}
+}
+
+func TestBuildTopology(t *testing.T) {
+	ctx := context.Background()
+	db := memory_db.NewDB()
+	ref := func(typ objects.ManagedObjectTypes, value string) objects.ManagedObjectReference {
+		return objects.ManagedObjectReference{Type: typ, Value: value}
+	}
+	dc := ref(objects.ManagedObjectTypesDatacenter, "datacenter-1")
+	hostFolder := ref(objects.ManagedObjectTypesFolder, "group-h1")
+	cluster := ref(objects.ManagedObjectTypesCluster, "domain-c1")
+	rPool := ref(objects.ManagedObjectTypesResourcePool, "resgroup-1")
+
+	// Two datacenters with the same name in different folders
+	db.SetDatacenter(ctx, objects.Datacenter{Self: dc, Name: "DC0"}, time.Minute)
+	db.SetDatacenter(ctx, objects.Datacenter{Self: ref(objects.ManagedObjectTypesDatacenter, "datacenter-2"), Name: "DC0"}, time.Minute)
+	db.SetFolder(ctx, objects.Folder{Self: hostFolder, Parent: &dc, Name: "host"}, time.Minute)
+	db.SetCluster(ctx, objects.Cluster{Self: cluster, Parent: &hostFolder, Name: "C0", Datacenter: "DC0"}, time.Minute)
+	db.SetHost(ctx, objects.Host{Self: ref(objects.ManagedObjectTypesHost, "host-1"), Parent: &cluster, Name: "esx1", Datacenter: "DC0"}, time.Minute)
+	db.SetHost(ctx, objects.Host{Self: ref(objects.ManagedObjectTypesHost, "host-2"), Parent: &hostFolder, Name: "esx2", Datacenter: "DC0"}, time.Minute)
+	db.SetResourcePool(ctx, objects.ResourcePool{Self: rPool, Name: "Resources"}, time.Minute)
+	db.SetVM(ctx, objects.VirtualMachine{Self: ref(objects.ManagedObjectTypesVirtualMachine, "vm-1"), Name: "vm1", ResourcePoolRef: &rPool, HostInfo: objects.VirtualMachineHostInfo{HostID: "host-1"}}, time.Minute)
+	db.SetVM(ctx, objects.VirtualMachine{Self: ref(objects.ManagedObjectTypesVirtualMachine, "vm-2"), Name: "vm2", HostInfo: objects.VirtualMachineHostInfo{HostID: "host-2"}}, time.Minute)
+	db.SetDatastore(ctx, objects.Datastore{Self: ref(objects.ManagedObjectTypesDatastore, "datastore-1"), Name: "ds1", VMs: []string{"vm-1", "vm-2", "vm-gone"}}, time.Minute)
+	db.SetStoragePod(ctx, objects.StoragePod{Self: ref(objects.ManagedObjectTypesStoragePod, "group-p1"), Name: "pod1", Datastores: []objects.ManagedObjectReference{ref(objects.ManagedObjectTypesDatastore, "datastore-1")}}, time.Minute)
+
+	topology, err := scraper.BuildTopology(ctx, db)
+	if err != nil {
+		t.Fatalf("failed to build topology: %v", err)
+	}
+	if len(topology.Nodes) != 10 || len(topology.Edges) != 9 {
+		t.Fatalf("expected 10 nodes and 9 edges, got %+v", topology)
+	}
+	if !slices.Contains(topology.Edges, scraper.TopologyEdge{From: "datacenter-1", To: "host-2", Relation: scraper.TopologyEdgeContains}) {
+		t.Errorf("standalone host not linked to datacenter: %+v", topology.Edges)
+	}
+
+	subtreeIDs := func(roots ...string) []string {
+		ids := []string{}
+		for _, node := range topology.Subtree(roots...).Nodes {
+			ids = append(ids, node.ID)
+		}
+		slices.Sort(ids)
+		return ids
+	}
+	for root, expected := range map[string][]string{
+		"C0":           {"domain-c1", "host-1", "vm-1"},
+		"pod1":         {"datastore-1", "group-p1", "vm-1", "vm-2"},
+		"datastore-1":  {"datastore-1", "vm-1", "vm-2"},
+		"Resources":    {"resgroup-1", "vm-1"},
+		"datacenter-2": {"datacenter-2"},
+	} {
+		if ids := subtreeIDs(root); !reflect.DeepEqual(ids, expected) {
+			t.Errorf("expected subtree of %s %v, got %v", root, expected, ids)
+		}
+	}
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/sanderdescamps/govc_exporter/internal/scraper/logger"
 	sensormetrics "github.com/sanderdescamps/govc_exporter/internal/scraper/sensor_metrics"
 	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/types"
 )
 
 const CLUSTER_SENSOR_NAME = "ClusterSensor"
@@ -39,6 +40,8 @@ func NewClusterSensor(scraper *VCenterScraper, config config.SensorConfig, l *sl
 				"parent",
 				"name",
 				"summary",
+				"configurationEx",
+				"recommendation",
 			},
 			mc, sm),
 		started:          helper.NewStartedCheck(),
@@ -212,5 +215,121 @@ func ConvertToCluster(ctx context.Context, scraper *VCenterScraper, c mo.Cluster
 		cluster.OverallStatus = string(summary.OverallStatus)
 	}
 
+	if summary, ok := c.Summary.(*types.ClusterComputeResourceSummary); ok {
+		cluster.NumVmotions = float64(summary.NumVmotions)
+		cluster.DRSScore = float64(summary.DrsScore)
+		for _, n := range summary.NumVmsPerDrsScoreBucket {
+			cluster.DRSVMsPerScoreBucket = append(cluster.DRSVMsPerScoreBucket, float64(n))
+		}
+		cluster.HACurrentFailoverLevel = float64(summary.CurrentFailoverLevel)
+		cluster.EVCMode = summary.CurrentEVCModeKey
+
+		if info, ok := summary.AdmissionControlInfo.(*types.ClusterFailoverResourcesAdmissionControlInfo); ok {
+			cluster.HACurrentCPUFailoverPercent = float64(info.CurrentCpuFailoverResourcesPercent)
+			cluster.HACurrentMemoryFailoverPercent = float64(info.CurrentMemoryFailoverResourcesPercent)
+		}
+	}
+
+	if config, ok := c.ConfigurationEx.(*types.ClusterConfigInfoEx); ok {
+		drs := config.DrsConfig
+		cluster.DRSEnabled = drs.Enabled != nil && *drs.Enabled
+		cluster.DRSBehavior = string(drs.DefaultVmBehavior)
+
+		das := config.DasConfig
+		cluster.HAEnabled = das.Enabled != nil && *das.Enabled
+		cluster.HAHostMonitoring = das.HostMonitoring == string(types.ClusterDasConfigInfoServiceStateEnabled)
+		cluster.HAVMMonitoring = das.VmMonitoring
+		cluster.HAAdmissionControlEnabled = das.AdmissionControlEnabled != nil && *das.AdmissionControlEnabled
+		cluster.HAFailoverLevel = float64(das.FailoverLevel)
+		switch policy := das.AdmissionControlPolicy.(type) {
+		case *types.ClusterFailoverLevelAdmissionControlPolicy:
+			cluster.HAAdmissionControlPolicy = "failover_level"
+			cluster.HAFailoverLevel = float64(policy.FailoverLevel)
+		case *types.ClusterFailoverResourcesAdmissionControlPolicy:
+			cluster.HAAdmissionControlPolicy = "failover_resources"
+			if policy.FailoverLevel > 0 {
+				cluster.HAFailoverLevel = float64(policy.FailoverLevel)
+			}
+			cluster.HAConfiguredCPUFailoverPercent = float64(policy.CpuFailoverResourcesPercent)
+			cluster.HAConfiguredMemoryFailoverPercent = float64(policy.MemoryFailoverResourcesPercent)
+		case *types.ClusterFailoverHostAdmissionControlPolicy:
+			cluster.HAAdmissionControlPolicy = "failover_hosts"
+			if policy.FailoverLevel > 0 {
+				cluster.HAFailoverLevel = float64(policy.FailoverLevel)
+			} else {
+				cluster.HAFailoverLevel = float64(len(policy.FailoverHosts))
+			}
+		}
+
+		for _, r := range config.Rule {
+			if rule := convertToClusterRule(r); rule != nil {
+				cluster.Rules = append(cluster.Rules, *rule)
+			}
+		}
+		for _, g := range config.Group {
+			if group := convertToClusterGroup(g); group != nil {
+				cluster.Groups = append(cluster.Groups, *group)
+			}
+		}
+	}
+
+	cluster.DRSPendingRecommendations = float64(len(c.Recommendation))
+
 	return cluster
 }
+
+func convertToClusterRule(r types.BaseClusterRuleInfo) *objects.ClusterRule {
+	info := r.GetClusterRuleInfo()
+	if info == nil {
+		return nil
+	}
+	rule := objects.ClusterRule{
+		Key:       info.Key,
+		Name:      info.Name,
+		Enabled:   info.Enabled != nil && *info.Enabled,
+		Mandatory: info.Mandatory != nil && *info.Mandatory,
+	}
+
+	switch t := r.(type) {
+	case *types.ClusterAffinityRuleSpec:
+		rule.Type = objects.ClusterRuleTypeVMAffinity
+		rule.VMs = refIDs(t.Vm)
+	case *types.ClusterAntiAffinityRuleSpec:
+		rule.Type = objects.ClusterRuleTypeVMAntiAffinity
+		rule.VMs = refIDs(t.Vm)
+	case *types.ClusterVmHostRuleInfo:
+		rule.VMGroup = t.VmGroupName
+		if t.AffineHostGroupName != "" {
+			rule.Type = objects.ClusterRuleTypeVMHostAffinity
+			rule.TargetGroup = t.AffineHostGroupName
+		} else {
+			rule.Type = objects.ClusterRuleTypeVMHostAntiAffinity
+			rule.TargetGroup = t.AntiAffineHostGroupName
+		}
+	case *types.ClusterDependencyRuleInfo:
+		rule.Type = objects.ClusterRuleTypeVMDependency
+		rule.VMGroup = t.VmGroup
+		rule.TargetGroup = t.DependsOnVmGroup
+	default:
+		return nil
+	}
+	return &rule
+}
+
+func convertToClusterGroup(g types.BaseClusterGroupInfo) *objects.ClusterGroup {
+	switch t := g.(type) {
+	case *types.ClusterVmGroup:
+		return &objects.ClusterGroup{Name: t.Name, Type: objects.ClusterGroupTypeVM, Members: refIDs(t.Vm)}
+	case *types.ClusterHostGroup:
+		return &objects.ClusterGroup{Name: t.Name, Type: objects.ClusterGroupTypeHost, Members: refIDs(t.Host)}
+	}
+	return nil
+}
+
+func refIDs(refs []types.ManagedObjectReference) []string {
+	ids := make([]string, 0, len(refs))
+	for _, ref := range refs {
+		ids = append(ids, ref.Value)
+	}
+	return ids
+}
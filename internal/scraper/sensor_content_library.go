@@ -0,0 +1,246 @@
+package scraper
+
+import (
+	"context"
+	"log/slog"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/sanderdescamps/govc_exporter/internal/config"
+	"github.com/sanderdescamps/govc_exporter/internal/database/objects"
+	"github.com/sanderdescamps/govc_exporter/internal/helper"
+	"github.com/sanderdescamps/govc_exporter/internal/scraper/logger"
+	sensormetrics "github.com/sanderdescamps/govc_exporter/internal/scraper/sensor_metrics"
+	"github.com/vmware/govmomi/vapi/library"
+)
+
+const CONTENT_LIBRARY_SENSOR_NAME = "ContentLibrarySensor"
+
+type ContentLibrarySensor struct {
+	logger.SensorLogger
+	metricsCollector *sensormetrics.SensorMetricsCollector
+	statusMonitor    *sensormetrics.StatusMonitor
+	started          *helper.StartedCheck
+	sensorLock       sync.Mutex
+	manualRefresh    chan struct{}
+	stopChan         chan struct{}
+	config           config.SensorConfig
+}
+
+func NewContentLibrarySensor(scraper *VCenterScraper, config config.SensorConfig, l *slog.Logger) *ContentLibrarySensor {
+	var mc *sensormetrics.SensorMetricsCollector = sensormetrics.NewLastSensorMetricsCollector()
+	var sm *sensormetrics.StatusMonitor = sensormetrics.NewStatusMonitor()
+
+	return &ContentLibrarySensor{
+		started:          helper.NewStartedCheck(),
+		stopChan:         make(chan struct{}),
+		manualRefresh:    make(chan struct{}),
+		config:           config,
+		SensorLogger:     logger.NewSLogLogger(l, logger.WithKind(CONTENT_LIBRARY_SENSOR_NAME)),
+		metricsCollector: mc,
+		statusMonitor:    sm,
+	}
+}
+
+func (s *ContentLibrarySensor) refresh(ctx context.Context, scraper *VCenterScraper) error {
+	if ok := s.sensorLock.TryLock(); !ok {
+		return ErrSensorAlreadyRunning
+	}
+	defer s.sensorLock.Unlock()
+
+	sensorStopwatch := sensormetrics.NewSensorStopwatch()
+	sensorStopwatch.Start()
+	restclient, release, err := scraper.clientPool.AcquireRest()
+	defer release()
+	if err != nil {
+		return ErrSensorCientFailed
+	}
+	defer restclient.Logout(ctx)
+	sensorStopwatch.Mark1()
+
+	m := library.NewManager(restclient)
+	libraries, err := m.GetLibraries(ctx)
+	if err != nil {
+		return NewSensorError("failed to get content libraries", "err", err)
+	}
+
+	t := time.Now()
+	for _, lib := range libraries {
+		items, err := m.GetLibraryItems(ctx, lib.ID)
+		if err != nil {
+			s.SensorLogger.Warn("failed to get content library items", "library", lib.Name, "err", err)
+			continue
+		}
+
+		if err := scraper.DB.SetContentLibrary(ctx, ConvertToContentLibrary(lib, items, t), s.config.MaxAge); err != nil {
+			return err
+		}
+	}
+
+	sensorStopwatch.Finish()
+	s.metricsCollector.UploadStats(sensorStopwatch.GetStats())
+	return nil
+}
+
+func ConvertToContentLibrary(lib library.Library, items []library.Item, t time.Time) objects.ContentLibrary {
+	result := objects.ContentLibrary{
+		Timestamp:        t,
+		ID:               lib.ID,
+		Name:             lib.Name,
+		Type:             lib.Type,
+		CreationTime:     timeOrZero(lib.CreationTime),
+		LastModifiedTime: timeOrZero(lib.LastModifiedTime),
+		LastSyncTime:     timeOrZero(lib.LastSyncTime),
+		Datastores:       []objects.ManagedObjectReference{},
+		Items:            []objects.ContentLibraryItem{},
+	}
+	if lib.StateInfo != nil {
+		result.State = lib.StateInfo.State
+	}
+
+	for _, backing := range lib.Storage {
+		if backing.DatastoreID != "" {
+			result.Datastores = append(result.Datastores, objects.ManagedObjectReference{
+				Type:  objects.ManagedObjectTypesDatastore,
+				Value: backing.DatastoreID,
+			})
+		} else if backing.StorageURI != "" {
+			result.StorageURI = backing.StorageURI
+		}
+	}
+
+	if pub := lib.Publication; pub != nil && pub.Published != nil {
+		result.Published = *pub.Published
+	}
+	if sub := lib.Subscription; sub != nil {
+		result.Subscription = &objects.ContentLibrarySubscription{
+			URL:           sub.SubscriptionURL,
+			AutomaticSync: sub.AutomaticSyncEnabled != nil && *sub.AutomaticSyncEnabled,
+			OnDemand:      sub.OnDemand != nil && *sub.OnDemand,
+		}
+	}
+
+	for _, item := range items {
+		result.Items = append(result.Items, objects.ContentLibraryItem{
+			ID:               item.ID,
+			Name:             item.Name,
+			Type:             item.Type,
+			Size:             float64(item.Size),
+			Cached:           item.Cached,
+			CreationTime:     timeOrZero(item.CreationTime),
+			LastModifiedTime: timeOrZero(item.LastModifiedTime),
+			LastSyncTime:     timeOrZero(item.LastSyncTime),
+		})
+	}
+	return result
+}
+
+func timeOrZero(t *time.Time) time.Time {
+	if t == nil {
+		return time.Time{}
+	}
+	return *t
+}
+
+func (s *ContentLibrarySensor) Init(ctx context.Context, scraper *VCenterScraper) error {
+	if !s.started.IsStarted() {
+		err := s.refresh(ctx, scraper)
+		if err != nil {
+			s.statusMonitor.Fail()
+			return err
+		}
+		s.statusMonitor.Success()
+		s.started.Started()
+	} else {
+		return ErrSensorAlreadyStarted
+	}
+	return nil
+}
+
+func (s *ContentLibrarySensor) StartRefresher(ctx context.Context, scraper *VCenterScraper) error {
+	ticker := time.NewTicker(s.config.RefreshInterval)
+	go func() {
+		time.Sleep(time.Duration(rand.Intn(20000)) * time.Millisecond)
+		for {
+			select {
+			case <-ticker.C:
+				go func() {
+					err := s.refresh(ctx, scraper)
+					if err == nil {
+						s.SensorLogger.Debug("refresh successful")
+						s.statusMonitor.Success()
+					} else {
+						s.SensorLogger.Error("refresh failed", "err", err)
+						s.statusMonitor.Fail()
+					}
+				}()
+			case <-s.manualRefresh:
+				go func() {
+					s.SensorLogger.Info("trigger manual refresh")
+					err := s.refresh(ctx, scraper)
+					if err == nil {
+						s.SensorLogger.Info("manual refresh successful")
+						s.statusMonitor.Success()
+					} else {
+						s.SensorLogger.Error("manual refresh failed", "err", err)
+						s.statusMonitor.Fail()
+					}
+				}()
+			case <-s.stopChan:
+				s.started.Stopped()
+				ticker.Stop()
+			case <-ctx.Done():
+				s.started.Stopped()
+				ticker.Stop()
+			}
+		}
+	}()
+	return nil
+}
+
+func (s *ContentLibrarySensor) StopRefresher(ctx context.Context) {
+	close(s.stopChan)
+}
+
+func (s *ContentLibrarySensor) TriggerManualRefresh(ctx context.Context) {
+	s.manualRefresh <- struct{}{}
+}
+
+func (s *ContentLibrarySensor) Kind() string {
+	return "ContentLibrarySensor"
+}
+
+func (s *ContentLibrarySensor) WaitTillStartup() {
+	s.started.Wait()
+}
+
+func (s *ContentLibrarySensor) Match(name string) bool {
+	return helper.NewMatcher("content_library", "contentlibrary", "library").Match(name)
+}
+
+func (s *ContentLibrarySensor) Enabled() bool {
+	return true
+}
+
+func (s *ContentLibrarySensor) GetLatestMetrics() []sensormetrics.SensorMetric {
+	return append(
+		s.metricsCollector.ComposeMetrics(s.Kind()),
+		sensormetrics.SensorMetric{
+			Sensor:     s.Kind(),
+			MetricName: "failed",
+			Value:      s.statusMonitor.StatusFailedFloat64(),
+			Unit:       "boolean",
+		}, sensormetrics.SensorMetric{
+			Sensor:     s.Kind(),
+			MetricName: "fail_rate",
+			Value:      s.statusMonitor.FailRate(),
+			Unit:       "boolean",
+		}, sensormetrics.SensorMetric{
+			Sensor:     s.Kind(),
+			MetricName: "enabled",
+			Value:      1.0,
+			Unit:       "boolean",
+		},
+	)
+}
@@ -0,0 +1,244 @@
+package scraper
+
+import (
+	"context"
+	"log/slog"
+	"math/rand"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/sanderdescamps/govc_exporter/internal/config"
+	"github.com/sanderdescamps/govc_exporter/internal/database/objects"
+	"github.com/sanderdescamps/govc_exporter/internal/helper"
+	"github.com/sanderdescamps/govc_exporter/internal/scraper/logger"
+	sensormetrics "github.com/sanderdescamps/govc_exporter/internal/scraper/sensor_metrics"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/view"
+	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+const CUSTOM_ATTRIBUTES_SENSOR_NAME = "CustomAttributesSensor"
+
+type CustomAttributesSensor struct {
+	logger.SensorLogger
+	metricsCollector *sensormetrics.SensorMetricsCollector
+	statusMonitor    *sensormetrics.StatusMonitor
+	started          *helper.StartedCheck
+	sensorLock       sync.Mutex
+	manualRefresh    chan struct{}
+	stopChan         chan struct{}
+	config           config.CustomAttributesSensorConfig
+}
+
+func NewCustomAttributesSensor(scraper *VCenterScraper, config config.CustomAttributesSensorConfig, l *slog.Logger) *CustomAttributesSensor {
+	var mc *sensormetrics.SensorMetricsCollector = sensormetrics.NewLastSensorMetricsCollector()
+	var sm *sensormetrics.StatusMonitor = sensormetrics.NewStatusMonitor()
+	return &CustomAttributesSensor{
+		started:          helper.NewStartedCheck(),
+		stopChan:         make(chan struct{}),
+		manualRefresh:    make(chan struct{}),
+		config:           config,
+		SensorLogger:     logger.NewSLogLogger(l, logger.WithKind(CUSTOM_ATTRIBUTES_SENSOR_NAME)),
+		metricsCollector: mc,
+		statusMonitor:    sm,
+	}
+}
+
+func (s *CustomAttributesSensor) refresh(ctx context.Context, scraper *VCenterScraper) error {
+	if ok := s.sensorLock.TryLock(); !ok {
+		return ErrSensorAlreadyRunning
+	}
+	defer s.sensorLock.Unlock()
+
+	sensorStopwatch := sensormetrics.NewSensorStopwatch()
+	sensorStopwatch.Start()
+
+	client, release, err := scraper.clientPool.AcquireWithContext(ctx)
+	if err != nil {
+		return ErrSensorCientFailed
+	}
+	defer release()
+	sensorStopwatch.Mark1()
+
+	fieldManager, err := object.GetCustomFieldsManager(client.Client)
+	if err != nil {
+		return NewSensorError("failed to get custom fields manager", "err", err)
+	}
+
+	fieldDefs, err := fieldManager.Field(ctx)
+	if err != nil {
+		return NewSensorError("failed to get custom field definitions", "err", err)
+	}
+
+	fieldNames := map[int32]string{}
+	for _, def := range fieldDefs {
+		if len(s.config.AttributesToCollect) == 0 || slices.Contains(s.config.AttributesToCollect, def.Name) {
+			fieldNames[def.Key] = def.Name
+		}
+	}
+
+	moTypes := []string{
+		string(types.ManagedObjectTypesClusterComputeResource),
+		string(types.ManagedObjectTypesDatastore),
+		string(types.ManagedObjectTypesHostSystem),
+		string(types.ManagedObjectTypesResourcePool),
+		string(types.ManagedObjectTypesStoragePod),
+		string(types.ManagedObjectTypesVirtualMachine),
+	}
+
+	m := view.NewManager(client.Client)
+	v, err := m.CreateContainerView(
+		ctx,
+		client.ServiceContent.RootFolder,
+		moTypes,
+		true,
+	)
+	if err != nil {
+		return NewSensorError("failed to create container view", "err", err)
+	}
+	defer v.Destroy(ctx)
+
+	var entities []mo.ManagedEntity
+	err = v.Retrieve(
+		ctx,
+		moTypes,
+		[]string{"customValue"},
+		&entities,
+	)
+	if err != nil {
+		return NewSensorError("failed to retrieve custom values", "err", err)
+	}
+
+	sensorStopwatch.Finish()
+	s.metricsCollector.UploadStats(sensorStopwatch.GetStats())
+
+	for _, entity := range entities {
+		attrSet := ConvertToAttributeSet(entity, fieldNames)
+		if len(attrSet.Attributes) == 0 {
+			continue
+		}
+
+		err := scraper.DB.SetAttributes(ctx, attrSet, s.config.MaxAge)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func ConvertToAttributeSet(entity mo.ManagedEntity, fieldNames map[int32]string) objects.AttributeSet {
+	attrSet := objects.NewAttributeSet(objects.NewManagedObjectReferenceFromVMwareRef(entity.Self))
+	for _, value := range entity.CustomValue {
+		if strValue, ok := value.(*types.CustomFieldStringValue); ok {
+			if name, ok := fieldNames[strValue.Key]; ok {
+				attrSet.Attributes[name] = strValue.Value
+			}
+		}
+	}
+	return attrSet
+}
+
+func (s *CustomAttributesSensor) Init(ctx context.Context, scraper *VCenterScraper) error {
+	if !s.started.IsStarted() {
+		err := s.refresh(ctx, scraper)
+		if err != nil {
+			s.statusMonitor.Fail()
+			return err
+		}
+		s.statusMonitor.Success()
+		s.started.Started()
+	} else {
+		return ErrSensorAlreadyStarted
+	}
+	return nil
+}
+
+func (s *CustomAttributesSensor) StartRefresher(ctx context.Context, scraper *VCenterScraper) error {
+	ticker := time.NewTicker(s.config.RefreshInterval)
+	go func() {
+		time.Sleep(time.Duration(rand.Intn(20000)) * time.Millisecond)
+		for {
+			select {
+			case <-ticker.C:
+				go func() {
+					err := s.refresh(ctx, scraper)
+					if err == nil {
+						s.SensorLogger.Debug("refresh successful")
+						s.statusMonitor.Success()
+					} else {
+						s.SensorLogger.Error("refresh failed", "err", err)
+						s.statusMonitor.Fail()
+					}
+				}()
+			case <-s.manualRefresh:
+				go func() {
+					s.SensorLogger.Info("trigger manual refresh")
+					err := s.refresh(ctx, scraper)
+					if err == nil {
+						s.SensorLogger.Info("manual refresh successful")
+						s.statusMonitor.Success()
+					} else {
+						s.SensorLogger.Error("manual refresh failed", "err", err)
+						s.statusMonitor.Fail()
+					}
+				}()
+			case <-s.stopChan:
+				s.started.Stopped()
+				ticker.Stop()
+			case <-ctx.Done():
+				s.started.Stopped()
+				ticker.Stop()
+			}
+		}
+	}()
+	return nil
+}
+
+func (s *CustomAttributesSensor) StopRefresher(ctx context.Context) {
+	close(s.stopChan)
+}
+
+func (s *CustomAttributesSensor) TriggerManualRefresh(ctx context.Context) {
+	s.manualRefresh <- struct{}{}
+}
+
+func (s *CustomAttributesSensor) Kind() string {
+	return "CustomAttributesSensor"
+}
+
+func (s *CustomAttributesSensor) WaitTillStartup() {
+	s.started.Wait()
+}
+
+func (s *CustomAttributesSensor) Match(name string) bool {
+	return helper.NewMatcher("attributes", "attribute", "custom_attributes", "customattributes").Match(name)
+}
+
+func (s *CustomAttributesSensor) Enabled() bool {
+	return true
+}
+
+func (s *CustomAttributesSensor) GetLatestMetrics() []sensormetrics.SensorMetric {
+	return append(
+		s.metricsCollector.ComposeMetrics(s.Kind()),
+		sensormetrics.SensorMetric{
+			Sensor:     s.Kind(),
+			MetricName: "failed",
+			Value:      s.statusMonitor.StatusFailedFloat64(),
+			Unit:       "boolean",
+		}, sensormetrics.SensorMetric{
+			Sensor:     s.Kind(),
+			MetricName: "fail_rate",
+			Value:      s.statusMonitor.FailRate(),
+			Unit:       "boolean",
+		}, sensormetrics.SensorMetric{
+			Sensor:     s.Kind(),
+			MetricName: "enabled",
+			Value:      1.0,
+			Unit:       "boolean",
+		},
+	)
+}
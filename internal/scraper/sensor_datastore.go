@@ -2,6 +2,7 @@ package scraper
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
 	"math/rand"
 	"reflect"
@@ -41,6 +42,7 @@ func NewDatastoreSensor(scraper *VCenterScraper, config config.SensorConfig, l *
 				"parent",
 				"summary",
 				"info",
+				"vm",
 			}, mc, sm),
 		started:          helper.NewStartedCheck(),
 		stopChan:         make(chan struct{}),
@@ -188,8 +190,12 @@ func ConvertToDatastore(ctx context.Context, scraper *VCenterScraper, d mo.Datas
 
 	kind := ExtractKindFromDatastore(d)
 	var vmfsInfo *objects.DatastoreVmfsInfo
-	if kind == "vmfs" {
+	var nasInfo *objects.DatastoreNasInfo
+	switch kind {
+	case "vmfs":
 		vmfsInfo = ExtractVmfsInfoFromDatastore(d)
+	case "nas":
+		nasInfo = ExtractNasInfoFromDatastore(d)
 	}
 
 	datastore := objects.Datastore{
@@ -199,17 +205,20 @@ func ConvertToDatastore(ctx context.Context, scraper *VCenterScraper, d mo.Datas
 		Self:      self,
 		Parent:    parent,
 		VmfsInfo:  vmfsInfo,
+		NasInfo:   nasInfo,
 	}
 
 	if datastore.Parent != nil {
 		parentChain := scraper.DB.GetParentChain(ctx, *datastore.Parent)
 		datastore.DatastoreCluster = parentChain.SPOD
+		datastore.FolderPath = parentChain.FolderPath()
 	}
 
 	summary := d.Summary
 	datastore.Accessible = summary.Accessible
 	datastore.Capacity = float64(summary.Capacity)
 	datastore.FreeSpace = float64(summary.FreeSpace)
+	datastore.Uncommitted = float64(summary.Uncommitted)
 	datastore.Maintenance = summary.MaintenanceMode
 
 	for _, hostMountInfo := range d.Host {
@@ -224,6 +233,10 @@ func ConvertToDatastore(ctx context.Context, scraper *VCenterScraper, d mo.Datas
 		})
 	}
 
+	for _, vm := range d.Vm {
+		datastore.VMs = append(datastore.VMs, vm.Value)
+	}
+
 	datastore.OverallStatus = string(d.OverallStatus)
 
 	return datastore
@@ -264,8 +277,34 @@ func ExtractVmfsInfoFromDatastore(d mo.Datastore) *objects.DatastoreVmfsInfo {
 					}
 					return ""
 				}(),
+				Version:            vmfs.Version,
+				BlockSize:          float64(int64(vmfs.BlockSize) * 1024),
+				UnmapPriority:      vmfs.UnmapPriority,
+				UnmapGranularity:   float64(int64(vmfs.UnmapGranularity) * 1024),
+				UnmapBandwidthSpec: unmapBandwidthSpec(vmfs.UnmapBandwidthSpec),
 			}
 		}
 	}
 	return nil
 }
+
+func unmapBandwidthSpec(spec *types.VmfsUnmapBandwidthSpec) string {
+	if spec == nil {
+		return ""
+	}
+	if spec.Policy == string(types.HostVmfsVolumeUnmapBandwidthPolicyFixed) {
+		return fmt.Sprintf("%s:%dMB/s", spec.Policy, spec.FixedValue)
+	}
+	return fmt.Sprintf("%s:%d-%dMB/s", spec.Policy, spec.DynamicMin, spec.DynamicMax)
+}
+
+func ExtractNasInfoFromDatastore(d mo.Datastore) *objects.DatastoreNasInfo {
+	if info, ok := d.Info.(*types.NasDatastoreInfo); ok && info.Nas != nil {
+		return &objects.DatastoreNasInfo{
+			Type:       info.Nas.Type,
+			RemoteHost: info.Nas.RemoteHost,
+			RemotePath: info.Nas.RemotePath,
+		}
+	}
+	return nil
+}
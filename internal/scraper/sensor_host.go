@@ -34,10 +34,10 @@ type HostSensor struct {
 	sensorLock    sync.Mutex
 	manualRefresh chan struct{}
 	stopChan      chan struct{}
-	config        config.SensorConfig
+	config        config.HostSensorConfig
 }
 
-func NewHostSensor(scraper *VCenterScraper, config config.SensorConfig, l *slog.Logger) *HostSensor {
+func NewHostSensor(scraper *VCenterScraper, config config.HostSensorConfig, l *slog.Logger) *HostSensor {
 	var mc *sensormetrics.SensorMetricsCollector = sensormetrics.NewAvgSensorMetricsCollector(50)
 	var sm *sensormetrics.StatusMonitor = sensormetrics.NewStatusMonitor()
 	return &HostSensor{
@@ -138,21 +138,31 @@ func (s *HostSensor) queryHostsInContainer(ctx context.Context, scraper *VCenter
 	}
 	defer v.Destroy(ctx)
 
+	properties := []string{
+		"name",
+		"parent",
+		"summary",
+		"runtime",
+		"config.storageDevice",
+		"config.fileSystemVolume",
+		// "network",
+		"hardware",
+		"vm",
+	}
+	if s.config.Hardware {
+		properties = append(properties, "config.pciPassthruInfo", "config.graphicsInfo")
+	}
+	if s.config.Network {
+		properties = append(properties, "config.network", "config.virtualNicManagerInfo")
+	} else {
+		properties = append(properties, "config.network.pnic")
+	}
+
 	var entities []mo.HostSystem
 	err = v.Retrieve(
 		ctx,
 		[]string{"HostSystem"},
-		[]string{
-			"name",
-			"parent",
-			"summary",
-			"runtime",
-			"config.storageDevice",
-			"config.fileSystemVolume",
-			// "network",
-			"hardware",
-			"vm",
-		},
+		properties,
 		&entities,
 	)
 	sensorStopwatch.Finish()
@@ -312,6 +322,7 @@ func ConvertToHost(ctx context.Context, scraper *VCenterScraper, h mo.HostSystem
 		parentChain := scraper.DB.GetParentChain(ctx, *host.Parent)
 		host.Cluster = parentChain.Cluster
 		host.Datacenter = parentChain.DC
+		host.FolderPath = parentChain.FolderPath()
 	}
 
 	summary := h.Summary
@@ -347,6 +358,13 @@ func ConvertToHost(ctx context.Context, scraper *VCenterScraper, h mo.HostSystem
 	if h.Hardware != nil && h.Hardware.BiosInfo != nil {
 		host.BiosVersion = h.Hardware.BiosInfo.BiosVersion
 	}
+	if h.Hardware != nil {
+		host.HardwareMemoryBytes = float64(h.Hardware.MemorySize)
+	}
+	if tpm := summary.TpmAttestation; tpm != nil {
+		host.TPMAttestationStatus = string(tpm.Status)
+		host.TPMAttestationTime = tpm.Time
+	}
 
 	runtime := h.Runtime
 	host.PowerState = string(runtime.PowerState)
@@ -408,6 +426,14 @@ func ConvertToHost(ctx context.Context, scraper *VCenterScraper, h mo.HostSystem
 	host.HBA = getHBAs(h)
 	host.Luns = getSCSILuns(h)
 	host.MultipathPathInfo = getMultipathInfo(h)
+	SetIscsiSessionState(host.HBA, host.MultipathPathInfo)
+	host.CPUPackages = getCPUPackages(h)
+	host.PCIDevices = getPCIDevices(h)
+	host.GPUs = getGPUs(h)
+	host.PhysicalNICs = getPhysicalNICs(h)
+	host.VirtualSwitches = getVirtualSwitches(h)
+	host.PortGroups = getPortGroups(h)
+	host.VMKernelNICs = getVMKernelNICs(h)
 
 	return host
 }
@@ -513,59 +539,337 @@ func getHBAs(host mo.HostSystem) []objects.HostBusAdapter {
 	if config := host.Config; config != nil {
 		if storDev := config.StorageDevice; storDev != nil {
 			for _, adapter := range storDev.HostBusAdapter {
+				baseHba := adapter.GetHostHostBusAdapter()
+				hba := objects.HostBusAdapter{
+					Type:     "generic",
+					Device:   baseHba.Device,
+					Model:    cleanString(baseHba.Model),
+					Driver:   baseHba.Driver,
+					PCI:      baseHba.Pci,
+					Status:   baseHba.Status,
+					Protocol: baseHba.StorageProtocol,
+				}
+
 				ihba := reflect.ValueOf(adapter).Elem().Interface()
-				switch hba := ihba.(type) {
+				switch a := ihba.(type) {
 				case types.HostInternetScsiHba:
-					iscsiDiscoveryTarget := []objects.IscsiDiscoveryTarget{}
-					for _, target := range hba.ConfiguredSendTarget {
-						iscsiDiscoveryTarget = append(iscsiDiscoveryTarget, objects.IscsiDiscoveryTarget{
+					hba.Type = "iscsi"
+					hba.IscsiInitiatorIQN = a.IScsiName
+					hba.IscsiSoftware = a.IsSoftwareBased
+					hba.SpeedMbps = float64(a.CurrentSpeedMb)
+
+					hba.IscsiDiscoveryTarget = []objects.IscsiDiscoveryTarget{}
+					for _, target := range a.ConfiguredSendTarget {
+						hba.IscsiDiscoveryTarget = append(hba.IscsiDiscoveryTarget, objects.IscsiDiscoveryTarget{
 							Address: target.Address,
 							Port:    target.Port,
 						})
 					}
 
-					iscsiStaticTarget := []objects.IscsiStaticTarget{}
-					for _, target := range hba.ConfiguredStaticTarget {
-						iscsiStaticTarget = append(iscsiStaticTarget, objects.IscsiStaticTarget{
+					hba.IscsiStaticTarget = []objects.IscsiStaticTarget{}
+					for _, target := range a.ConfiguredStaticTarget {
+						hba.IscsiStaticTarget = append(hba.IscsiStaticTarget, objects.IscsiStaticTarget{
 							Address:         target.Address,
 							Port:            target.Port,
 							IQN:             target.IScsiName,
 							DiscoveryMethod: target.DiscoveryMethod,
 						})
 					}
+				case types.HostFibreChannelHba:
+					hba.Type = "fc"
+					setFibreChannelInfo(&hba, a)
+				case types.HostFibreChannelOverEthernetHba:
+					hba.Type = "fcoe"
+					hba.AssociatedDevice = a.UnderlyingNic
+					setFibreChannelInfo(&hba, a.HostFibreChannelHba)
+				case types.HostTcpHba:
+					hba.Type = "tcp"
+					hba.AssociatedDevice = a.AssociatedPnic
+				case types.HostRdmaHba:
+					hba.Type = "rdma"
+					hba.AssociatedDevice = a.AssociatedRdmaDevice
+				}
 
-					res = append(res, objects.HostBusAdapter{
-						Type:                 "iscsi",
-						Device:               hba.Device,
-						Model:                cleanString(hba.Model),
-						Driver:               hba.Driver,
-						Status:               hba.Status,
-						Protocol:             hba.StorageProtocol,
-						IscsiInitiatorIQN:    hba.IScsiName,
-						IscsiDiscoveryTarget: iscsiDiscoveryTarget,
-						IscsiStaticTarget:    iscsiStaticTarget,
-					})
-				// case types.HostTcpHba:
-				// case types.HostBlockHba:
-				// case types.HostFibreChannelHba:
-				// case types.HostParallelScsiHba:
-				// case types.HostPcieHba:
-				// case types.HostRdmaDevice:
-				// case types.HostSerialAttachedHba:
-				default:
-					baseHba := adapter.GetHostHostBusAdapter()
-					res = append(res, objects.HostBusAdapter{
-						Type:     "generic",
-						Device:   baseHba.Device,
-						Model:    cleanString(baseHba.Model),
-						Driver:   baseHba.Driver,
-						Status:   baseHba.Status,
-						Protocol: baseHba.StorageProtocol,
-					})
+				hba.NvmeControllers = getNvmeControllers(storDev.NvmeTopology, baseHba.Key)
+				res = append(res, hba)
+			}
+		}
+	}
+	return res
+}
+
+func setFibreChannelInfo(hba *objects.HostBusAdapter, fc types.HostFibreChannelHba) {
+	hba.FcNodeWWN = FormatWWN(fc.NodeWorldWideName)
+	hba.FcPortWWN = FormatWWN(fc.PortWorldWideName)
+	hba.FcPortType = string(fc.PortType)
+	hba.SpeedMbps = float64(fc.Speed * 1000)
+}
+
+// Return the NVMe controllers connected to the adapter with the given key
+func getNvmeControllers(topology *types.HostNvmeTopology, adapterKey string) []objects.HostNvmeController {
+	res := []objects.HostNvmeController{}
+	if topology == nil {
+		return res
+	}
+	for _, iface := range topology.Adapter {
+		if iface.Adapter != adapterKey {
+			continue
+		}
+		for _, controller := range iface.ConnectedController {
+			c := objects.HostNvmeController{
+				Name:             controller.Name,
+				ControllerNumber: controller.ControllerNumber,
+				Subnqn:           controller.Subnqn,
+				TransportType:    controller.TransportType,
+				NumberOfQueues:   float64(controller.NumberOfQueues),
+				QueueSize:        float64(controller.QueueSize),
+				Namespaces:       []objects.HostNvmeNamespace{},
+			}
+			for _, ns := range controller.AttachedNamespace {
+				c.Namespaces = append(c.Namespaces, objects.HostNvmeNamespace{
+					Name:          ns.Name,
+					ID:            ns.Id,
+					CapacityBytes: float64(ns.CapacityInBlocks) * float64(ns.BlockSize),
+				})
+			}
+			res = append(res, c)
+		}
+	}
+	return res
+}
+
+// Format a Fibre Channel world wide name as colon separated hex bytes
+// (20:00:00:25:b5:00:00:01)
+func FormatWWN(wwn int64) string {
+	if wwn == 0 {
+		return ""
+	}
+	hex := fmt.Sprintf("%016x", uint64(wwn))
+	parts := []string{}
+	for i := 0; i < len(hex); i += 2 {
+		parts = append(parts, hex[i:i+2])
+	}
+	return strings.Join(parts, ":")
+}
+
+// Derive the iSCSI session state of the static targets from the multipath
+// paths to the target. The vSphere API does not expose the sessions itself.
+func SetIscsiSessionState(hbas []objects.HostBusAdapter, paths []objects.MultipathPathInfo) {
+	for _, hba := range hbas {
+		for i, target := range hba.IscsiStaticTarget {
+			state := "unknown"
+			for _, p := range paths {
+				if p.Adapter != hba.Device || p.IscsiTargetIQN != target.IQN {
+					continue
+				}
+				if strings.EqualFold(p.State, "active") || strings.EqualFold(p.State, "standby") {
+					state = "online"
+					break
+				}
+				state = "offline"
+			}
+			hba.IscsiStaticTarget[i].SessionState = state
+		}
+	}
+}
+
+func getCPUPackages(host mo.HostSystem) []objects.HostCPUPackage {
+	res := []objects.HostCPUPackage{}
+	if host.Hardware == nil {
+		return res
+	}
+	for _, pkg := range host.Hardware.CpuPkg {
+		res = append(res, objects.HostCPUPackage{
+			Index:       int(pkg.Index),
+			Vendor:      pkg.Vendor,
+			Description: cleanString(pkg.Description),
+			Hz:          float64(pkg.Hz),
+			Threads:     float64(len(pkg.ThreadId)),
+		})
+	}
+	return res
+}
+
+func getPCIDevices(host mo.HostSystem) []objects.HostPCIDevice {
+	res := []objects.HostPCIDevice{}
+	if host.Hardware == nil {
+		return res
+	}
+
+	passthru := map[string]*types.HostPciPassthruInfo{}
+	if host.Config != nil {
+		for _, info := range host.Config.PciPassthruInfo {
+			i := info.GetHostPciPassthruInfo()
+			passthru[i.Id] = i
+		}
+	}
+
+	for _, device := range host.Hardware.PciDevice {
+		d := objects.HostPCIDevice{
+			ID:         device.Id,
+			ClassID:    fmt.Sprintf("%04x", uint16(device.ClassId)),
+			VendorName: cleanString(device.VendorName),
+			DeviceName: cleanString(device.DeviceName),
+		}
+		if info, ok := passthru[device.Id]; ok {
+			d.PassthruCapable = info.PassthruCapable
+			d.PassthruEnabled = info.PassthruEnabled
+			d.PassthruActive = info.PassthruActive
+		}
+		res = append(res, d)
+	}
+	return res
+}
+
+func getGPUs(host mo.HostSystem) []objects.HostGPU {
+	res := []objects.HostGPU{}
+	if host.Config == nil {
+		return res
+	}
+	for _, info := range host.Config.GraphicsInfo {
+		gpu := objects.HostGPU{
+			PCIID:        info.PciId,
+			VendorName:   cleanString(info.VendorName),
+			DeviceName:   cleanString(info.DeviceName),
+			GraphicsType: info.GraphicsType,
+			VGPUMode:     info.VgpuMode,
+			MemoryBytes:  float64(info.MemorySizeInKB * 1024),
+			VMs:          []objects.ManagedObjectReference{},
+		}
+		for _, vm := range info.Vm {
+			gpu.VMs = append(gpu.VMs, objects.NewManagedObjectReferenceFromVMwareRef(vm))
+		}
+		res = append(res, gpu)
+	}
+	return res
+}
+
+func getPhysicalNICs(host mo.HostSystem) []objects.HostPhysicalNIC {
+	res := []objects.HostPhysicalNIC{}
+	if host.Config == nil || host.Config.Network == nil {
+		return res
+	}
+	for _, pnic := range host.Config.Network.Pnic {
+		nic := objects.HostPhysicalNIC{
+			Device:          pnic.Device,
+			PCI:             pnic.Pci,
+			Mac:             pnic.Mac,
+			Driver:          pnic.Driver,
+			DriverVersion:   pnic.DriverVersion,
+			FirmwareVersion: pnic.FirmwareVersion,
+		}
+		if pnic.LinkSpeed != nil {
+			nic.LinkUp = true
+			nic.LinkSpeedMbps = float64(pnic.LinkSpeed.SpeedMb)
+			nic.FullDuplex = pnic.LinkSpeed.Duplex
+		}
+		res = append(res, nic)
+	}
+	return res
+}
+
+func getVirtualSwitches(host mo.HostSystem) []objects.HostVirtualSwitch {
+	res := []objects.HostVirtualSwitch{}
+	if host.Config == nil || host.Config.Network == nil {
+		return res
+	}
+
+	pnicNames := map[string]string{}
+	for _, pnic := range host.Config.Network.Pnic {
+		pnicNames[pnic.Key] = pnic.Device
+	}
+
+	for _, vswitch := range host.Config.Network.Vswitch {
+		sw := objects.HostVirtualSwitch{
+			Name:     vswitch.Name,
+			MTU:      float64(vswitch.Mtu),
+			NumPorts: float64(vswitch.NumPorts),
+			Uplinks:  []string{},
+		}
+		for _, key := range vswitch.Pnic {
+			if name, ok := pnicNames[key]; ok {
+				sw.Uplinks = append(sw.Uplinks, name)
+			}
+		}
+		if policy := vswitch.Spec.Policy; policy != nil {
+			sw.Security = convertSecurityPolicy(policy.Security)
+		}
+		res = append(res, sw)
+	}
+	return res
+}
+
+func getPortGroups(host mo.HostSystem) []objects.HostPortGroup {
+	res := []objects.HostPortGroup{}
+	if host.Config == nil || host.Config.Network == nil {
+		return res
+	}
+	for _, pg := range host.Config.Network.Portgroup {
+		res = append(res, objects.HostPortGroup{
+			Name:     pg.Spec.Name,
+			VSwitch:  pg.Spec.VswitchName,
+			VlanID:   float64(pg.Spec.VlanId),
+			NumPorts: float64(len(pg.Port)),
+			Security: convertSecurityPolicy(pg.ComputedPolicy.Security),
+		})
+	}
+	return res
+}
+
+func convertSecurityPolicy(policy *types.HostNetworkSecurityPolicy) objects.HostNetworkSecurityPolicy {
+	if policy == nil {
+		return objects.HostNetworkSecurityPolicy{}
+	}
+	return objects.HostNetworkSecurityPolicy{
+		AllowPromiscuous: policy.AllowPromiscuous != nil && *policy.AllowPromiscuous,
+		MacChanges:       policy.MacChanges != nil && *policy.MacChanges,
+		ForgedTransmits:  policy.ForgedTransmits != nil && *policy.ForgedTransmits,
+	}
+}
+
+func getVMKernelNICs(host mo.HostSystem) []objects.HostVMKernelNIC {
+	res := []objects.HostVMKernelNIC{}
+	if host.Config == nil || host.Config.Network == nil {
+		return res
+	}
+
+	// vnic device => enabled services
+	services := map[string][]string{}
+	if info := host.Config.VirtualNicManagerInfo; info != nil {
+		for _, netConfig := range info.NetConfig {
+			for _, vnic := range netConfig.CandidateVnic {
+				if slices.Contains(netConfig.SelectedVnic, vnic.Key) {
+					services[vnic.Device] = append(services[vnic.Device], netConfig.NicType)
 				}
 			}
 		}
 	}
+
+	for _, vnic := range host.Config.Network.Vnic {
+		nic := objects.HostVMKernelNIC{
+			Device:    vnic.Device,
+			PortGroup: vnic.Portgroup,
+			Mac:       vnic.Spec.Mac,
+			MTU:       float64(vnic.Spec.Mtu),
+			NetStack:  vnic.Spec.NetStackInstanceKey,
+			Services:  services[vnic.Device],
+		}
+		if nic.Services == nil {
+			nic.Services = []string{}
+		}
+		if nic.NetStack == "" {
+			nic.NetStack = "defaultTcpipStack"
+		}
+		if ip := vnic.Spec.Ip; ip != nil {
+			nic.IP = ip.IpAddress
+			nic.SubnetMask = ip.SubnetMask
+			nic.DHCP = ip.Dhcp
+		}
+		if port := vnic.Spec.DistributedVirtualPort; port != nil {
+			nic.DistributedSwitch = port.SwitchUuid
+		}
+		res = append(res, nic)
+	}
 	return res
 }
 
@@ -601,6 +905,18 @@ func getMultipathInfo(host mo.HostSystem) []objects.MultipathPathInfo {
 								mpPathInfo.IscsiTargetAddress = transport.Address[0]
 							}
 							mpPathInfo.IscsiTargetIQN = transport.IScsiName
+						case types.HostFibreChannelTargetTransport:
+							mpPathInfo.Type = "fc"
+							mpPathInfo.FcTargetNodeWWN = FormatWWN(transport.NodeWorldWideName)
+							mpPathInfo.FcTargetPortWWN = FormatWWN(transport.PortWorldWideName)
+						case types.HostFibreChannelOverEthernetTargetTransport:
+							mpPathInfo.Type = "fcoe"
+							mpPathInfo.FcTargetNodeWWN = FormatWWN(transport.NodeWorldWideName)
+							mpPathInfo.FcTargetPortWWN = FormatWWN(transport.PortWorldWideName)
+						case types.HostTcpTargetTransport:
+							mpPathInfo.Type = "tcp"
+						case types.HostRdmaTargetTransport:
+							mpPathInfo.Type = "rdma"
 						default:
 							mpPathInfo.Type = "generic"
 						}
@@ -0,0 +1,254 @@
+package scraper
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/sanderdescamps/govc_exporter/internal/config"
+	"github.com/sanderdescamps/govc_exporter/internal/database/objects"
+	"github.com/sanderdescamps/govc_exporter/internal/helper"
+	"github.com/sanderdescamps/govc_exporter/internal/scraper/logger"
+	sensormetrics "github.com/sanderdescamps/govc_exporter/internal/scraper/sensor_metrics"
+	"github.com/vmware/govmomi/fault"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+const HOST_OPTIONS_SENSOR_NAME = "HostOptionsSensor"
+
+type HostOptionsSensor struct {
+	logger.SensorLogger
+	metricsCollector *sensormetrics.SensorMetricsCollector
+	statusMonitor    *sensormetrics.StatusMonitor
+	started          *helper.StartedCheck
+	sensorLock       sync.Mutex
+	manualRefresh    chan struct{}
+	stopChan         chan struct{}
+	config           config.HostOptionsSensorConfig
+	rules            []config.HostOptionRule
+}
+
+func NewHostOptionsSensor(scraper *VCenterScraper, config config.HostOptionsSensorConfig, l *slog.Logger) *HostOptionsSensor {
+	var mc *sensormetrics.SensorMetricsCollector = sensormetrics.NewAvgSensorMetricsCollector(100)
+	var sm *sensormetrics.StatusMonitor = sensormetrics.NewStatusMonitor()
+
+	// Rules are validated together with the rest of the config
+	rules, _ := config.ParseDesiredOptions()
+
+	return &HostOptionsSensor{
+		started:          helper.NewStartedCheck(),
+		stopChan:         make(chan struct{}),
+		manualRefresh:    make(chan struct{}),
+		config:           config,
+		rules:            rules,
+		SensorLogger:     logger.NewSLogLogger(l, logger.WithKind(HOST_OPTIONS_SENSOR_NAME)),
+		metricsCollector: mc,
+		statusMonitor:    sm,
+	}
+}
+
+func (s *HostOptionsSensor) refresh(ctx context.Context, scraper *VCenterScraper) error {
+	if ok := s.sensorLock.TryLock(); !ok {
+		return ErrSensorAlreadyRunning
+	}
+	defer s.sensorLock.Unlock()
+
+	if scraper.Host == nil {
+		s.SensorLogger.Error("Can't query for host advanced options if host sensor is not defined")
+		return fmt.Errorf("no host sensor found")
+	}
+	(scraper.Host).(*HostSensor).WaitTillStartup()
+
+	hosts, err := scraper.DB.GetAllHost(ctx)
+	if err != nil {
+		return err
+	}
+
+	var wg sync.WaitGroup
+	var errsLock sync.Mutex
+	errs := []error{}
+	wg.Add(len(hosts))
+	for _, host := range hosts {
+		go func() {
+			defer wg.Done()
+
+			tags := scraper.DB.GetTags(ctx, host.Self)
+			desired := config.DesiredHostOptions(s.rules, host.Cluster, tags.Tags)
+			if len(desired) == 0 {
+				return
+			}
+
+			options, err := s.queryOptionsForHost(ctx, scraper, host.Self.ToVMwareRef(), desired)
+			if err != nil {
+				s.SensorLogger.Error("Failed to get advanced options for host", "host", host.Self.Value, "err", err)
+				errsLock.Lock()
+				errs = append(errs, fmt.Errorf("host %s: %w", host.Self.Value, err))
+				errsLock.Unlock()
+				return
+			}
+
+			err = scraper.DB.SetHostOptions(ctx, objects.HostOptions{
+				Timestamp: time.Now(),
+				Host:      host.Self,
+				Options:   options,
+			}, s.config.MaxAge)
+			if err != nil {
+				s.SensorLogger.Error("failed to store host advanced options", "host", host.Self.Value, "err", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	return errors.Join(errs...)
+}
+
+func (s *HostOptionsSensor) queryOptionsForHost(ctx context.Context, scraper *VCenterScraper, hostRef types.ManagedObjectReference, desired map[string]config.HostOptionRule) ([]objects.HostOption, error) {
+	sensorStopwatch := sensormetrics.NewSensorStopwatch()
+	sensorStopwatch.Start()
+	client, release, err := scraper.clientPool.AcquireWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	sensorStopwatch.Mark1()
+
+	m, err := object.NewHostSystem(client.Client, hostRef).ConfigManager().OptionManager(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := []objects.HostOption{}
+	for key, rule := range desired {
+		option := objects.HostOption{
+			Key:      key,
+			Rule:     rule.Rule,
+			Expected: rule.Value,
+		}
+
+		values, err := m.Query(ctx, key)
+		if fault.Is(err, &types.InvalidName{}) {
+			option.Missing = true
+		} else if err != nil {
+			return nil, err
+		}
+		for _, v := range values {
+			if o := v.GetOptionValue(); o.Key == key {
+				option.Actual = fmt.Sprint(o.Value)
+			}
+		}
+
+		option.Drift = option.Missing || !rule.Evaluate(option.Actual)
+		result = append(result, option)
+	}
+
+	sensorStopwatch.Finish()
+	s.metricsCollector.UploadStats(sensorStopwatch.GetStats())
+	return result, nil
+}
+
+func (s *HostOptionsSensor) Init(ctx context.Context, scraper *VCenterScraper) error {
+	if !s.started.IsStarted() {
+		err := s.refresh(ctx, scraper)
+		if err != nil {
+			s.statusMonitor.Fail()
+			return err
+		}
+		s.statusMonitor.Success()
+		s.started.Started()
+	} else {
+		return ErrSensorAlreadyStarted
+	}
+	return nil
+}
+
+func (s *HostOptionsSensor) StartRefresher(ctx context.Context, scraper *VCenterScraper) error {
+	ticker := time.NewTicker(s.config.RefreshInterval)
+	go func() {
+		time.Sleep(time.Duration(rand.Intn(20000)) * time.Millisecond)
+		for {
+			select {
+			case <-ticker.C:
+				go func() {
+					err := s.refresh(ctx, scraper)
+					if err == nil {
+						s.SensorLogger.Debug("refresh successful")
+						s.statusMonitor.Success()
+					} else {
+						s.SensorLogger.Error("refresh failed", "err", err)
+						s.statusMonitor.Fail()
+					}
+				}()
+			case <-s.manualRefresh:
+				go func() {
+					s.SensorLogger.Info("trigger manual refresh")
+					err := s.refresh(ctx, scraper)
+					if err == nil {
+						s.SensorLogger.Info("manual refresh successful")
+						s.statusMonitor.Success()
+					} else {
+						s.SensorLogger.Error("manual refresh failed", "err", err)
+						s.statusMonitor.Fail()
+					}
+				}()
+			case <-s.stopChan:
+				s.started.Stopped()
+				ticker.Stop()
+			case <-ctx.Done():
+				s.started.Stopped()
+				ticker.Stop()
+			}
+		}
+	}()
+	return nil
+}
+
+func (s *HostOptionsSensor) StopRefresher(ctx context.Context) {
+	close(s.stopChan)
+}
+
+func (s *HostOptionsSensor) TriggerManualRefresh(ctx context.Context) {
+	s.manualRefresh <- struct{}{}
+}
+
+func (s *HostOptionsSensor) Kind() string {
+	return "HostOptionsSensor"
+}
+
+func (s *HostOptionsSensor) WaitTillStartup() {
+	s.started.Wait()
+}
+
+func (s *HostOptionsSensor) Match(name string) bool {
+	return helper.NewMatcher("host_options", "hostoptions", "advanced_options").Match(name)
+}
+
+func (s *HostOptionsSensor) Enabled() bool {
+	return true
+}
+
+func (s *HostOptionsSensor) GetLatestMetrics() []sensormetrics.SensorMetric {
+	return append(
+		s.metricsCollector.ComposeMetrics(s.Kind()),
+		sensormetrics.SensorMetric{
+			Sensor:     s.Kind(),
+			MetricName: "failed",
+			Value:      s.statusMonitor.StatusFailedFloat64(),
+			Unit:       "boolean",
+		}, sensormetrics.SensorMetric{
+			Sensor:     s.Kind(),
+			MetricName: "fail_rate",
+			Value:      s.statusMonitor.FailRate(),
+			Unit:       "boolean",
+		}, sensormetrics.SensorMetric{
+			Sensor:     s.Kind(),
+			MetricName: "enabled",
+			Value:      1.0,
+			Unit:       "boolean",
+		},
+	)
+}
@@ -0,0 +1,336 @@
+package scraper
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/sanderdescamps/govc_exporter/internal/config"
+	"github.com/sanderdescamps/govc_exporter/internal/database/objects"
+	"github.com/sanderdescamps/govc_exporter/internal/helper"
+	"github.com/sanderdescamps/govc_exporter/internal/scraper/logger"
+	sensormetrics "github.com/sanderdescamps/govc_exporter/internal/scraper/sensor_metrics"
+	"github.com/vmware/govmomi/fault"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/property"
+	"github.com/vmware/govmomi/vim25"
+	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+const HOST_SECURITY_SENSOR_NAME = "HostSecuritySensor"
+
+type HostSecuritySensor struct {
+	BaseSensor
+	logger.SensorLogger
+	metricsCollector *sensormetrics.SensorMetricsCollector
+	statusMonitor    *sensormetrics.StatusMonitor
+	started          *helper.StartedCheck
+	sensorLock       sync.Mutex
+	manualRefresh    chan struct{}
+	stopChan         chan struct{}
+	config           config.SensorConfig
+}
+
+func NewHostSecuritySensor(scraper *VCenterScraper, config config.SensorConfig, l *slog.Logger) *HostSecuritySensor {
+	var mc *sensormetrics.SensorMetricsCollector = sensormetrics.NewLastSensorMetricsCollector()
+	var sm *sensormetrics.StatusMonitor = sensormetrics.NewStatusMonitor()
+	return &HostSecuritySensor{
+		BaseSensor: *NewBaseSensor(
+			"HostSystem", []string{
+				"config.lockdownMode",
+				"config.service",
+				"config.dateTimeInfo",
+				"config.firewall",
+				"configManager",
+			}, mc, sm),
+		started:          helper.NewStartedCheck(),
+		stopChan:         make(chan struct{}),
+		manualRefresh:    make(chan struct{}),
+		config:           config,
+		SensorLogger:     logger.NewSLogLogger(l, logger.WithKind(HOST_SECURITY_SENSOR_NAME)),
+		metricsCollector: mc,
+		statusMonitor:    sm,
+	}
+}
+
+func (s *HostSecuritySensor) refresh(ctx context.Context, scraper *VCenterScraper) error {
+	if ok := s.sensorLock.TryLock(); !ok {
+		return ErrSensorAlreadyRunning
+	}
+	defer s.sensorLock.Unlock()
+
+	if scraper.Host == nil {
+		s.SensorLogger.Error("Can't query for host security settings if host sensor is not defined")
+		return fmt.Errorf("no host sensor found")
+	}
+	(scraper.Host).(*HostSensor).WaitTillStartup()
+
+	var hosts []mo.HostSystem
+	err := s.baseRefresh(ctx, scraper, &hosts)
+	if err != nil {
+		return err
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(len(hosts))
+	for _, host := range hosts {
+		go func() {
+			defer wg.Done()
+
+			sec := ConvertToHostSecurity(host, time.Now())
+			s.queryRuntimeSettings(ctx, scraper, host, &sec)
+
+			err := scraper.DB.SetHostSecurity(ctx, sec, s.config.MaxAge)
+			if err != nil {
+				s.SensorLogger.Error("failed to store host security settings", "host", host.Self.Value, "err", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	return nil
+}
+
+// Query the settings which are not available as property of the HostSystem.
+// Failures are only logged because not every host exposes all managers.
+func (s *HostSecuritySensor) queryRuntimeSettings(ctx context.Context, scraper *VCenterScraper, host mo.HostSystem, sec *objects.HostSecurity) {
+	if host.ConfigManager.AdvancedOption == nil && host.ConfigManager.DateTimeSystem == nil && host.ConfigManager.CertificateManager == nil {
+		return
+	}
+
+	client, release, err := scraper.clientPool.AcquireWithContext(ctx)
+	if err != nil {
+		s.SensorLogger.Warn("failed to acquire client", "host", host.Self.Value, "err", err)
+		return
+	}
+	defer release()
+
+	if ref := host.ConfigManager.AdvancedOption; ref != nil {
+		m := object.NewOptionManager(client.Client, *ref)
+		options := []types.BaseOptionValue{}
+		queried := true
+		for _, key := range []string{"UserVars.ESXiShellTimeOut", "UserVars.ESXiShellInteractiveTimeOut"} {
+			values, err := m.Query(ctx, key)
+			if fault.Is(err, &types.InvalidName{}) {
+				s.SensorLogger.Debug("shell timeout option not available", "host", host.Self.Value, "key", key)
+				queried = false
+				break
+			} else if err != nil {
+				s.SensorLogger.Warn("failed to query shell timeouts", "host", host.Self.Value, "key", key, "err", err)
+				queried = false
+				break
+			}
+			options = append(options, values...)
+		}
+		if queried {
+			sec.ShellTimeout, sec.ShellInteractiveTimeout = ExtractShellTimeouts(options)
+			sec.ShellTimeoutsQueried = true
+		}
+	}
+
+	if ref := host.ConfigManager.DateTimeSystem; ref != nil {
+		before := time.Now()
+		hostTime, err := object.NewHostDateTimeSystem(client.Client, *ref).Query(ctx)
+		if err != nil {
+			s.SensorLogger.Warn("failed to query host time", "host", host.Self.Value, "err", err)
+		} else if hostTime != nil {
+			// Compare with the middle of the request to compensate for the round trip
+			localTime := before.Add(time.Since(before) / 2)
+			sec.ClockDrift = hostTime.Sub(localTime).Seconds()
+			sec.ClockDriftQueried = true
+		}
+	}
+
+	if ref := host.ConfigManager.CertificateManager; ref != nil {
+		err := queryHostCertificate(ctx, client.Client, *ref, sec)
+		if err != nil {
+			s.SensorLogger.Warn("failed to query host certificate", "host", host.Self.Value, "err", err)
+		}
+	}
+}
+
+func queryHostCertificate(ctx context.Context, c *vim25.Client, ref types.ManagedObjectReference, sec *objects.HostSecurity) error {
+	var cm mo.HostCertificateManager
+	err := property.DefaultCollector(c).RetrieveOne(ctx, ref, []string{"certificateInfo"}, &cm)
+	if err != nil {
+		return err
+	}
+	if cm.CertificateInfo.NotAfter != nil {
+		sec.CertificateNotAfter = *cm.CertificateInfo.NotAfter
+	}
+	sec.CertificateSubject = cm.CertificateInfo.Subject
+	sec.CertificateIssuer = cm.CertificateInfo.Issuer
+	return nil
+}
+
+func (s *HostSecuritySensor) Init(ctx context.Context, scraper *VCenterScraper) error {
+	if !s.started.IsStarted() {
+		err := s.refresh(ctx, scraper)
+		if err != nil {
+			s.statusMonitor.Fail()
+			return err
+		}
+		s.statusMonitor.Success()
+		s.started.Started()
+	} else {
+		return ErrSensorAlreadyStarted
+	}
+	return nil
+}
+
+func (s *HostSecuritySensor) StartRefresher(ctx context.Context, scraper *VCenterScraper) error {
+	ticker := time.NewTicker(s.config.RefreshInterval)
+	go func() {
+		time.Sleep(time.Duration(rand.Intn(20000)) * time.Millisecond)
+		for {
+			select {
+			case <-ticker.C:
+				go func() {
+					err := s.refresh(ctx, scraper)
+					if err == nil {
+						s.SensorLogger.Debug("refresh successful")
+						s.statusMonitor.Success()
+					} else {
+						s.SensorLogger.Error("refresh failed", "err", err)
+						s.statusMonitor.Fail()
+					}
+				}()
+			case <-s.manualRefresh:
+				go func() {
+					s.SensorLogger.Info("trigger manual refresh")
+					err := s.refresh(ctx, scraper)
+					if err == nil {
+						s.SensorLogger.Info("manual refresh successful")
+						s.statusMonitor.Success()
+					} else {
+						s.SensorLogger.Error("manual refresh failed", "err", err)
+						s.statusMonitor.Fail()
+					}
+				}()
+			case <-s.stopChan:
+				s.started.Stopped()
+				ticker.Stop()
+			case <-ctx.Done():
+				s.started.Stopped()
+				ticker.Stop()
+			}
+		}
+	}()
+	return nil
+}
+
+func (s *HostSecuritySensor) StopRefresher(ctx context.Context) {
+	close(s.stopChan)
+}
+
+func (s *HostSecuritySensor) TriggerManualRefresh(ctx context.Context) {
+	s.manualRefresh <- struct{}{}
+}
+
+func (s *HostSecuritySensor) Kind() string {
+	return "HostSecuritySensor"
+}
+
+func (s *HostSecuritySensor) WaitTillStartup() {
+	s.started.Wait()
+}
+
+func (s *HostSecuritySensor) Match(name string) bool {
+	return helper.NewMatcher("host_security", "hostsecurity", "security").Match(name)
+}
+
+func (s *HostSecuritySensor) Enabled() bool {
+	return true
+}
+
+func (s *HostSecuritySensor) GetLatestMetrics() []sensormetrics.SensorMetric {
+	return append(
+		s.metricsCollector.ComposeMetrics(s.Kind()),
+		sensormetrics.SensorMetric{
+			Sensor:     s.Kind(),
+			MetricName: "failed",
+			Value:      s.statusMonitor.StatusFailedFloat64(),
+			Unit:       "boolean",
+		}, sensormetrics.SensorMetric{
+			Sensor:     s.Kind(),
+			MetricName: "fail_rate",
+			Value:      s.statusMonitor.FailRate(),
+			Unit:       "boolean",
+		}, sensormetrics.SensorMetric{
+			Sensor:     s.Kind(),
+			MetricName: "enabled",
+			Value:      1.0,
+			Unit:       "boolean",
+		},
+	)
+}
+
+func ConvertToHostSecurity(h mo.HostSystem, t time.Time) objects.HostSecurity {
+	sec := objects.HostSecurity{
+		Timestamp:  t,
+		Host:       objects.NewManagedObjectReferenceFromVMwareRef(h.Self),
+		Services:   []objects.HostService{},
+		NTPServers: []string{},
+	}
+
+	config := h.Config
+	if config == nil {
+		return sec
+	}
+
+	sec.LockdownMode = string(config.LockdownMode)
+
+	if config.Service != nil {
+		for _, service := range config.Service.Service {
+			sec.Services = append(sec.Services, objects.HostService{
+				Key:     service.Key,
+				Label:   service.Label,
+				Running: service.Running,
+				Policy:  service.Policy,
+			})
+		}
+	}
+
+	if config.DateTimeInfo != nil && config.DateTimeInfo.NtpConfig != nil {
+		sec.NTPServers = append(sec.NTPServers, config.DateTimeInfo.NtpConfig.Server...)
+	}
+
+	if config.Firewall != nil {
+		policy := config.Firewall.DefaultPolicy
+		sec.FirewallIncomingBlocked = policy.IncomingBlocked != nil && *policy.IncomingBlocked
+		sec.FirewallOutgoingBlocked = policy.OutgoingBlocked != nil && *policy.OutgoingBlocked
+	}
+
+	return sec
+}
+
+// Extract UserVars.ESXiShellTimeOut and UserVars.ESXiShellInteractiveTimeOut
+// from the advanced options of a host
+func ExtractShellTimeouts(options []types.BaseOptionValue) (timeout float64, interactiveTimeout float64) {
+	for _, o := range options {
+		option := o.GetOptionValue()
+		var value float64
+		switch v := option.Value.(type) {
+		case int32:
+			value = float64(v)
+		case int64:
+			value = float64(v)
+		case float64:
+			value = v
+		default:
+			continue
+		}
+
+		switch option.Key {
+		case "UserVars.ESXiShellTimeOut":
+			timeout = value
+		case "UserVars.ESXiShellInteractiveTimeOut":
+			interactiveTimeout = value
+		}
+	}
+	return timeout, interactiveTimeout
+}
@@ -0,0 +1,245 @@
+package scraper
+
+import (
+	"context"
+	"log/slog"
+	"math/rand"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sanderdescamps/govc_exporter/internal/config"
+	"github.com/sanderdescamps/govc_exporter/internal/database/objects"
+	"github.com/sanderdescamps/govc_exporter/internal/helper"
+	"github.com/sanderdescamps/govc_exporter/internal/scraper/logger"
+	sensormetrics "github.com/sanderdescamps/govc_exporter/internal/scraper/sensor_metrics"
+	"github.com/vmware/govmomi/license"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+const LICENSE_SENSOR_NAME = "LicenseSensor"
+
+type LicenseSensor struct {
+	logger.SensorLogger
+	metricsCollector *sensormetrics.SensorMetricsCollector
+	statusMonitor    *sensormetrics.StatusMonitor
+	started          *helper.StartedCheck
+	sensorLock       sync.Mutex
+	manualRefresh    chan struct{}
+	stopChan         chan struct{}
+	config           config.SensorConfig
+}
+
+func NewLicenseSensor(scraper *VCenterScraper, config config.SensorConfig, l *slog.Logger) *LicenseSensor {
+	var mc *sensormetrics.SensorMetricsCollector = sensormetrics.NewLastSensorMetricsCollector()
+	var sm *sensormetrics.StatusMonitor = sensormetrics.NewStatusMonitor()
+	return &LicenseSensor{
+		started:          helper.NewStartedCheck(),
+		stopChan:         make(chan struct{}),
+		manualRefresh:    make(chan struct{}),
+		config:           config,
+		SensorLogger:     logger.NewSLogLogger(l, logger.WithKind(LICENSE_SENSOR_NAME)),
+		metricsCollector: mc,
+		statusMonitor:    sm,
+	}
+}
+
+func (s *LicenseSensor) refresh(ctx context.Context, scraper *VCenterScraper) error {
+	if ok := s.sensorLock.TryLock(); !ok {
+		return ErrSensorAlreadyRunning
+	}
+	defer s.sensorLock.Unlock()
+
+	sensorStopwatch := sensormetrics.NewSensorStopwatch()
+	sensorStopwatch.Start()
+
+	client, release, err := scraper.clientPool.AcquireWithContext(ctx)
+	if err != nil {
+		return ErrSensorCientFailed
+	}
+	defer release()
+	sensorStopwatch.Mark1()
+
+	m := license.NewManager(client.Client)
+	licenses, err := m.List(ctx)
+	if err != nil {
+		return NewSensorError("failed to list licenses", "err", err)
+	}
+
+	am, err := m.AssignmentManager(ctx)
+	if err != nil {
+		return NewSensorError("failed to get license assignment manager", "err", err)
+	}
+	assignments, err := am.QueryAssigned(ctx, "")
+	if err != nil {
+		return NewSensorError("failed to query license assignments", "err", err)
+	}
+	sensorStopwatch.Finish()
+	s.metricsCollector.UploadStats(sensorStopwatch.GetStats())
+
+	now := time.Now()
+	for _, l := range licenses {
+		err := scraper.DB.SetLicense(ctx, ConvertToLicense(l, now), s.config.MaxAge)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, a := range assignments {
+		// Assignments of the vCenter itself use the instance uuid as entity id
+		if !strings.HasPrefix(a.EntityId, "host-") {
+			continue
+		}
+		err := scraper.DB.SetHostLicense(ctx, ConvertToHostLicense(a, now), s.config.MaxAge)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *LicenseSensor) Init(ctx context.Context, scraper *VCenterScraper) error {
+	if !s.started.IsStarted() {
+		err := s.refresh(ctx, scraper)
+		if err != nil {
+			s.statusMonitor.Fail()
+			return err
+		}
+		s.statusMonitor.Success()
+		s.started.Started()
+	} else {
+		return ErrSensorAlreadyStarted
+	}
+	return nil
+}
+
+func (s *LicenseSensor) StartRefresher(ctx context.Context, scraper *VCenterScraper) error {
+	ticker := time.NewTicker(s.config.RefreshInterval)
+	go func() {
+		time.Sleep(time.Duration(rand.Intn(20000)) * time.Millisecond)
+		for {
+			select {
+			case <-ticker.C:
+				go func() {
+					err := s.refresh(ctx, scraper)
+					if err == nil {
+						s.SensorLogger.Debug("refresh successful")
+						s.statusMonitor.Success()
+					} else {
+						s.SensorLogger.Error("refresh failed", "err", err)
+						s.statusMonitor.Fail()
+					}
+				}()
+			case <-s.manualRefresh:
+				go func() {
+					s.SensorLogger.Info("trigger manual refresh")
+					err := s.refresh(ctx, scraper)
+					if err == nil {
+						s.SensorLogger.Info("manual refresh successful")
+						s.statusMonitor.Success()
+					} else {
+						s.SensorLogger.Error("manual refresh failed", "err", err)
+						s.statusMonitor.Fail()
+					}
+				}()
+			case <-s.stopChan:
+				s.started.Stopped()
+				ticker.Stop()
+			case <-ctx.Done():
+				s.started.Stopped()
+				ticker.Stop()
+			}
+		}
+	}()
+	return nil
+}
+
+func (s *LicenseSensor) StopRefresher(ctx context.Context) {
+	close(s.stopChan)
+}
+
+func (s *LicenseSensor) TriggerManualRefresh(ctx context.Context) {
+	s.manualRefresh <- struct{}{}
+}
+
+func (s *LicenseSensor) Kind() string {
+	return "LicenseSensor"
+}
+
+func (s *LicenseSensor) WaitTillStartup() {
+	s.started.Wait()
+}
+
+func (s *LicenseSensor) Match(name string) bool {
+	return helper.NewMatcher("license", "licenses").Match(name)
+}
+
+func (s *LicenseSensor) Enabled() bool {
+	return true
+}
+
+func (s *LicenseSensor) GetLatestMetrics() []sensormetrics.SensorMetric {
+	return append(
+		s.metricsCollector.ComposeMetrics(s.Kind()),
+		sensormetrics.SensorMetric{
+			Sensor:     s.Kind(),
+			MetricName: "failed",
+			Value:      s.statusMonitor.StatusFailedFloat64(),
+			Unit:       "boolean",
+		}, sensormetrics.SensorMetric{
+			Sensor:     s.Kind(),
+			MetricName: "fail_rate",
+			Value:      s.statusMonitor.FailRate(),
+			Unit:       "boolean",
+		}, sensormetrics.SensorMetric{
+			Sensor:     s.Kind(),
+			MetricName: "enabled",
+			Value:      1.0,
+			Unit:       "boolean",
+		},
+	)
+}
+
+func ConvertToLicense(l types.LicenseManagerLicenseInfo, t time.Time) objects.License {
+	return objects.License{
+		Timestamp:      t,
+		LicenseKey:     objects.MaskLicenseKey(l.LicenseKey),
+		Name:           l.Name,
+		EditionKey:     l.EditionKey,
+		CostUnit:       l.CostUnit,
+		Total:          float64(l.Total),
+		Used:           float64(l.Used),
+		ExpirationDate: licenseExpiration(l.Properties, t),
+	}
+}
+
+func ConvertToHostLicense(a types.LicenseAssignmentManagerLicenseAssignment, t time.Time) objects.HostLicense {
+	l := a.AssignedLicense
+	return objects.HostLicense{
+		Timestamp:  t,
+		Host:       objects.NewManagedObjectReference(objects.ManagedObjectTypesHost, a.EntityId),
+		LicenseKey: objects.MaskLicenseKey(l.LicenseKey),
+		Name:       l.Name,
+		EditionKey: l.EditionKey,
+		Evaluation: l.LicenseKey == objects.LicenseKeyEvaluation || l.EditionKey == "eval",
+	}
+}
+
+// Time limited licenses have an expirationDate property, evaluation licenses
+// only report the remaining hours
+func licenseExpiration(properties []types.KeyAnyValue, t time.Time) time.Time {
+	for _, p := range properties {
+		switch p.Key {
+		case "expirationDate":
+			if date, ok := p.Value.(time.Time); ok {
+				return date
+			}
+		case "expirationHours":
+			if hours, ok := p.Value.(int32); ok {
+				return t.Add(time.Duration(hours) * time.Hour)
+			}
+		}
+	}
+	return time.Time{}
+}
@@ -0,0 +1,320 @@
+package scraper
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/rand"
+	"path"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sanderdescamps/govc_exporter/internal/config"
+	"github.com/sanderdescamps/govc_exporter/internal/database/objects"
+	"github.com/sanderdescamps/govc_exporter/internal/helper"
+	"github.com/sanderdescamps/govc_exporter/internal/scraper/logger"
+	sensormetrics "github.com/sanderdescamps/govc_exporter/internal/scraper/sensor_metrics"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+const ORPHANED_VMDK_SENSOR_NAME = "OrphanedVMDKSensor"
+
+type OrphanedVMDKSensor struct {
+	logger.SensorLogger
+	metricsCollector *sensormetrics.SensorMetricsCollector
+	statusMonitor    *sensormetrics.StatusMonitor
+	started          *helper.StartedCheck
+	sensorLock       sync.Mutex
+	manualRefresh    chan struct{}
+	stopChan         chan struct{}
+	config           config.SensorConfig
+}
+
+func NewOrphanedVMDKSensor(scraper *VCenterScraper, config config.SensorConfig, l *slog.Logger) *OrphanedVMDKSensor {
+	var mc *sensormetrics.SensorMetricsCollector = sensormetrics.NewAvgSensorMetricsCollector(100)
+	var sm *sensormetrics.StatusMonitor = sensormetrics.NewStatusMonitor()
+
+	return &OrphanedVMDKSensor{
+		started:          helper.NewStartedCheck(),
+		stopChan:         make(chan struct{}),
+		manualRefresh:    make(chan struct{}),
+		config:           config,
+		SensorLogger:     logger.NewSLogLogger(l, logger.WithKind(ORPHANED_VMDK_SENSOR_NAME)),
+		metricsCollector: mc,
+		statusMonitor:    sm,
+	}
+}
+
+func (s *OrphanedVMDKSensor) refresh(ctx context.Context, scraper *VCenterScraper) error {
+	if ok := s.sensorLock.TryLock(); !ok {
+		return ErrSensorAlreadyRunning
+	}
+	defer s.sensorLock.Unlock()
+
+	if scraper.VM == nil || scraper.Datastore == nil {
+		s.SensorLogger.Error("Can't search for orphaned vmdk files if vm or datastore sensor is not defined")
+		return fmt.Errorf("no vm or datastore sensor found")
+	}
+	(scraper.Datastore).(*DatastoreSensor).WaitTillStartup()
+	(scraper.VM).(*VirtualMachineSensor).WaitTillStartup()
+
+	vms, err := scraper.DB.GetAllVM(ctx)
+	if err != nil {
+		return err
+	}
+	registered := map[string]bool{}
+	for _, vm := range vms {
+		for _, disk := range vm.Disk {
+			registered[VMDKBaseName(disk.VMDKFile)] = true
+		}
+	}
+
+	datastores, err := scraper.DB.GetAllDatastore(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Datastore browser searches are expensive, don't run them in parallel
+	var errs []error
+	for _, ds := range datastores {
+		if !ds.Accessible {
+			continue
+		}
+		// The disk backings on vSAN and vVol datastores reference the object
+		// uuid instead of the folder name returned by the datastore browser
+		if ds.Kind == "vsan" || ds.Kind == "vvol" {
+			s.SensorLogger.Debug("Skip orphaned vmdk search", "datastore", ds.Name, "kind", ds.Kind)
+			continue
+		}
+		files, err := s.searchDatastore(ctx, scraper, ds)
+		if err != nil {
+			s.SensorLogger.Warn("Failed to search datastore for vmdk files", "datastore", ds.Name, "err", err)
+			errs = append(errs, fmt.Errorf("datastore %s: %w", ds.Name, err))
+			continue
+		}
+
+		err = scraper.DB.SetOrphanedVMDKs(ctx, objects.OrphanedVMDKs{
+			Timestamp:     time.Now(),
+			Datastore:     ds.Self,
+			DatastoreName: ds.Name,
+			Files:         FilterOrphanedVMDKs(files, registered),
+		}, s.config.MaxAge)
+		if err != nil {
+			s.SensorLogger.Error("failed to store orphaned vmdk files", "datastore", ds.Name, "err", err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (s *OrphanedVMDKSensor) searchDatastore(ctx context.Context, scraper *VCenterScraper, ds objects.Datastore) ([]objects.OrphanedVMDK, error) {
+	sensorStopwatch := sensormetrics.NewSensorStopwatch()
+	sensorStopwatch.Start()
+	client, release, err := scraper.clientPool.AcquireWithContext(ctx)
+	if err != nil {
+		return nil, ErrSensorCientFailed
+	}
+	defer release()
+	sensorStopwatch.Mark1()
+
+	browser, err := object.NewDatastore(client.Client, ds.Self.ToVMwareRef()).Browser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// VmDiskFileQuery only returns the descriptor files, flat, delta and
+	// ctk extents are reported as part of the disk
+	task, err := browser.SearchDatastoreSubFolders(ctx, fmt.Sprintf("[%s]", ds.Name), &types.HostDatastoreBrowserSearchSpec{
+		MatchPattern: []string{"*.vmdk"},
+		Query:        []types.BaseFileQuery{&types.VmDiskFileQuery{}},
+		Details: &types.FileQueryFlags{
+			FileSize:     true,
+			FileType:     true,
+			Modification: true,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	info, err := task.WaitForResult(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []types.HostDatastoreBrowserSearchResults
+	if r, ok := info.Result.(types.ArrayOfHostDatastoreBrowserSearchResults); ok {
+		results = r.HostDatastoreBrowserSearchResults
+	}
+
+	files := []objects.OrphanedVMDK{}
+	for _, result := range results {
+		for _, f := range result.File {
+			fileInfo := f.GetFileInfo()
+			file := objects.OrphanedVMDK{
+				Path: datastorePathJoin(result.FolderPath, fileInfo.Path),
+				Size: float64(fileInfo.FileSize),
+			}
+			if fileInfo.Modification != nil {
+				file.Modification = *fileInfo.Modification
+			}
+			files = append(files, file)
+		}
+	}
+
+	sensorStopwatch.Finish()
+	s.metricsCollector.UploadStats(sensorStopwatch.GetStats())
+	return files, nil
+}
+
+// Return the files that are not used by any registered disk. registered
+// contains the VMDKBaseName of all vm disks. First class disks (fcd folder)
+// exist without being attached to a vm and are never reported.
+func FilterOrphanedVMDKs(files []objects.OrphanedVMDK, registered map[string]bool) []objects.OrphanedVMDK {
+	orphaned := []objects.OrphanedVMDK{}
+	for _, f := range files {
+		if isFirstClassDisk(f.Path) {
+			continue
+		}
+		if !registered[VMDKBaseName(f.Path)] {
+			orphaned = append(orphaned, f)
+		}
+	}
+	return orphaned
+}
+
+func isFirstClassDisk(p string) bool {
+	p = datastorePathJoin(p)
+	if _, rest, found := strings.Cut(p, "] "); found {
+		p = rest
+	}
+	return strings.HasPrefix(p, "fcd/")
+}
+
+var snapshotDeltaSuffix = regexp.MustCompile(`-\d{6}\.vmdk$`)
+
+// Normalize a datastore path and strip the snapshot suffix (ex. -000001).
+// A vm running on a snapshot only references the delta disk, but the whole
+// chain is in use.
+func VMDKBaseName(p string) string {
+	return snapshotDeltaSuffix.ReplaceAllString(datastorePathJoin(p), ".vmdk")
+}
+
+// Join datastore path elements into the "[ds] folder/file" notation
+func datastorePathJoin(elem ...string) string {
+	p := path.Join(elem...)
+	if !strings.HasPrefix(p, "[") {
+		return p
+	}
+	ds, rest, found := strings.Cut(p, "]")
+	if !found {
+		return p
+	}
+	rest = strings.TrimLeft(rest, " /")
+	return ds + "] " + rest
+}
+
+func (s *OrphanedVMDKSensor) Init(ctx context.Context, scraper *VCenterScraper) error {
+	if !s.started.IsStarted() {
+		err := s.refresh(ctx, scraper)
+		if err != nil {
+			s.statusMonitor.Fail()
+			return err
+		}
+		s.statusMonitor.Success()
+		s.started.Started()
+	} else {
+		return ErrSensorAlreadyStarted
+	}
+	return nil
+}
+
+func (s *OrphanedVMDKSensor) StartRefresher(ctx context.Context, scraper *VCenterScraper) error {
+	ticker := time.NewTicker(s.config.RefreshInterval)
+	go func() {
+		time.Sleep(time.Duration(rand.Intn(20000)) * time.Millisecond)
+		for {
+			select {
+			case <-ticker.C:
+				go func() {
+					err := s.refresh(ctx, scraper)
+					if err == nil {
+						s.SensorLogger.Debug("refresh successful")
+						s.statusMonitor.Success()
+					} else {
+						s.SensorLogger.Error("refresh failed", "err", err)
+						s.statusMonitor.Fail()
+					}
+				}()
+			case <-s.manualRefresh:
+				go func() {
+					s.SensorLogger.Info("trigger manual refresh")
+					err := s.refresh(ctx, scraper)
+					if err == nil {
+						s.SensorLogger.Info("manual refresh successful")
+						s.statusMonitor.Success()
+					} else {
+						s.SensorLogger.Error("manual refresh failed", "err", err)
+						s.statusMonitor.Fail()
+					}
+				}()
+			case <-s.stopChan:
+				s.started.Stopped()
+				ticker.Stop()
+			case <-ctx.Done():
+				s.started.Stopped()
+				ticker.Stop()
+			}
+		}
+	}()
+	return nil
+}
+
+func (s *OrphanedVMDKSensor) StopRefresher(ctx context.Context) {
+	close(s.stopChan)
+}
+
+func (s *OrphanedVMDKSensor) TriggerManualRefresh(ctx context.Context) {
+	s.manualRefresh <- struct{}{}
+}
+
+func (s *OrphanedVMDKSensor) Kind() string {
+	return "OrphanedVMDKSensor"
+}
+
+func (s *OrphanedVMDKSensor) WaitTillStartup() {
+	s.started.Wait()
+}
+
+func (s *OrphanedVMDKSensor) Match(name string) bool {
+	return helper.NewMatcher("orphaned_vmdk", "orphanedvmdk", "orphaned").Match(name)
+}
+
+func (s *OrphanedVMDKSensor) Enabled() bool {
+	return true
+}
+
+func (s *OrphanedVMDKSensor) GetLatestMetrics() []sensormetrics.SensorMetric {
+	return append(
+		s.metricsCollector.ComposeMetrics(s.Kind()),
+		sensormetrics.SensorMetric{
+			Sensor:     s.Kind(),
+			MetricName: "failed",
+			Value:      s.statusMonitor.StatusFailedFloat64(),
+			Unit:       "boolean",
+		}, sensormetrics.SensorMetric{
+			Sensor:     s.Kind(),
+			MetricName: "fail_rate",
+			Value:      s.statusMonitor.FailRate(),
+			Unit:       "boolean",
+		}, sensormetrics.SensorMetric{
+			Sensor:     s.Kind(),
+			MetricName: "enabled",
+			Value:      1.0,
+			Unit:       "boolean",
+		},
+	)
+}
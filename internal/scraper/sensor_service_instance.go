@@ -0,0 +1,219 @@
+package scraper
+
+import (
+	"context"
+	"log/slog"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/sanderdescamps/govc_exporter/internal/config"
+	"github.com/sanderdescamps/govc_exporter/internal/database/objects"
+	"github.com/sanderdescamps/govc_exporter/internal/helper"
+	"github.com/sanderdescamps/govc_exporter/internal/scraper/logger"
+	sensormetrics "github.com/sanderdescamps/govc_exporter/internal/scraper/sensor_metrics"
+	"github.com/vmware/govmomi/property"
+	"github.com/vmware/govmomi/vim25/methods"
+	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+const SERVICE_INSTANCE_SENSOR_NAME = "ServiceInstanceSensor"
+
+type ServiceInstanceSensor struct {
+	logger.SensorLogger
+	metricsCollector *sensormetrics.SensorMetricsCollector
+	statusMonitor    *sensormetrics.StatusMonitor
+	started          *helper.StartedCheck
+	sensorLock       sync.Mutex
+	manualRefresh    chan struct{}
+	stopChan         chan struct{}
+	config           config.SensorConfig
+}
+
+func NewServiceInstanceSensor(scraper *VCenterScraper, config config.SensorConfig, l *slog.Logger) *ServiceInstanceSensor {
+	var mc *sensormetrics.SensorMetricsCollector = sensormetrics.NewLastSensorMetricsCollector()
+	var sm *sensormetrics.StatusMonitor = sensormetrics.NewStatusMonitor()
+
+	return &ServiceInstanceSensor{
+		started:          helper.NewStartedCheck(),
+		stopChan:         make(chan struct{}),
+		manualRefresh:    make(chan struct{}),
+		config:           config,
+		SensorLogger:     logger.NewSLogLogger(l, logger.WithKind(SERVICE_INSTANCE_SENSOR_NAME)),
+		metricsCollector: mc,
+		statusMonitor:    sm,
+	}
+}
+
+func (s *ServiceInstanceSensor) refresh(ctx context.Context, scraper *VCenterScraper) error {
+	if ok := s.sensorLock.TryLock(); !ok {
+		return ErrSensorAlreadyRunning
+	}
+	defer s.sensorLock.Unlock()
+
+	sensorStopwatch := sensormetrics.NewSensorStopwatch()
+	sensorStopwatch.Start()
+	client, release, err := scraper.clientPool.AcquireWithContext(ctx)
+	if err != nil {
+		return ErrSensorCientFailed
+	}
+	defer release()
+	sensorStopwatch.Mark1()
+
+	before := time.Now()
+	serverTime, err := methods.GetCurrentTime(ctx, client.Client)
+	if err != nil {
+		return NewSensorError("failed to get vCenter server time", "err", err)
+	}
+	after := time.Now()
+
+	// Reading the sessions requires the Sessions.TerminateSession privilege,
+	// without it the about info and server time are still exported.
+	var sessionManager mo.SessionManager
+	if ref := client.ServiceContent.SessionManager; ref != nil {
+		err = property.DefaultCollector(client.Client).RetrieveOne(ctx, *ref, []string{"sessionList"}, &sessionManager)
+		if err != nil {
+			s.SensorLogger.Warn("failed to get vCenter sessions", "err", err)
+		}
+	}
+	sensorStopwatch.Finish()
+	s.metricsCollector.UploadStats(sensorStopwatch.GetStats())
+
+	vc := ConvertToVCenter(client.ServiceContent.About, *serverTime, before, after, sessionManager.SessionList)
+	return scraper.DB.SetVCenter(ctx, vc, s.config.MaxAge)
+}
+
+// Convert the about info, server time and sessions. before and after are the
+// local times right before and after the server time was requested.
+func ConvertToVCenter(about types.AboutInfo, serverTime time.Time, before time.Time, after time.Time, sessions []types.UserSession) objects.VCenter {
+	local := before.Add(after.Sub(before) / 2)
+	vc := objects.VCenter{
+		Timestamp:        after,
+		Name:             about.Name,
+		FullName:         about.FullName,
+		Version:          about.Version,
+		Build:            about.Build,
+		APIType:          about.ApiType,
+		APIVersion:       about.ApiVersion,
+		InstanceUUID:     about.InstanceUuid,
+		OSType:           about.OsType,
+		ProductLineID:    about.ProductLineId,
+		ServerTime:       serverTime,
+		ClockSkewSeconds: serverTime.Sub(local).Seconds(),
+		Sessions:         []objects.VCenterSession{},
+	}
+	for _, session := range sessions {
+		vc.Sessions = append(vc.Sessions, objects.VCenterSession{
+			UserName:       session.UserName,
+			IPAddress:      session.IpAddress,
+			UserAgent:      session.UserAgent,
+			LoginTime:      session.LoginTime,
+			LastActiveTime: session.LastActiveTime,
+			CallCount:      float64(session.CallCount),
+		})
+	}
+	return vc
+}
+
+func (s *ServiceInstanceSensor) Init(ctx context.Context, scraper *VCenterScraper) error {
+	if !s.started.IsStarted() {
+		err := s.refresh(ctx, scraper)
+		if err != nil {
+			s.statusMonitor.Fail()
+			return err
+		}
+		s.statusMonitor.Success()
+		s.started.Started()
+	} else {
+		return ErrSensorAlreadyStarted
+	}
+	return nil
+}
+
+func (s *ServiceInstanceSensor) StartRefresher(ctx context.Context, scraper *VCenterScraper) error {
+	ticker := time.NewTicker(s.config.RefreshInterval)
+	go func() {
+		time.Sleep(time.Duration(rand.Intn(20000)) * time.Millisecond)
+		for {
+			select {
+			case <-ticker.C:
+				go func() {
+					err := s.refresh(ctx, scraper)
+					if err == nil {
+						s.SensorLogger.Debug("refresh successful")
+						s.statusMonitor.Success()
+					} else {
+						s.SensorLogger.Error("refresh failed", "err", err)
+						s.statusMonitor.Fail()
+					}
+				}()
+			case <-s.manualRefresh:
+				go func() {
+					s.SensorLogger.Info("trigger manual refresh")
+					err := s.refresh(ctx, scraper)
+					if err == nil {
+						s.SensorLogger.Info("manual refresh successful")
+						s.statusMonitor.Success()
+					} else {
+						s.SensorLogger.Error("manual refresh failed", "err", err)
+						s.statusMonitor.Fail()
+					}
+				}()
+			case <-s.stopChan:
+				s.started.Stopped()
+				ticker.Stop()
+			case <-ctx.Done():
+				s.started.Stopped()
+				ticker.Stop()
+			}
+		}
+	}()
+	return nil
+}
+
+func (s *ServiceInstanceSensor) StopRefresher(ctx context.Context) {
+	close(s.stopChan)
+}
+
+func (s *ServiceInstanceSensor) TriggerManualRefresh(ctx context.Context) {
+	s.manualRefresh <- struct{}{}
+}
+
+func (s *ServiceInstanceSensor) Kind() string {
+	return "ServiceInstanceSensor"
+}
+
+func (s *ServiceInstanceSensor) WaitTillStartup() {
+	s.started.Wait()
+}
+
+func (s *ServiceInstanceSensor) Match(name string) bool {
+	return helper.NewMatcher("service_instance", "serviceinstance", "vcenter", "sessions").Match(name)
+}
+
+func (s *ServiceInstanceSensor) Enabled() bool {
+	return true
+}
+
+func (s *ServiceInstanceSensor) GetLatestMetrics() []sensormetrics.SensorMetric {
+	return append(
+		s.metricsCollector.ComposeMetrics(s.Kind()),
+		sensormetrics.SensorMetric{
+			Sensor:     s.Kind(),
+			MetricName: "failed",
+			Value:      s.statusMonitor.StatusFailedFloat64(),
+			Unit:       "boolean",
+		}, sensormetrics.SensorMetric{
+			Sensor:     s.Kind(),
+			MetricName: "fail_rate",
+			Value:      s.statusMonitor.FailRate(),
+			Unit:       "boolean",
+		}, sensormetrics.SensorMetric{
+			Sensor:     s.Kind(),
+			MetricName: "enabled",
+			Value:      1.0,
+			Unit:       "boolean",
+		},
+	)
+}
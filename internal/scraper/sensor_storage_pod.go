@@ -13,6 +13,7 @@ import (
 	"github.com/sanderdescamps/govc_exporter/internal/scraper/logger"
 	sensormetrics "github.com/sanderdescamps/govc_exporter/internal/scraper/sensor_metrics"
 	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/types"
 )
 
 const STORAGE_POD_SENSOR_NAME = "StoragePodSensor"
@@ -38,6 +39,8 @@ func NewStoragePodSensor(scraper *VCenterScraper, config config.SensorConfig, l
 				"parent",
 				"name",
 				"summary",
+				"childEntity",
+				"podStorageDrsEntry",
 			}, mc, sm),
 		started:          helper.NewStartedCheck(),
 		stopChan:         make(chan struct{}),
@@ -200,5 +203,32 @@ func ConvertToStoragePod(ctx context.Context, scraper *VCenterScraper, p mo.Stor
 		spod.FreeSpace = float64(summary.FreeSpace)
 	}
 
+	for _, child := range p.ChildEntity {
+		if child.Type == string(types.ManagedObjectTypesDatastore) {
+			spod.Datastores = append(spod.Datastores, objects.NewManagedObjectReferenceFromVMwareRef(child))
+		}
+	}
+
+	if entry := p.PodStorageDrsEntry; entry != nil {
+		config := entry.StorageDrsConfig.PodConfig
+		spod.SDRSEnabled = config.Enabled
+		spod.SDRSBehavior = config.DefaultVmBehavior
+		spod.SDRSIoLoadBalanceEnabled = config.IoLoadBalanceEnabled
+		spod.SDRSLoadBalanceInterval = float64(config.LoadBalanceInterval) * 60
+		if space := config.SpaceLoadBalanceConfig; space != nil {
+			spod.SDRSSpaceThresholdMode = space.SpaceThresholdMode
+			spod.SDRSSpaceUtilizationThreshold = float64(space.SpaceUtilizationThreshold)
+			spod.SDRSFreeSpaceThreshold = float64(int64(space.FreeSpaceThresholdGB) * 1024 * 1024 * 1024)
+		}
+		if io := config.IoLoadBalanceConfig; io != nil {
+			spod.SDRSIoLatencyThreshold = float64(io.IoLatencyThreshold) / 1000
+			spod.SDRSIoLoadImbalanceThreshold = float64(io.IoLoadImbalanceThreshold)
+		}
+		spod.SDRSPendingRecommendations = float64(len(entry.Recommendation))
+		for _, f := range entry.DrsFault {
+			spod.SDRSFaults += float64(len(f.FaultsByVm))
+		}
+	}
+
 	return spod
 }
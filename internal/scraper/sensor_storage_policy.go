@@ -0,0 +1,347 @@
+package scraper
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/sanderdescamps/govc_exporter/internal/config"
+	"github.com/sanderdescamps/govc_exporter/internal/database/objects"
+	"github.com/sanderdescamps/govc_exporter/internal/helper"
+	"github.com/sanderdescamps/govc_exporter/internal/scraper/logger"
+	sensormetrics "github.com/sanderdescamps/govc_exporter/internal/scraper/sensor_metrics"
+	"github.com/vmware/govmomi/pbm"
+	pbmtypes "github.com/vmware/govmomi/pbm/types"
+)
+
+const STORAGE_POLICY_SENSOR_NAME = "StoragePolicySensor"
+
+// Max number of entities in a single SPBM query
+const storagePolicyBatchSize = 100
+
+type StoragePolicySensor struct {
+	logger.SensorLogger
+	metricsCollector *sensormetrics.SensorMetricsCollector
+	statusMonitor    *sensormetrics.StatusMonitor
+	started          *helper.StartedCheck
+	sensorLock       sync.Mutex
+	manualRefresh    chan struct{}
+	stopChan         chan struct{}
+	config           config.SensorConfig
+}
+
+func NewStoragePolicySensor(scraper *VCenterScraper, config config.SensorConfig, l *slog.Logger) *StoragePolicySensor {
+	var mc *sensormetrics.SensorMetricsCollector = sensormetrics.NewLastSensorMetricsCollector()
+	var sm *sensormetrics.StatusMonitor = sensormetrics.NewStatusMonitor()
+
+	return &StoragePolicySensor{
+		started:          helper.NewStartedCheck(),
+		stopChan:         make(chan struct{}),
+		manualRefresh:    make(chan struct{}),
+		config:           config,
+		SensorLogger:     logger.NewSLogLogger(l, logger.WithKind(STORAGE_POLICY_SENSOR_NAME)),
+		metricsCollector: mc,
+		statusMonitor:    sm,
+	}
+}
+
+func (s *StoragePolicySensor) refresh(ctx context.Context, scraper *VCenterScraper) error {
+	if ok := s.sensorLock.TryLock(); !ok {
+		return ErrSensorAlreadyRunning
+	}
+	defer s.sensorLock.Unlock()
+
+	if scraper.VM == nil || scraper.Datastore == nil {
+		s.SensorLogger.Error("Can't query storage policies if vm or datastore sensor is not defined")
+		return fmt.Errorf("no vm or datastore sensor found")
+	}
+	(scraper.Datastore).(*DatastoreSensor).WaitTillStartup()
+	(scraper.VM).(*VirtualMachineSensor).WaitTillStartup()
+
+	sensorStopwatch := sensormetrics.NewSensorStopwatch()
+	sensorStopwatch.Start()
+	client, release, err := scraper.clientPool.AcquireWithContext(ctx)
+	if err != nil {
+		return ErrSensorCientFailed
+	}
+	defer release()
+	sensorStopwatch.Mark1()
+
+	pc, err := pbm.NewClient(ctx, client.Client)
+	if err != nil {
+		return NewSensorError("failed to create pbm client", "err", err)
+	}
+
+	t := time.Now()
+	policies, err := s.queryPolicies(ctx, pc, scraper)
+	if err != nil {
+		return err
+	}
+	policyNames := map[string]string{}
+	for _, policy := range policies {
+		policy.Timestamp = t
+		policyNames[policy.ID] = policy.Name
+		if err := scraper.DB.SetStoragePolicy(ctx, policy, s.config.MaxAge); err != nil {
+			return err
+		}
+	}
+
+	vms, err := scraper.DB.GetAllVM(ctx)
+	if err != nil {
+		return err
+	}
+	serverUUID := client.ServiceContent.About.InstanceUuid
+	entityRefs := []pbmtypes.PbmServerObjectRef{}
+	for _, vm := range vms {
+		entityRefs = append(entityRefs, StoragePolicyEntityRefs(vm, serverUUID)...)
+	}
+
+	assigned := map[string][]string{}
+	compliance := map[string]string{}
+	for start := 0; start < len(entityRefs); start += storagePolicyBatchSize {
+		batch := entityRefs[start:min(start+storagePolicyBatchSize, len(entityRefs))]
+
+		results, err := pc.QueryAssociatedProfiles(ctx, batch)
+		if err != nil {
+			return NewSensorError("failed to query associated storage policies", "err", err)
+		}
+		for _, r := range results {
+			for _, id := range r.ProfileId {
+				assigned[r.Object.Key] = append(assigned[r.Object.Key], id.UniqueId)
+			}
+		}
+
+		// Compliance is best effort, the assigned policies are still useful
+		// when the compliance manager is unavailable.
+		complianceResults, err := pc.FetchComplianceResult(ctx, batch)
+		if err != nil {
+			s.SensorLogger.Warn("failed to fetch storage policy compliance", "err", err)
+			continue
+		}
+		for _, r := range complianceResults {
+			compliance[r.Entity.Key] = r.ComplianceStatus
+		}
+	}
+
+	for _, vm := range vms {
+		vmPolicy := ConvertToVMStoragePolicy(vm, assigned, compliance, policyNames, t)
+		if len(vmPolicy.Entities) == 0 {
+			continue
+		}
+		if err := scraper.DB.SetVMStoragePolicy(ctx, vmPolicy, s.config.MaxAge); err != nil {
+			return err
+		}
+	}
+
+	sensorStopwatch.Finish()
+	s.metricsCollector.UploadStats(sensorStopwatch.GetStats())
+	return nil
+}
+
+func (s *StoragePolicySensor) queryPolicies(ctx context.Context, pc *pbm.Client, scraper *VCenterScraper) ([]objects.StoragePolicy, error) {
+	ids, err := pc.QueryProfile(ctx, pbmtypes.PbmProfileResourceType{
+		ResourceType: string(pbmtypes.PbmProfileResourceTypeEnumSTORAGE),
+	}, string(pbmtypes.PbmProfileCategoryEnumREQUIREMENT))
+	if err != nil {
+		return nil, NewSensorError("failed to query storage policies", "err", err)
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	profiles, err := pc.RetrieveContent(ctx, ids)
+	if err != nil {
+		return nil, NewSensorError("failed to retrieve storage policies", "err", err)
+	}
+
+	datastores, err := scraper.DB.GetAllDatastore(ctx)
+	if err != nil {
+		return nil, err
+	}
+	hubs := []pbmtypes.PbmPlacementHub{}
+	for _, ds := range datastores {
+		hubs = append(hubs, pbmtypes.PbmPlacementHub{HubType: ds.Self.Type.String(), HubId: ds.Self.Value})
+	}
+
+	policies := []objects.StoragePolicy{}
+	for _, p := range profiles {
+		profile := p.GetPbmProfile()
+		policy := objects.StoragePolicy{
+			ID:          profile.ProfileId.UniqueId,
+			Name:        profile.Name,
+			Description: profile.Description,
+		}
+
+		if len(hubs) > 0 {
+			result, err := pc.CheckRequirements(ctx, hubs, nil, []pbmtypes.BasePbmPlacementRequirement{
+				&pbmtypes.PbmPlacementCapabilityProfileRequirement{ProfileId: profile.ProfileId},
+			})
+			if err != nil {
+				s.SensorLogger.Warn("failed to check storage policy compatibility", "policy", profile.Name, "err", err)
+			} else {
+				for _, hub := range result.CompatibleDatastores() {
+					policy.CompatibleDatastores = append(policy.CompatibleDatastores, hub.HubId)
+				}
+				for _, hub := range result.NonCompatibleDatastores() {
+					policy.IncompatibleDatastores = append(policy.IncompatibleDatastores, hub.HubId)
+				}
+			}
+		}
+		policies = append(policies, policy)
+	}
+	return policies, nil
+}
+
+// Return the SPBM references of the vm home and all virtual disks of the vm
+func StoragePolicyEntityRefs(vm objects.VirtualMachine, serverUUID string) []pbmtypes.PbmServerObjectRef {
+	refs := []pbmtypes.PbmServerObjectRef{{
+		ObjectType: string(pbmtypes.PbmObjectTypeVirtualMachine),
+		Key:        vm.Self.Value,
+		ServerUuid: serverUUID,
+	}}
+	for _, disk := range vm.Disk {
+		refs = append(refs, pbmtypes.PbmServerObjectRef{
+			ObjectType: string(pbmtypes.PbmObjectTypeVirtualDiskId),
+			Key:        fmt.Sprintf("%s:%d", vm.Self.Value, disk.Key),
+			ServerUuid: serverUUID,
+		})
+	}
+	return refs
+}
+
+// Combine the assigned policies and compliance status of the vm home and
+// disks. Entities without a policy are skipped.
+func ConvertToVMStoragePolicy(vm objects.VirtualMachine, assigned map[string][]string, compliance map[string]string, policyNames map[string]string, t time.Time) objects.VMStoragePolicy {
+	result := objects.VMStoragePolicy{
+		Timestamp: t,
+		VM:        vm.Self,
+		Entities:  []objects.VMStoragePolicyEntity{},
+	}
+
+	add := func(key string, entityType string, disk string) {
+		status, ok := compliance[key]
+		if !ok || status == "" {
+			status = string(pbmtypes.PbmComplianceStatusUnknown)
+		}
+		for _, id := range assigned[key] {
+			result.Entities = append(result.Entities, objects.VMStoragePolicyEntity{
+				Type:             entityType,
+				Disk:             disk,
+				PolicyID:         id,
+				PolicyName:       policyNames[id],
+				ComplianceStatus: status,
+			})
+		}
+	}
+
+	add(vm.Self.Value, objects.StoragePolicyEntityHome, "")
+	for _, disk := range vm.Disk {
+		add(fmt.Sprintf("%s:%d", vm.Self.Value, disk.Key), objects.StoragePolicyEntityDisk, disk.Label)
+	}
+	return result
+}
+
+func (s *StoragePolicySensor) Init(ctx context.Context, scraper *VCenterScraper) error {
+	if !s.started.IsStarted() {
+		err := s.refresh(ctx, scraper)
+		if err != nil {
+			s.statusMonitor.Fail()
+			return err
+		}
+		s.statusMonitor.Success()
+		s.started.Started()
+	} else {
+		return ErrSensorAlreadyStarted
+	}
+	return nil
+}
+
+func (s *StoragePolicySensor) StartRefresher(ctx context.Context, scraper *VCenterScraper) error {
+	ticker := time.NewTicker(s.config.RefreshInterval)
+	go func() {
+		time.Sleep(time.Duration(rand.Intn(20000)) * time.Millisecond)
+		for {
+			select {
+			case <-ticker.C:
+				go func() {
+					err := s.refresh(ctx, scraper)
+					if err == nil {
+						s.SensorLogger.Debug("refresh successful")
+						s.statusMonitor.Success()
+					} else {
+						s.SensorLogger.Error("refresh failed", "err", err)
+						s.statusMonitor.Fail()
+					}
+				}()
+			case <-s.manualRefresh:
+				go func() {
+					s.SensorLogger.Info("trigger manual refresh")
+					err := s.refresh(ctx, scraper)
+					if err == nil {
+						s.SensorLogger.Info("manual refresh successful")
+						s.statusMonitor.Success()
+					} else {
+						s.SensorLogger.Error("manual refresh failed", "err", err)
+						s.statusMonitor.Fail()
+					}
+				}()
+			case <-s.stopChan:
+				s.started.Stopped()
+				ticker.Stop()
+			case <-ctx.Done():
+				s.started.Stopped()
+				ticker.Stop()
+			}
+		}
+	}()
+	return nil
+}
+
+func (s *StoragePolicySensor) StopRefresher(ctx context.Context) {
+	close(s.stopChan)
+}
+
+func (s *StoragePolicySensor) TriggerManualRefresh(ctx context.Context) {
+	s.manualRefresh <- struct{}{}
+}
+
+func (s *StoragePolicySensor) Kind() string {
+	return "StoragePolicySensor"
+}
+
+func (s *StoragePolicySensor) WaitTillStartup() {
+	s.started.Wait()
+}
+
+func (s *StoragePolicySensor) Match(name string) bool {
+	return helper.NewMatcher("storage_policy", "storagepolicy", "spbm", "pbm").Match(name)
+}
+
+func (s *StoragePolicySensor) Enabled() bool {
+	return true
+}
+
+func (s *StoragePolicySensor) GetLatestMetrics() []sensormetrics.SensorMetric {
+	return append(
+		s.metricsCollector.ComposeMetrics(s.Kind()),
+		sensormetrics.SensorMetric{
+			Sensor:     s.Kind(),
+			MetricName: "failed",
+			Value:      s.statusMonitor.StatusFailedFloat64(),
+			Unit:       "boolean",
+		}, sensormetrics.SensorMetric{
+			Sensor:     s.Kind(),
+			MetricName: "fail_rate",
+			Value:      s.statusMonitor.FailRate(),
+			Unit:       "boolean",
+		}, sensormetrics.SensorMetric{
+			Sensor:     s.Kind(),
+			MetricName: "enabled",
+			Value:      1.0,
+			Unit:       "boolean",
+		},
+	)
+}
@@ -3,9 +3,11 @@ package scraper
 import (
 	"context"
 	"log/slog"
+	"maps"
 	"math/rand"
 	"slices"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/sanderdescamps/govc_exporter/internal/config"
@@ -18,6 +20,13 @@ import (
 
 const TAGS_SENSOR_NAME = "TagsSensor"
 
+// Number of tags for which the attached objects are requested in one call
+const TAGS_SENSOR_BATCH_SIZE = 100
+
+// Time the name of a tag is cached, renamed tags are picked up after this
+// period
+const TAGS_SENSOR_NAME_TTL = 1 * time.Hour
+
 type TagsSensor struct {
 	logger.SensorLogger
 	metricsCollector *sensormetrics.SensorMetricsCollector
@@ -27,6 +36,56 @@ type TagsSensor struct {
 	manualRefresh    chan struct{}
 	stopChan         chan struct{}
 	config           config.TagsSensorConfig
+	failedTags       atomic.Int64
+	failedCategories atomic.Int64
+	tagNames         tagNameCache
+}
+
+// tagNameCache keeps the names of the tags between refreshes, only tags which
+// are not in the cache need to be resolved with a separate request
+type tagNameCache struct {
+	lock  sync.Mutex
+	names map[string]tagNameCacheEntry
+}
+
+type tagNameCacheEntry struct {
+	name   string
+	expire time.Time
+}
+
+func (c *tagNameCache) Get(id string) (string, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	entry, ok := c.names[id]
+	if !ok || time.Now().After(entry.expire) {
+		return "", false
+	}
+	return entry.name, true
+}
+
+func (c *tagNameCache) Set(id string, name string, ttl time.Duration) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.names == nil {
+		c.names = map[string]tagNameCacheEntry{}
+	}
+	c.names[id] = tagNameCacheEntry{name: name, expire: time.Now().Add(ttl)}
+}
+
+// Prune removes the tags which no longer exist
+func (c *tagNameCache) Prune(ids []string) {
+	keep := make(map[string]struct{}, len(ids))
+	for _, id := range ids {
+		keep[id] = struct{}{}
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	for id := range c.names {
+		if _, ok := keep[id]; !ok {
+			delete(c.names, id)
+		}
+	}
 }
 
 func NewTagsSensor(scraper *VCenterScraper, config config.TagsSensorConfig, l *slog.Logger) *TagsSensor {
@@ -52,55 +111,71 @@ func (s *TagsSensor) refresh(ctx context.Context, scraper *VCenterScraper) error
 	sensorStopwatch := sensormetrics.NewSensorStopwatch()
 	sensorStopwatch.Start()
 
-	restclient, release, err := scraper.clientPool.AcquireRest()
-	defer release()
+	tagCategories, err := s.queryTagCategories(ctx, scraper, sensorStopwatch)
 	if err != nil {
-		return ErrSensorCientFailed
+		return err
 	}
-	sensorStopwatch.Mark1()
+	tagIDs := slices.Collect(maps.Keys(tagCategories))
+	s.tagNames.Prune(tagIDs)
 
-	m := tags.NewManager(restclient)
+	// Limit the number of concurrent requests to the size of the client pool
+	workers := max(scraper.config.ClientPoolSize, 1)
 
-	allCats, err := m.GetCategories(ctx)
-	if err != nil {
-		return NewSensorError("failed to get tag categories", "err", err)
+	var wg sync.WaitGroup
+	var attachObjsLock sync.Mutex
+	var failedTags atomic.Int64
+	sem := make(chan struct{}, workers)
+	attachObjs := []tags.AttachedObjects{}
+	for batch := range slices.Chunk(tagIDs, TAGS_SENSOR_BATCH_SIZE) {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			result, err := s.queryAttachedObjects(ctx, scraper, batch)
+			if err != nil {
+				s.SensorLogger.Warn("failed to get attached objects for tags", "tags", batch, "err", err)
+				failedTags.Add(int64(len(batch)))
+				return
+			}
+			attachObjsLock.Lock()
+			defer attachObjsLock.Unlock()
+			attachObjs = append(attachObjs, result...)
+		}()
 	}
+	wg.Wait()
 
-	catList := []tags.Category{}
-	for _, cat := range allCats {
-		if len(s.config.CategoryToCollect) == 0 || slices.Contains(s.config.CategoryToCollect, cat.Name) {
-			catList = append(catList, cat)
+	names := map[string]string{}
+	missing := []string{}
+	for _, attachObj := range attachObjs {
+		if name, ok := s.tagNames.Get(attachObj.TagID); ok {
+			names[attachObj.TagID] = name
+		} else {
+			missing = append(missing, attachObj.TagID)
 		}
 	}
+	resolved, failed := s.resolveTagNames(ctx, scraper, missing, workers)
+	maps.Copy(names, resolved)
+	failedTags.Add(int64(failed))
 
 	objectTags := map[string]objects.TagSet{}
-	for _, cat := range catList {
-		tags, err := m.GetTagsForCategory(ctx, cat.ID)
-		if err != nil {
-			return NewSensorError("failed to get tags for category", "category", cat, "err", err)
+	for _, attachObj := range attachObjs {
+		name, ok := names[attachObj.TagID]
+		if !ok {
+			continue
 		}
-
-		for _, tag := range tags {
-			attachObjs, err := m.GetAttachedObjectsOnTags(ctx, []string{tag.ID})
-			if err != nil {
-				return NewSensorError("failed to get attached objects for tag", "tag", tag, "err", err)
-			}
-
-			for _, attachObj := range attachObjs {
-				for _, elem := range attachObj.ObjectIDs {
-					ref := objects.NewManagedObjectReferenceFromVMwareRef(elem.Reference())
-					if _, ok := objectTags[ref.Hash()]; !ok {
-						objectTags[ref.Hash()] = objects.TagSet{
-							ObjectRef: ref,
-							Tags:      map[string]string{cat.Name: tag.Name},
-						}
-					} else {
-						objectTags[ref.Hash()].Tags[cat.Name] = tag.Name
-					}
-				}
+		for _, elem := range attachObj.ObjectIDs {
+			ref := objects.NewManagedObjectReferenceFromVMwareRef(elem.Reference())
+			tagSet, ok := objectTags[ref.Hash()]
+			if !ok {
+				tagSet = objects.NewTagSet(ref)
 			}
+			tagSet.AddTag(tagCategories[attachObj.TagID], name)
+			objectTags[ref.Hash()] = tagSet
 		}
 	}
+	s.failedTags.Store(failedTags.Load())
 
 	sensorStopwatch.Finish()
 	s.metricsCollector.UploadStats(sensorStopwatch.GetStats())
@@ -115,6 +190,120 @@ func (s *TagsSensor) refresh(ctx context.Context, scraper *VCenterScraper) error
 	return nil
 }
 
+// queryTagCategories returns the IDs of all tags in the categories which need
+// to be collected, mapped on the name of their category. Categories which
+// fail are skipped.
+func (s *TagsSensor) queryTagCategories(ctx context.Context, scraper *VCenterScraper, sensorStopwatch *sensormetrics.SensorStopwatch) (map[string]string, error) {
+	restclient, release, err := scraper.clientPool.AcquireRest()
+	defer release()
+	if err != nil {
+		return nil, ErrSensorCientFailed
+	}
+	defer restclient.Logout(ctx)
+	sensorStopwatch.Mark1()
+
+	m := tags.NewManager(restclient)
+
+	allCats, err := m.GetCategories(ctx)
+	if err != nil {
+		return nil, NewSensorError("failed to get tag categories", "err", err)
+	}
+
+	var failedCategories int64
+	tagCategories := map[string]string{}
+	for _, cat := range allCats {
+		if len(s.config.CategoryToCollect) != 0 && !slices.Contains(s.config.CategoryToCollect, cat.Name) {
+			continue
+		}
+
+		tagIDs, err := m.ListTagsForCategory(ctx, cat.ID)
+		if err != nil {
+			s.SensorLogger.Warn("failed to get tags for category", "category", cat.Name, "err", err)
+			failedCategories++
+			continue
+		}
+		for _, tagID := range tagIDs {
+			tagCategories[tagID] = cat.Name
+		}
+	}
+	s.failedCategories.Store(failedCategories)
+
+	return tagCategories, nil
+}
+
+// queryAttachedObjects fetches the attached objects of a batch of tags in a
+// single call. Tags without attached objects are left out.
+func (s *TagsSensor) queryAttachedObjects(ctx context.Context, scraper *VCenterScraper, tagIDs []string) ([]tags.AttachedObjects, error) {
+	restclient, release, err := scraper.clientPool.AcquireRest()
+	defer release()
+	if err != nil {
+		return nil, err
+	}
+	defer restclient.Logout(ctx)
+
+	attachObjs, err := tags.NewManager(restclient).ListAttachedObjectsOnTags(ctx, tagIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	result := []tags.AttachedObjects{}
+	for _, attachObj := range attachObjs {
+		if len(attachObj.ObjectIDs) != 0 {
+			result = append(result, attachObj)
+		}
+	}
+	return result, nil
+}
+
+// resolveTagNames requests the names of tags which are not in the cache and
+// adds them to the cache. The requests are spread over at most workers
+// clients. The number of tags which could not be resolved is returned next to
+// the names.
+func (s *TagsSensor) resolveTagNames(ctx context.Context, scraper *VCenterScraper, tagIDs []string, workers int) (map[string]string, int) {
+	result := map[string]string{}
+	if len(tagIDs) == 0 {
+		return result, 0
+	}
+
+	var wg sync.WaitGroup
+	var resultLock sync.Mutex
+	failed := 0
+	chunkSize := (len(tagIDs) + workers - 1) / workers
+	for chunk := range slices.Chunk(tagIDs, chunkSize) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			restclient, release, err := scraper.clientPool.AcquireRest()
+			defer release()
+			if err != nil {
+				s.SensorLogger.Warn("failed to get rest client", "err", err)
+				resultLock.Lock()
+				failed += len(chunk)
+				resultLock.Unlock()
+				return
+			}
+			defer restclient.Logout(ctx)
+
+			m := tags.NewManager(restclient)
+			for _, tagID := range chunk {
+				tag, err := m.GetTag(ctx, tagID)
+				resultLock.Lock()
+				if err != nil {
+					s.SensorLogger.Warn("failed to get tag", "tag", tagID, "err", err)
+					failed++
+				} else {
+					result[tagID] = tag.Name
+					s.tagNames.Set(tagID, tag.Name, TAGS_SENSOR_NAME_TTL)
+				}
+				resultLock.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	return result, failed
+}
+
 func (s *TagsSensor) Init(ctx context.Context, scraper *VCenterScraper) error {
 	if !s.started.IsStarted() {
 		err := s.refresh(ctx, scraper)
@@ -213,6 +402,16 @@ func (s *TagsSensor) GetLatestMetrics() []sensormetrics.SensorMetric {
 			MetricName: "enabled",
 			Value:      1.0,
 			Unit:       "boolean",
+		}, sensormetrics.SensorMetric{
+			Sensor:     s.Kind(),
+			MetricName: "failed_tags",
+			Value:      float64(s.failedTags.Load()),
+			Unit:       "count",
+		}, sensormetrics.SensorMetric{
+			Sensor:     s.Kind(),
+			MetricName: "failed_categories",
+			Value:      float64(s.failedCategories.Load()),
+			Unit:       "count",
 		},
 	)
 }
@@ -0,0 +1,248 @@
+package scraper
+
+import (
+	"context"
+	"log/slog"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/sanderdescamps/govc_exporter/internal/config"
+	"github.com/sanderdescamps/govc_exporter/internal/database/objects"
+	"github.com/sanderdescamps/govc_exporter/internal/helper"
+	"github.com/sanderdescamps/govc_exporter/internal/scraper/logger"
+	sensormetrics "github.com/sanderdescamps/govc_exporter/internal/scraper/sensor_metrics"
+	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+const VIRTUAL_APP_SENSOR_NAME = "VirtualAppSensor"
+
+type VirtualAppSensor struct {
+	BaseSensor
+	logger.SensorLogger
+	metricsCollector *sensormetrics.SensorMetricsCollector
+	statusMonitor    *sensormetrics.StatusMonitor
+	started          *helper.StartedCheck
+	sensorLock       sync.Mutex
+	manualRefresh    chan struct{}
+	stopChan         chan struct{}
+	config           config.SensorConfig
+}
+
+func NewVirtualAppSensor(scraper *VCenterScraper, config config.SensorConfig, l *slog.Logger) *VirtualAppSensor {
+	var mc *sensormetrics.SensorMetricsCollector = sensormetrics.NewLastSensorMetricsCollector()
+	var sm *sensormetrics.StatusMonitor = sensormetrics.NewStatusMonitor()
+	return &VirtualAppSensor{
+		BaseSensor: *NewBaseSensor(
+			"VirtualApp", []string{
+				"parent",
+				"name",
+				"parentFolder",
+				"parentVApp",
+				"summary",
+				"vm",
+				"vAppConfig.annotation",
+			}, mc, sm),
+		started:          helper.NewStartedCheck(),
+		stopChan:         make(chan struct{}),
+		manualRefresh:    make(chan struct{}),
+		config:           config,
+		SensorLogger:     logger.NewSLogLogger(l, logger.WithKind(VIRTUAL_APP_SENSOR_NAME)),
+		metricsCollector: mc,
+		statusMonitor:    sm,
+	}
+}
+
+func (s *VirtualAppSensor) refresh(ctx context.Context, scraper *VCenterScraper) error {
+	if ok := s.sensorLock.TryLock(); !ok {
+		return ErrSensorAlreadyRunning
+	}
+	defer s.sensorLock.Unlock()
+
+	var vApps []mo.VirtualApp
+	err := s.baseRefresh(ctx, scraper, &vApps)
+	if err != nil {
+		return err
+	}
+
+	for _, vApp := range vApps {
+		oVApp := ConvertToVirtualApp(ctx, scraper, vApp, time.Now())
+		err := scraper.DB.SetVirtualApp(ctx, oVApp, s.config.MaxAge)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *VirtualAppSensor) Init(ctx context.Context, scraper *VCenterScraper) error {
+	if !s.started.IsStarted() {
+		err := s.refresh(ctx, scraper)
+		if err != nil {
+			s.statusMonitor.Fail()
+			return err
+		}
+		s.statusMonitor.Success()
+		s.started.Started()
+	} else {
+		return ErrSensorAlreadyStarted
+	}
+	return nil
+}
+
+func (s *VirtualAppSensor) StartRefresher(ctx context.Context, scraper *VCenterScraper) error {
+	ticker := time.NewTicker(s.config.RefreshInterval)
+	go func() {
+		time.Sleep(time.Duration(rand.Intn(20000)) * time.Millisecond)
+		for {
+			select {
+			case <-ticker.C:
+				go func() {
+					err := s.refresh(ctx, scraper)
+					if err == nil {
+						s.SensorLogger.Debug("refresh successful")
+						s.statusMonitor.Success()
+					} else {
+						s.SensorLogger.Error("refresh failed", "err", err)
+						s.statusMonitor.Fail()
+					}
+				}()
+			case <-s.manualRefresh:
+				go func() {
+					s.SensorLogger.Info("trigger manual refresh")
+					err := s.refresh(ctx, scraper)
+					if err == nil {
+						s.SensorLogger.Info("manual refresh successful")
+						s.statusMonitor.Success()
+					} else {
+						s.SensorLogger.Error("manual refresh failed", "err", err)
+						s.statusMonitor.Fail()
+					}
+				}()
+			case <-s.stopChan:
+				s.started.Stopped()
+				ticker.Stop()
+			case <-ctx.Done():
+				s.started.Stopped()
+				ticker.Stop()
+			}
+		}
+	}()
+	return nil
+}
+
+func (s *VirtualAppSensor) StopRefresher(ctx context.Context) {
+	close(s.stopChan)
+}
+
+func (s *VirtualAppSensor) TriggerManualRefresh(ctx context.Context) {
+	s.manualRefresh <- struct{}{}
+}
+
+func (s *VirtualAppSensor) Kind() string {
+	return "VirtualAppSensor"
+}
+
+func (s *VirtualAppSensor) WaitTillStartup() {
+	s.started.Wait()
+}
+
+func (s *VirtualAppSensor) Match(name string) bool {
+	return helper.NewMatcher("virtual_app", "virtualapp", "vapp").Match(name)
+}
+
+func (s *VirtualAppSensor) Enabled() bool {
+	return true
+}
+
+func (s *VirtualAppSensor) GetLatestMetrics() []sensormetrics.SensorMetric {
+	return append(
+		s.metricsCollector.ComposeMetrics(s.Kind()),
+		sensormetrics.SensorMetric{
+			Sensor:     s.Kind(),
+			MetricName: "failed",
+			Value:      s.statusMonitor.StatusFailedFloat64(),
+			Unit:       "boolean",
+		}, sensormetrics.SensorMetric{
+			Sensor:     s.Kind(),
+			MetricName: "fail_rate",
+			Value:      s.statusMonitor.FailRate(),
+			Unit:       "boolean",
+		}, sensormetrics.SensorMetric{
+			Sensor:     s.Kind(),
+			MetricName: "enabled",
+			Value:      1.0,
+			Unit:       "boolean",
+		},
+	)
+}
+
+func ConvertToVirtualApp(ctx context.Context, scraper *VCenterScraper, a mo.VirtualApp, t time.Time) objects.VirtualApp {
+	self := objects.NewManagedObjectReferenceFromVMwareRef(a.Self)
+
+	var parent *objects.ManagedObjectReference
+	if a.Parent != nil {
+		p := objects.NewManagedObjectReferenceFromVMwareRef(*a.Parent)
+		parent = &p
+	}
+
+	var parentFolder *objects.ManagedObjectReference
+	if a.ParentFolder != nil {
+		p := objects.NewManagedObjectReferenceFromVMwareRef(*a.ParentFolder)
+		parentFolder = &p
+	}
+
+	var parentVApp *objects.ManagedObjectReference
+	if a.ParentVApp != nil {
+		p := objects.NewManagedObjectReferenceFromVMwareRef(*a.ParentVApp)
+		parentVApp = &p
+	}
+
+	vApp := objects.VirtualApp{
+		Timestamp:    t,
+		Name:         a.Name,
+		Self:         self,
+		Parent:       parent,
+		ParentFolder: parentFolder,
+		ParentVApp:   parentVApp,
+		VMs:          []objects.ManagedObjectReference{},
+	}
+
+	if vApp.Parent != nil {
+		parentChain := scraper.DB.GetParentChain(ctx, *vApp.Parent)
+		vApp.Datacenter = parentChain.DC
+		vApp.Cluster = parentChain.Cluster
+		parentChain.Path = append(parentChain.Path, vApp.Name)
+		vApp.FolderPath = parentChain.FolderPath()
+	}
+
+	for _, vm := range a.Vm {
+		vApp.VMs = append(vApp.VMs, objects.NewManagedObjectReferenceFromVMwareRef(vm))
+	}
+
+	if a.VAppConfig != nil {
+		vApp.Annotation = a.VAppConfig.Annotation
+	}
+
+	mb := int64(1024 * 1024)
+	if summary, ok := a.Summary.(*types.VirtualAppSummary); ok {
+		vApp.State = string(summary.VAppState)
+		if summary.Product != nil {
+			vApp.Product = summary.Product.Name
+			vApp.Version = summary.Product.Version
+		}
+	}
+	if summary := a.Summary.GetResourcePoolSummary(); summary != nil {
+		if qs := summary.QuickStats; qs != nil {
+			vApp.OverallCPUUsage = float64(qs.OverallCpuUsage)
+			vApp.OverallCPUDemand = float64(qs.OverallCpuDemand)
+			vApp.GuestMemoryUsage = float64(qs.GuestMemoryUsage * mb)
+			vApp.HostMemoryUsage = float64(qs.HostMemoryUsage * mb)
+		}
+		vApp.OverallStatus = string(summary.Runtime.OverallStatus)
+	}
+
+	return vApp
+}
@@ -2,14 +2,14 @@ package scraper
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"log/slog"
 	"maps"
 	"math/rand"
-	"regexp"
+	"net"
 	"slices"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -19,15 +19,15 @@ import (
 	"github.com/sanderdescamps/govc_exporter/internal/scraper/logger"
 	sensormetrics "github.com/sanderdescamps/govc_exporter/internal/scraper/sensor_metrics"
 	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/property"
 	"github.com/vmware/govmomi/view"
+	"github.com/vmware/govmomi/vim25"
 	"github.com/vmware/govmomi/vim25/mo"
 	"github.com/vmware/govmomi/vim25/types"
 )
 
 const VM_SENSOR_NAME = "VirtualMachineSensor"
 
-var regexPatternIPv4 = regexp.MustCompile(`^(?:(?:25[0-5]|2[0-4][0-9]|1[0-9][0-9]|[1-9][0-9]|[0-9])\.){3}(?:25[0-5]|2[0-4][0-9]|1[0-9][0-9]|[1-9][0-9]|[0-9])$`)
-
 type VirtualMachineSensor struct {
 	logger.SensorLogger
 	metricsCollector *sensormetrics.SensorMetricsCollector
@@ -36,13 +36,13 @@ type VirtualMachineSensor struct {
 	sensorLock       sync.Mutex
 	manualRefresh    chan struct{}
 	stopChan         chan struct{}
-	config           config.SensorConfig
+	config           config.VirtualMachineSensorConfig
 
 	// moType       string
 	// moProperties []string
 }
 
-func NewVirtualMachineSensor(scraper *VCenterScraper, config config.SensorConfig, l *slog.Logger) *VirtualMachineSensor {
+func NewVirtualMachineSensor(scraper *VCenterScraper, config config.VirtualMachineSensorConfig, l *slog.Logger) *VirtualMachineSensor {
 	var mc *sensormetrics.SensorMetricsCollector = sensormetrics.NewAvgSensorMetricsCollector(100)
 	var sm *sensormetrics.StatusMonitor = sensormetrics.NewStatusMonitor()
 	var sensor VirtualMachineSensor = VirtualMachineSensor{
@@ -228,12 +228,15 @@ func (s *VirtualMachineSensor) queryVmsForHost(ctx context.Context, scraper *VCe
 			//"datatore",
 			"guest", //(only for advanced network)
 			// "guestHeartbeatStatus", //(not sure)
-			// "network",
+			"network",
 			"parent",
+			"parentVApp",
 			// "resourceConfig",
 			"resourcePool",
 			"runtime",
-			// "snapshot",
+			"snapshot",
+			"layoutEx",
+			"storage",
 			"summary",
 		},
 		&items,
@@ -243,9 +246,20 @@ func (s *VirtualMachineSensor) queryVmsForHost(ctx context.Context, scraper *VCe
 		return nil, err
 	}
 
+	networkNames := s.queryNetworkNames(ctx, client.Client, items)
+
 	oVMs := []objects.VirtualMachine{}
 	for _, item := range items {
-		oVMs = append(oVMs, ConvertToVirtualMachine(ctx, scraper, item, time.Now()))
+		oVM := ConvertToVirtualMachine(ctx, scraper, item, time.Now())
+		if s.config.ConfigSettings {
+			oVM.ConfigSettings = ExtractConfigSettingsFromVM(item)
+		}
+		for i, nic := range oVM.NIC {
+			if name, ok := networkNames[nic.NetworkID]; ok {
+				oVM.NIC[i].Network = name
+			}
+		}
+		oVMs = append(oVMs, oVM)
 	}
 
 	s.metricsCollector.UploadStats(sensorStopwatch.GetStats())
@@ -253,6 +267,35 @@ func (s *VirtualMachineSensor) queryVmsForHost(ctx context.Context, scraper *VCe
 	return oVMs, nil
 }
 
+// queryNetworkNames returns the names of all networks and portgroups the vms
+// are connected to, mapped on the value of their reference
+func (s *VirtualMachineSensor) queryNetworkNames(ctx context.Context, client *vim25.Client, vms []mo.VirtualMachine) map[string]string {
+	refs := []types.ManagedObjectReference{}
+	for _, vm := range vms {
+		for _, ref := range vm.Network {
+			if !slices.Contains(refs, ref) {
+				refs = append(refs, ref)
+			}
+		}
+	}
+
+	result := map[string]string{}
+	if len(refs) == 0 {
+		return result
+	}
+
+	var networks []mo.Network
+	err := property.DefaultCollector(client).Retrieve(ctx, refs, []string{"name"}, &networks)
+	if err != nil {
+		s.SensorLogger.Warn("failed to get network names", "err", err)
+		return result
+	}
+	for _, network := range networks {
+		result[network.Self.Value] = network.Name
+	}
+	return result
+}
+
 func (s *VirtualMachineSensor) StopRefresher(ctx context.Context) {
 	close(s.stopChan)
 }
@@ -308,17 +351,38 @@ func ConvertToVirtualMachine(ctx context.Context, scraper *VCenterScraper, vm mo
 		parent = &p
 	}
 
+	var parentVApp *objects.ManagedObjectReference
+	if vm.ParentVApp != nil {
+		p := objects.NewManagedObjectReferenceFromVMwareRef(*vm.ParentVApp)
+		parentVApp = &p
+	} else if vm.ResourcePool != nil && vm.ResourcePool.Type == string(types.ManagedObjectTypesVirtualApp) {
+		p := objects.NewManagedObjectReferenceFromVMwareRef(*vm.ResourcePool)
+		parentVApp = &p
+	}
+
 	virtualMachine := objects.VirtualMachine{
-		Timestamp: t,
-		Name:      vm.Name,
-		Self:      self,
-		Parent:    parent,
+		Timestamp:  t,
+		Name:       vm.Name,
+		Self:       self,
+		Parent:     parent,
+		ParentVApp: parentVApp,
 	}
 
 	if virtualMachine.Parent != nil {
 		parentChain := scraper.DB.GetParentChain(ctx, *virtualMachine.Parent)
 		virtualMachine.Datacenter = parentChain.DC
+		virtualMachine.FolderPath = parentChain.FolderPath()
 		// virtualMachine.Cluster = parentChain.Cluster
+	} else if virtualMachine.ParentVApp != nil {
+		parentChain := scraper.DB.GetParentChain(ctx, *virtualMachine.ParentVApp)
+		virtualMachine.Datacenter = parentChain.DC
+		virtualMachine.FolderPath = parentChain.FolderPath()
+	}
+
+	if virtualMachine.ParentVApp != nil {
+		if vApp := scraper.DB.GetVirtualApp(ctx, *virtualMachine.ParentVApp); vApp != nil {
+			virtualMachine.VApp = vApp.Name
+		}
 	}
 	mb := int64(1024 * 1024)
 	summary := vm.Summary
@@ -329,6 +393,7 @@ func ConvertToVirtualMachine(ctx context.Context, scraper *VCenterScraper, vm mo
 		virtualMachine.UUID = config.Uuid
 		virtualMachine.GuestID = config.GuestId
 		virtualMachine.Template = config.Template
+		virtualMachine.Annotation = config.Annotation
 		timeConfChanged, err := time.Parse(time.RFC3339Nano, config.ChangeVersion)
 		if err == nil {
 			virtualMachine.TimeConfigChanged = timeConfChanged
@@ -370,9 +435,13 @@ func ConvertToVirtualMachine(ctx context.Context, scraper *VCenterScraper, vm mo
 	virtualMachine.PowerState = string(runtime.PowerState)
 	if hostRef := runtime.Host; hostRef != nil {
 		oRef := objects.NewManagedObjectReferenceFromVMwareRef(*hostRef)
+		// Keep the host id when the host is not (yet) known, the cluster
+		// rule violations depend on it
+		virtualMachine.HostInfo.HostID = oRef.ID()
 		if host := scraper.DB.GetHost(ctx, oRef); host != nil {
 			virtualMachine.HostInfo = objects.VirtualMachineHostInfo{
 				Host:       host.Name,
+				HostID:     host.Self.ID(),
 				Datacenter: host.Datacenter,
 				Cluster:    host.Cluster,
 			}
@@ -404,83 +473,341 @@ func ConvertToVirtualMachine(ctx context.Context, scraper *VCenterScraper, vm mo
 		virtualMachine.GuestToolsStatus = string(guest.ToolsStatus)
 	}
 
+	virtualMachine.NIC = ExtractNICsFromVM(vm)
+
 	if guest := vm.Guest; guest != nil {
-		guestNets := []objects.VirtualMachineGuestNet{}
-		for _, net := range guest.Net {
-			if ipConfig := net.IpConfig; ipConfig != nil {
-				for _, address := range ipConfig.IpAddress {
-					if match := regexPatternIPv4.MatchString(address.IpAddress); match {
-						virtualMachine.GuestNetwork = append(virtualMachine.GuestNetwork, objects.VirtualMachineGuestNet{
-							MacAddress: net.MacAddress,
-							IpAddress:  address.IpAddress,
-							Connected:  net.Connected,
-						})
-					}
-				}
-			}
+		virtualMachine.GuestHostname = guest.HostName
+		virtualMachine.GuestFullName = guest.GuestFullName
+		for _, disk := range guest.Disk {
+			virtualMachine.GuestDisk = append(virtualMachine.GuestDisk, objects.VirtualMachineGuestDisk{
+				DiskPath:       disk.DiskPath,
+				FilesystemType: disk.FilesystemType,
+				Capacity:       float64(disk.Capacity),
+				FreeSpace:      float64(disk.FreeSpace),
+			})
 		}
-		virtualMachine.GuestNetwork = helper.DedupFunc(guestNets, func(i1, i2 objects.VirtualMachineGuestNet) bool {
-			return i1.IpAddress != i2.IpAddress && i1.MacAddress != i2.MacAddress
-		})
 	}
 
 	virtualMachine.Disk = ExtractDisksFromVM(vm)
+	for i, disk := range virtualMachine.Disk {
+		if disk.Datastore != nil {
+			if ds := scraper.DB.GetDatastore(ctx, *disk.Datastore); ds != nil {
+				virtualMachine.Disk[i].DatastoreName = ds.Name
+				// vSAN and vVol disks both use the flat backing with a backing
+				// object id, only the datastore tells them apart
+				if disk.BackingType == "flat" && (ds.Kind == "vsan" || ds.Kind == "vvol") {
+					virtualMachine.Disk[i].BackingType = ds.Kind
+				}
+			}
+		}
+	}
+	virtualMachine.DatastoreUsage = ExtractDatastoreUsageFromVM(vm)
+	for i, usage := range virtualMachine.DatastoreUsage {
+		if ds := scraper.DB.GetDatastore(ctx, usage.Datastore); ds != nil {
+			virtualMachine.DatastoreUsage[i].DatastoreName = ds.Name
+		}
+	}
 
-	if snapshots := vm.Snapshot; snapshots != nil {
-		virtualMachine.Snapshot = append(virtualMachine.Snapshot, walkSnapshotTree(snapshots.RootSnapshotList)...)
+	virtualMachine.Snapshot = append(virtualMachine.Snapshot, ExtractSnapshotsFromVM(vm)...)
+	for _, snap := range virtualMachine.Snapshot {
+		virtualMachine.SnapshotSize += snap.Size
+	}
+	virtualMachine.NumSnapshot = float64(len(virtualMachine.Snapshot))
+	if consolidationNeeded := vm.Runtime.ConsolidationNeeded; consolidationNeeded != nil {
+		virtualMachine.ConsolidationNeeded = *consolidationNeeded
 	}
 
 	if rPool := vm.ResourcePool; rPool != nil {
 		rPoolRef := objects.NewManagedObjectReferenceFromVMwareRef(*rPool)
+		virtualMachine.ResourcePoolRef = &rPoolRef
 
 		if rp := scraper.DB.GetResourcePool(ctx, rPoolRef); rp != nil {
 			virtualMachine.ResourcePool = rp.Name
 		}
 	}
 
-	virtualMachine.IsecAnnotation = GetIsecAnnotation(vm)
-
 	return virtualMachine
 }
 
-func walkSnapshotTree(snaps []types.VirtualMachineSnapshotTree) []objects.VirtualMachineSnapshot {
+func ExtractSnapshotsFromVM(vm mo.VirtualMachine) []objects.VirtualMachineSnapshot {
+	if vm.Snapshot == nil {
+		return []objects.VirtualMachineSnapshot{}
+	}
+	return walkSnapshotTree(vm, vm.Snapshot.RootSnapshotList, nil, 1)
+}
+
+func walkSnapshotTree(vm mo.VirtualMachine, snaps []types.VirtualMachineSnapshotTree, parent *types.ManagedObjectReference, depth int) []objects.VirtualMachineSnapshot {
 	result := []objects.VirtualMachineSnapshot{}
 	for _, snap := range snaps {
-		result = append(result, objects.VirtualMachineSnapshot{
+		current := vm.Snapshot.CurrentSnapshot != nil && vm.Snapshot.CurrentSnapshot.Value == snap.Snapshot.Value
+		oSnap := objects.VirtualMachineSnapshot{
+			ID:           snap.Snapshot.Value,
 			Name:         snap.Name,
 			CreationTime: snap.CreateTime,
-		})
-		result = append(result, walkSnapshotTree(snap.ChildSnapshotList)...)
+			Depth:        float64(depth),
+			// Snapshots without memory always have the poweredOff state,
+			// suspended snapshots include the suspended memory
+			Memory:  snap.State == types.VirtualMachinePowerStatePoweredOn || snap.State == types.VirtualMachinePowerStateSuspended,
+			Current: current,
+		}
+		if vm.LayoutEx != nil {
+			oSnap.Size = float64(object.SnapshotSize(snap.Snapshot, parent, vm.LayoutEx, current))
+		}
+		result = append(result, oSnap)
+		result = append(result, walkSnapshotTree(vm, snap.ChildSnapshotList, &snap.Snapshot, depth+1)...)
 	}
 	return result
 }
 
 func ExtractDisksFromVM(vm mo.VirtualMachine) []objects.VirtualMachineDisk {
 	result := []objects.VirtualMachineDisk{}
-	if vm.Config != nil {
-		disks := object.VirtualDeviceList(vm.Config.Hardware.Device).SelectByType((*types.VirtualDisk)(nil))
-		for _, d := range disks {
-			disk := d.(*types.VirtualDisk)
-			info := disk.Backing.(*types.VirtualDiskFlatVer2BackingInfo)
-			result = append(result, objects.VirtualMachineDisk{
-				UUID:            info.Uuid,
-				VMDKFile:        info.FileName,
-				Capacity:        float64(disk.CapacityInBytes),
-				ThinProvisioned: *info.ThinProvisioned,
-			})
+	if vm.Config == nil {
+		return result
+	}
+
+	devices := object.VirtualDeviceList(vm.Config.Hardware.Device)
+	for _, d := range devices.SelectByType((*types.VirtualDisk)(nil)) {
+		disk := d.(*types.VirtualDisk)
+		oDisk := objects.VirtualMachineDisk{
+			Key:      disk.Key,
+			Capacity: float64(disk.CapacityInBytes),
+		}
+		if info := disk.DeviceInfo; info != nil {
+			oDisk.Label = info.GetDescription().Label
+		}
+		if disk.UnitNumber != nil {
+			oDisk.UnitNumber = *disk.UnitNumber
+		}
+		if c, ok := devices.FindByKey(disk.ControllerKey).(types.BaseVirtualController); ok {
+			oDisk.ControllerType = devices.Type(c.(types.BaseVirtualDevice))
+			oDisk.BusNumber = c.GetVirtualController().BusNumber
+		}
+
+		if backing, ok := disk.Backing.(types.BaseVirtualDeviceFileBackingInfo); ok {
+			fileBacking := backing.GetVirtualDeviceFileBackingInfo()
+			oDisk.VMDKFile = fileBacking.FileName
+			if fileBacking.Datastore != nil {
+				ref := objects.NewManagedObjectReferenceFromVMwareRef(*fileBacking.Datastore)
+				oDisk.Datastore = &ref
+			}
+		}
+
+		switch backing := disk.Backing.(type) {
+		case *types.VirtualDiskFlatVer2BackingInfo:
+			oDisk.BackingType = "flat"
+			oDisk.UUID = backing.Uuid
+			oDisk.DiskMode = backing.DiskMode
+			if backing.ThinProvisioned != nil {
+				oDisk.ThinProvisioned = *backing.ThinProvisioned
+			}
+		case *types.VirtualDiskFlatVer1BackingInfo:
+			oDisk.BackingType = "flat"
+			oDisk.DiskMode = backing.DiskMode
+		case *types.VirtualDiskSeSparseBackingInfo:
+			oDisk.BackingType = "sesparse"
+			oDisk.UUID = backing.Uuid
+			oDisk.DiskMode = backing.DiskMode
+			oDisk.ThinProvisioned = true
+		case *types.VirtualDiskSparseVer2BackingInfo:
+			oDisk.BackingType = "sparse"
+			oDisk.UUID = backing.Uuid
+			oDisk.DiskMode = backing.DiskMode
+			oDisk.ThinProvisioned = true
+		case *types.VirtualDiskSparseVer1BackingInfo:
+			oDisk.BackingType = "sparse"
+			oDisk.DiskMode = backing.DiskMode
+			oDisk.ThinProvisioned = true
+		case *types.VirtualDiskRawDiskMappingVer1BackingInfo:
+			oDisk.BackingType = "rdm_" + backing.CompatibilityMode
+			oDisk.UUID = backing.Uuid
+			oDisk.DiskMode = backing.DiskMode
+		case *types.VirtualDiskRawDiskVer2BackingInfo:
+			oDisk.BackingType = "raw"
+			oDisk.UUID = backing.Uuid
+			oDisk.VMDKFile = backing.DescriptorFileName
+		case *types.VirtualDiskPartitionedRawDiskVer2BackingInfo:
+			oDisk.BackingType = "raw"
+			oDisk.UUID = backing.Uuid
+			oDisk.VMDKFile = backing.DescriptorFileName
+		case *types.VirtualDiskLocalPMemBackingInfo:
+			oDisk.BackingType = "pmem"
+			oDisk.UUID = backing.Uuid
+			oDisk.DiskMode = backing.DiskMode
+		default:
+			oDisk.BackingType = "unknown"
 		}
+
+		result = append(result, oDisk)
 	}
 	return result
 }
 
-func GetIsecAnnotation(vm mo.VirtualMachine) *objects.IsecAnnotation {
-	tmp := objects.IsecAnnotation{
-		Service:     "not defined",
-		Responsable: "not defined",
-		Criticality: "not defined",
+func ExtractNICsFromVM(vm mo.VirtualMachine) []objects.VirtualMachineNIC {
+	result := []objects.VirtualMachineNIC{}
+	if vm.Config == nil {
+		return result
 	}
-	if config := vm.Config; config != nil {
-		_ = json.Unmarshal([]byte(config.Annotation), &tmp)
+
+	guestNics := map[int32]types.GuestNicInfo{}
+	if vm.Guest != nil {
+		for _, guestNic := range vm.Guest.Net {
+			guestNics[guestNic.DeviceConfigId] = guestNic
+		}
+	}
+
+	devices := object.VirtualDeviceList(vm.Config.Hardware.Device)
+	for _, d := range devices.SelectByType((*types.VirtualEthernetCard)(nil)) {
+		card := d.(types.BaseVirtualEthernetCard).GetVirtualEthernetCard()
+		nic := objects.VirtualMachineNIC{
+			Key:         card.Key,
+			AdapterType: strings.ToLower(strings.TrimPrefix(devices.TypeName(d), "Virtual")),
+			MacAddress:  card.MacAddress,
+		}
+		if info := card.DeviceInfo; info != nil {
+			nic.Label = info.GetDescription().Label
+		}
+		if connectable := card.Connectable; connectable != nil {
+			nic.Connected = connectable.Connected
+			nic.StartConnected = connectable.StartConnected
+		}
+
+		switch backing := card.Backing.(type) {
+		case *types.VirtualEthernetCardNetworkBackingInfo:
+			nic.BackingType = "network"
+			nic.Network = backing.DeviceName
+			if backing.Network != nil {
+				nic.NetworkID = backing.Network.Value
+			}
+		case *types.VirtualEthernetCardDistributedVirtualPortBackingInfo:
+			nic.BackingType = "dvs"
+			nic.NetworkID = backing.Port.PortgroupKey
+			nic.DVSUUID = backing.Port.SwitchUuid
+			nic.PortKey = backing.Port.PortKey
+		case *types.VirtualEthernetCardOpaqueNetworkBackingInfo:
+			nic.BackingType = "opaque"
+			nic.NetworkID = backing.OpaqueNetworkId
+		}
+
+		if guestNic, ok := guestNics[card.Key]; ok {
+			nic.IpAddress = extractGuestIPs(guestNic)
+		}
+
+		result = append(result, nic)
+	}
+	return result
+}
+
+// extractGuestIPs returns all ip addresses reported by the guest tools for a
+// nic. The prefix length is only known when the guest reports the ip config.
+func extractGuestIPs(guestNic types.GuestNicInfo) []objects.VirtualMachineIpAddress {
+	result := []objects.VirtualMachineIpAddress{}
+	if ipConfig := guestNic.IpConfig; ipConfig != nil {
+		for _, address := range ipConfig.IpAddress {
+			result = append(result, newVirtualMachineIpAddress(address.IpAddress, address.PrefixLength))
+		}
+		return result
 	}
-	return &tmp
+	for _, address := range guestNic.IpAddress {
+		result = append(result, newVirtualMachineIpAddress(address, 0))
+	}
+	return result
+}
+
+func newVirtualMachineIpAddress(address string, prefixLength int32) objects.VirtualMachineIpAddress {
+	ip := objects.VirtualMachineIpAddress{
+		Address:      address,
+		PrefixLength: float64(prefixLength),
+		Family:       "ipv6",
+	}
+	if parsed := net.ParseIP(address); parsed != nil && parsed.To4() != nil {
+		ip.Family = "ipv4"
+	}
+	return ip
+}
+
+func ExtractConfigSettingsFromVM(vm mo.VirtualMachine) *objects.VirtualMachineConfigSettings {
+	config := vm.Config
+	if config == nil {
+		return nil
+	}
+
+	settings := objects.VirtualMachineConfigSettings{
+		HardwareVersion: config.Version,
+		Firmware:        config.Firmware,
+		Encrypted:       config.KeyId != nil,
+		CPULimit:        -1,
+		MemoryLimit:     -1,
+	}
+	if tools := config.Tools; tools != nil {
+		settings.ToolsUpgradePolicy = tools.ToolsUpgradePolicy
+	}
+	if config.CpuHotAddEnabled != nil {
+		settings.CPUHotAdd = *config.CpuHotAddEnabled
+	}
+	if config.MemoryHotAddEnabled != nil {
+		settings.MemoryHotAdd = *config.MemoryHotAddEnabled
+	}
+	if bootOptions := config.BootOptions; bootOptions != nil && bootOptions.EfiSecureBootEnabled != nil {
+		settings.SecureBoot = *bootOptions.EfiSecureBootEnabled
+	}
+	if latency := config.LatencySensitivity; latency != nil {
+		settings.LatencySensitivity = string(latency.Level)
+	}
+	if alloc := config.CpuAllocation; alloc != nil && alloc.Limit != nil {
+		settings.CPULimit = float64(*alloc.Limit)
+	}
+	if alloc := config.MemoryAllocation; alloc != nil && alloc.Limit != nil && *alloc.Limit >= 0 {
+		settings.MemoryLimit = float64(*alloc.Limit * 1024 * 1024)
+	}
+
+	devices := object.VirtualDeviceList(config.Hardware.Device)
+	settings.VTPM = len(devices.SelectByType((*types.VirtualTPM)(nil))) > 0
+
+	removableTypes := map[string]types.BaseVirtualDevice{
+		"cdrom":  (*types.VirtualCdrom)(nil),
+		"floppy": (*types.VirtualFloppy)(nil),
+	}
+	for deviceType, kind := range removableTypes {
+		for _, d := range devices.SelectByType(kind) {
+			device := d.GetVirtualDevice()
+			removable := objects.VirtualMachineRemovableDevice{
+				Type: deviceType,
+			}
+			if info := device.DeviceInfo; info != nil {
+				removable.Label = info.GetDescription().Label
+			}
+			if connectable := device.Connectable; connectable != nil {
+				removable.Connected = connectable.Connected
+				removable.StartConnected = connectable.StartConnected
+			}
+			switch backing := device.Backing.(type) {
+			case types.BaseVirtualDeviceFileBackingInfo:
+				removable.Image = backing.GetVirtualDeviceFileBackingInfo().FileName
+			case types.BaseVirtualDeviceDeviceBackingInfo:
+				removable.Image = backing.GetVirtualDeviceDeviceBackingInfo().DeviceName
+			}
+			settings.RemovableDevices = append(settings.RemovableDevices, removable)
+		}
+	}
+	slices.SortFunc(settings.RemovableDevices, func(a, b objects.VirtualMachineRemovableDevice) int {
+		return strings.Compare(a.Type+a.Label, b.Type+b.Label)
+	})
+
+	return &settings
+}
+
+func ExtractDatastoreUsageFromVM(vm mo.VirtualMachine) []objects.VirtualMachineDatastoreUsage {
+	result := []objects.VirtualMachineDatastoreUsage{}
+	if vm.Storage == nil {
+		return result
+	}
+	for _, usage := range vm.Storage.PerDatastoreUsage {
+		result = append(result, objects.VirtualMachineDatastoreUsage{
+			Datastore:   objects.NewManagedObjectReferenceFromVMwareRef(usage.Datastore),
+			Committed:   float64(usage.Committed),
+			Uncommitted: float64(usage.Uncommitted),
+			Unshared:    float64(usage.Unshared),
+		})
+	}
+	return result
 }
@@ -0,0 +1,289 @@
+package scraper
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/sanderdescamps/govc_exporter/internal/database"
+	"github.com/sanderdescamps/govc_exporter/internal/database/objects"
+)
+
+// All edges point from parent to child, so the subtree of a node contains
+// everything below it.
+const (
+	TopologyEdgeContains = "contains"
+	TopologyEdgeRuns     = "runs"
+	TopologyEdgeStores   = "stores"
+)
+
+type TopologyNode struct {
+	ID   string `json:"id"`
+	Type string `json:"type"`
+	Name string `json:"name"`
+}
+
+type TopologyEdge struct {
+	From     string `json:"from"`
+	To       string `json:"to"`
+	Relation string `json:"relation"`
+}
+
+type Topology struct {
+	Nodes []TopologyNode `json:"nodes"`
+	Edges []TopologyEdge `json:"edges"`
+}
+
+var topologyDotShapes = map[string]string{
+	objects.ManagedObjectTypesDatacenter.String():     "folder",
+	objects.ManagedObjectTypesCluster.String():        "box3d",
+	objects.ManagedObjectTypesHost.String():           "box",
+	objects.ManagedObjectTypesVirtualMachine.String(): "ellipse",
+	objects.ManagedObjectTypesDatastore.String():      "cylinder",
+	objects.ManagedObjectTypesStoragePod.String():     "tab",
+	objects.ManagedObjectTypesResourcePool.String():   "hexagon",
+	objects.ManagedObjectTypesVirtualApp.String():     "hexagon",
+}
+
+type topologyBuilder struct {
+	nodes map[string]TopologyNode
+	edges map[TopologyEdge]bool
+}
+
+func (b *topologyBuilder) addNode(ref objects.ManagedObjectReference, name string) {
+	b.nodes[ref.ID()] = TopologyNode{
+		ID:   ref.ID(),
+		Type: ref.Type.String(),
+		Name: name,
+	}
+}
+
+// Edges are only added when both ends are known, objects that expired from
+// the database would otherwise show up as dangling references.
+func (b *topologyBuilder) addEdge(from string, to string, relation string) {
+	if _, ok := b.nodes[from]; !ok {
+		return
+	}
+	if _, ok := b.nodes[to]; !ok {
+		return
+	}
+	b.edges[TopologyEdge{From: from, To: to, Relation: relation}] = true
+}
+
+func (b *topologyBuilder) topology() Topology {
+	result := Topology{
+		Nodes: []TopologyNode{},
+		Edges: []TopologyEdge{},
+	}
+	for _, node := range b.nodes {
+		result.Nodes = append(result.Nodes, node)
+	}
+	for edge := range b.edges {
+		result.Edges = append(result.Edges, edge)
+	}
+	slices.SortFunc(result.Nodes, func(a, b TopologyNode) int {
+		if c := strings.Compare(a.Type, b.Type); c != 0 {
+			return c
+		}
+		return strings.Compare(a.ID, b.ID)
+	})
+	slices.SortFunc(result.Edges, func(a, b TopologyEdge) int {
+		if c := strings.Compare(a.From, b.From); c != 0 {
+			return c
+		}
+		return strings.Compare(a.To, b.To)
+	})
+	return result
+}
+
+// Build the inventory graph from the objects in the database:
+// datacenter -> cluster -> host -> vm, storage pod -> datastore -> vm and
+// resource pool (or vApp) -> vm.
+func BuildTopology(ctx context.Context, db database.Database) (Topology, error) {
+	dcs, err := db.GetAllDatacenter(ctx)
+	if err != nil {
+		return Topology{}, fmt.Errorf("failed to get datacenters: %w", err)
+	}
+	clusters, err := db.GetAllCluster(ctx)
+	if err != nil {
+		return Topology{}, fmt.Errorf("failed to get clusters: %w", err)
+	}
+	hosts, err := db.GetAllHost(ctx)
+	if err != nil {
+		return Topology{}, fmt.Errorf("failed to get hosts: %w", err)
+	}
+	vms, err := db.GetAllVM(ctx)
+	if err != nil {
+		return Topology{}, fmt.Errorf("failed to get vms: %w", err)
+	}
+	datastores, err := db.GetAllDatastore(ctx)
+	if err != nil {
+		return Topology{}, fmt.Errorf("failed to get datastores: %w", err)
+	}
+	spods, err := db.GetAllStoragePod(ctx)
+	if err != nil {
+		return Topology{}, fmt.Errorf("failed to get storage pods: %w", err)
+	}
+	rPools, err := db.GetAllResourcePool(ctx)
+	if err != nil {
+		return Topology{}, fmt.Errorf("failed to get resource pools: %w", err)
+	}
+	vApps, err := db.GetAllVirtualApp(ctx)
+	if err != nil {
+		return Topology{}, fmt.Errorf("failed to get virtual apps: %w", err)
+	}
+
+	b := &topologyBuilder{
+		nodes: map[string]TopologyNode{},
+		edges: map[TopologyEdge]bool{},
+	}
+
+	dcByName := map[string][]string{}
+	for _, dc := range dcs {
+		b.addNode(dc.Self, dc.Name)
+		dcByName[dc.Name] = append(dcByName[dc.Name], dc.Self.ID())
+	}
+	// Datacenter names are only unique within their folder, prefer the
+	// datacenter in the parent chain and only fall back on a unique name
+	// when the folders are not collected.
+	datacenterID := func(parent *objects.ManagedObjectReference, name string) string {
+		if parent != nil {
+			for _, p := range db.GetParentChain(ctx, *parent).Chain {
+				if id, found := strings.CutPrefix(p, objects.ManagedObjectTypesDatacenter.String()+":"); found {
+					return id
+				}
+			}
+		}
+		if ids := dcByName[name]; len(ids) == 1 {
+			return ids[0]
+		}
+		return ""
+	}
+	for _, cluster := range clusters {
+		b.addNode(cluster.Self, cluster.Name)
+	}
+	for _, host := range hosts {
+		b.addNode(host.Self, host.Name)
+	}
+	for _, vm := range vms {
+		b.addNode(vm.Self, vm.Name)
+	}
+	for _, ds := range datastores {
+		b.addNode(ds.Self, ds.Name)
+	}
+	for _, spod := range spods {
+		b.addNode(spod.Self, spod.Name)
+	}
+	for _, rPool := range rPools {
+		b.addNode(rPool.Self, rPool.Name)
+	}
+	for _, vApp := range vApps {
+		b.addNode(vApp.Self, vApp.Name)
+	}
+
+	for _, cluster := range clusters {
+		b.addEdge(datacenterID(cluster.Parent, cluster.Datacenter), cluster.Self.ID(), TopologyEdgeContains)
+	}
+	for _, host := range hosts {
+		if host.Parent != nil && host.Parent.Type == objects.ManagedObjectTypesCluster {
+			b.addEdge(host.Parent.ID(), host.Self.ID(), TopologyEdgeContains)
+		} else {
+			// standalone host
+			b.addEdge(datacenterID(host.Parent, host.Datacenter), host.Self.ID(), TopologyEdgeContains)
+		}
+	}
+	for _, vm := range vms {
+		b.addEdge(vm.HostInfo.HostID, vm.Self.ID(), TopologyEdgeRuns)
+		if vm.ResourcePoolRef != nil {
+			b.addEdge(vm.ResourcePoolRef.ID(), vm.Self.ID(), TopologyEdgeContains)
+		}
+	}
+	for _, ds := range datastores {
+		for _, vmID := range ds.VMs {
+			b.addEdge(ds.Self.ID(), vmID, TopologyEdgeStores)
+		}
+	}
+	for _, spod := range spods {
+		for _, ds := range spod.Datastores {
+			b.addEdge(spod.Self.ID(), ds.ID(), TopologyEdgeContains)
+		}
+	}
+
+	return b.topology(), nil
+}
+
+// Return the part of the topology that is reachable from the given root
+// nodes. A root can be referenced by id or by name.
+func (t Topology) Subtree(roots ...string) Topology {
+	children := map[string][]string{}
+	for _, edge := range t.Edges {
+		children[edge.From] = append(children[edge.From], edge.To)
+	}
+
+	keep := map[string]bool{}
+	queue := []string{}
+	for _, node := range t.Nodes {
+		if slices.Contains(roots, node.ID) || slices.Contains(roots, node.Name) {
+			keep[node.ID] = true
+			queue = append(queue, node.ID)
+		}
+	}
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		for _, child := range children[id] {
+			if !keep[child] {
+				keep[child] = true
+				queue = append(queue, child)
+			}
+		}
+	}
+
+	result := Topology{
+		Nodes: []TopologyNode{},
+		Edges: []TopologyEdge{},
+	}
+	for _, node := range t.Nodes {
+		if keep[node.ID] {
+			result.Nodes = append(result.Nodes, node)
+		}
+	}
+	for _, edge := range t.Edges {
+		if keep[edge.From] && keep[edge.To] {
+			result.Edges = append(result.Edges, edge)
+		}
+	}
+	return result
+}
+
+// Write the topology as a GraphViz DOT digraph
+func (t Topology) WriteDOT(w io.Writer) error {
+	var sb strings.Builder
+	sb.WriteString("digraph topology {\n")
+	sb.WriteString("  rankdir=LR;\n")
+	for _, node := range t.Nodes {
+		shape, ok := topologyDotShapes[node.Type]
+		if !ok {
+			shape = "box"
+		}
+		fmt.Fprintf(&sb, "  %s [label=%s, shape=%s, tooltip=%s];\n",
+			dotQuote(node.ID), dotQuote(node.Name), shape, dotQuote(node.Type))
+	}
+	for _, edge := range t.Edges {
+		fmt.Fprintf(&sb, "  %s -> %s [label=%s];\n",
+			dotQuote(edge.From), dotQuote(edge.To), dotQuote(edge.Relation))
+	}
+	sb.WriteString("}\n")
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+func dotQuote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	s = strings.ReplaceAll(s, "\n", `\n`)
+	return `"` + s + `"`
+}